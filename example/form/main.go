@@ -1,7 +1,8 @@
 // Form example — demonstrates interactive form with validation using the App framework.
 //
-// This example shows multiple form types (registration, contact, survey) accessible
+// This example shows multiple form types (registration, contact, survey, settings) accessible
 // through a menu. Each form has custom validation, error handling, and submission flow.
+// The settings form is generated from a tagged struct with model.NewFormFor.
 //
 // Navigation:
 //
@@ -15,6 +16,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -31,8 +33,21 @@ var (
 	mainMenu   = NewFormMenu()
 	emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 	phoneRegex = regexp.MustCompile(`^\+?[0-9]{10,15}$`)
+	settings   = Settings{ServerURL: "https://localhost", Workers: 4, Theme: "dark"}
 )
 
+// Settings is edited by the settings form, built from its form tags.
+type Settings struct {
+	ServerURL string `form:"label=Server URL,required,placeholder=https://…,regex=^https?://"`
+	Workers   int    `form:"label=Workers,min=1,max=32"`
+	Theme     string `form:"label=Theme,oneof=dark|light|auto"`
+	Telemetry bool   `form:"label=Telemetry"`
+	Proxy     struct {
+		Host string `form:"label=Host,placeholder=proxy.local"`
+		Port uint16 `form:"label=Port"`
+	} `form:"label=Proxy"`
+}
+
 // ── FormMenu ────────────────────────────────────────────────────────
 
 type FormMenu struct {
@@ -46,6 +61,7 @@ func NewFormMenu() *FormMenu {
 			{Title: "User Registration", Subtitle: "Sign up with name, email, age, username"},
 			{Title: "Contact Form", Subtitle: "Get in touch: name, email, phone, message"},
 			{Title: "Survey Form", Subtitle: "Quick feedback: rating, comments, recommend"},
+			{Title: "Settings Form", Subtitle: "Generated from a tagged Go struct"},
 		},
 	}
 }
@@ -75,6 +91,8 @@ func (m *FormMenu) Action(app *model.App, index int) (model.Page, tea.Cmd) {
 		return NewFormPage(app, "contact"), nil
 	case 2:
 		return NewFormPage(app, "survey"), nil
+	case 3:
+		return NewFormPage(app, "settings"), nil
 	}
 	return nil, nil
 }
@@ -103,6 +121,12 @@ func NewFormPage(app *model.App, formType string) *FormPage {
 		p.form = p.createContactForm()
 	case "survey":
 		p.form = p.createSurveyForm()
+	case "settings":
+		form, err := model.NewFormFor(&settings)
+		if err != nil {
+			panic(err)
+		}
+		p.form = form
 	}
 
 	p.form.Focus()
@@ -110,7 +134,7 @@ func NewFormPage(app *model.App, formType string) *FormPage {
 }
 
 func (p *FormPage) createRegistrationForm() *model.Form {
-	return model.NewForm([]model.FormField{
+	form := model.NewForm([]model.FormField{
 		{
 			Key:         "name",
 			Label:       "Full Name",
@@ -159,6 +183,26 @@ func (p *FormPage) createRegistrationForm() *model.Form {
 				}
 				return nil
 			},
+			ValidateAsync: checkUsernameFree,
+		},
+		{
+			Key:         "password",
+			Label:       "Password",
+			Placeholder: "ctrl+r to reveal",
+			Type:        model.FieldPassword,
+			Required:    true,
+			Validate: func(value string) error {
+				if len(value) < 8 {
+					return fmt.Errorf("must be at least 8 characters")
+				}
+				return nil
+			},
+		},
+		{
+			Key:      "confirm",
+			Label:    "Confirm",
+			Type:     model.FieldPassword,
+			Required: true,
 		},
 		{
 			Key:         "bio",
@@ -167,6 +211,27 @@ func (p *FormPage) createRegistrationForm() *model.Form {
 			Required:    false,
 		},
 	})
+	form.AddValidator(func(values map[string]string) map[string]error {
+		if values["confirm"] != "" && values["confirm"] != values["password"] {
+			return map[string]error{"confirm": fmt.Errorf("passwords do not match")}
+		}
+		return nil
+	})
+	return form
+}
+
+// checkUsernameFree stands in for a call to a user service.
+func checkUsernameFree(ctx context.Context, username string) error {
+	select {
+	case <-time.After(800 * time.Millisecond):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	switch strings.ToLower(username) {
+	case "admin", "root", "johndoe":
+		return fmt.Errorf("username %q is taken", username)
+	}
+	return nil
 }
 
 func (p *FormPage) createContactForm() *model.Form {
@@ -189,11 +254,21 @@ func (p *FormPage) createContactForm() *model.Form {
 				return nil
 			},
 		},
+		{
+			Key:     "reply",
+			Label:   "Reply by",
+			Type:    model.FieldRadio,
+			Options: []string{"email", "phone"},
+			Default: "email",
+		},
 		{
 			Key:         "phone",
 			Label:       "Phone",
 			Placeholder: "+1234567890",
-			Required:    false,
+			Required:    true,
+			Visible: func(values map[string]string) bool {
+				return values["reply"] == "phone"
+			},
 			Validate: func(value string) error {
 				if !phoneRegex.MatchString(value) {
 					return fmt.Errorf("invalid phone number (10-15 digits)")
@@ -211,6 +286,7 @@ func (p *FormPage) createContactForm() *model.Form {
 			Key:         "message",
 			Label:       "Message",
 			Placeholder: "Your message here...",
+			Type:        model.FieldTextarea,
 			Required:    true,
 			Validate: func(value string) error {
 				if len(value) < 10 {
@@ -225,52 +301,46 @@ func (p *FormPage) createContactForm() *model.Form {
 func (p *FormPage) createSurveyForm() *model.Form {
 	return model.NewForm([]model.FormField{
 		{
-			Key:         "rating",
-			Label:       "Rating",
-			Placeholder: "1-5",
-			Required:    true,
-			Validate: func(value string) error {
-				rating, err := strconv.Atoi(value)
-				if err != nil {
-					return fmt.Errorf("must be a number")
-				}
-				if rating < 1 || rating > 5 {
-					return fmt.Errorf("must be between 1 and 5")
-				}
-				return nil
-			},
+			Key:      "rating",
+			Label:    "Rating",
+			Type:     model.FieldNumber,
+			Min:      1,
+			Max:      5,
+			Default:  "3",
+			Required: true,
 		},
 		{
 			Key:         "experience",
 			Label:       "Experience",
-			Placeholder: "excellent/good/fair/poor",
+			Placeholder: "Choose one",
+			Type:        model.FieldSelect,
+			Options:     []string{"excellent", "good", "fair", "poor"},
 			Required:    true,
-			Validate: func(value string) error {
-				valid := map[string]bool{"excellent": true, "good": true, "fair": true, "poor": true}
-				if !valid[strings.ToLower(value)] {
-					return fmt.Errorf("must be: excellent, good, fair, or poor")
-				}
-				return nil
-			},
 		},
 		{
 			Key:         "recommend",
 			Label:       "Recommend?",
-			Placeholder: "yes/no",
-			Required:    true,
-			Validate: func(value string) error {
-				lower := strings.ToLower(value)
-				if lower != "yes" && lower != "no" {
-					return fmt.Errorf("must be yes or no")
-				}
-				return nil
-			},
+			Placeholder: "I would recommend this",
+			Type:        model.FieldCheckbox,
+		},
+		{
+			Key:     "contact",
+			Label:   "Contact me",
+			Type:    model.FieldRadio,
+			Options: []string{"never", "email", "phone"},
+			Default: "never",
+		},
+		{
+			Key:         "visited",
+			Label:       "Visited on",
+			Type:        model.FieldDate,
+			Placeholder: "YYYY-MM-DD (optional)",
 		},
 		{
 			Key:         "comments",
 			Label:       "Comments",
 			Placeholder: "Additional feedback (optional)",
-			Required:    false,
+			Type:        model.FieldTextarea,
 		},
 	})
 }
@@ -353,6 +423,9 @@ func (p *FormPage) renderForm(ss style.StyleSet) string {
 	case "survey":
 		header = "📊 Quick Survey"
 		subtitle = "Help us improve. Share your feedback."
+	case "settings":
+		header = "⚙ Settings"
+		subtitle = "Built from the Settings struct; saved back into it on submit."
 	}
 
 	title := ss.Title.Render(header)
@@ -431,6 +504,8 @@ func (p *FormPage) renderSuccess(ss style.StyleSet) string {
 		if values["comments"] != "" {
 			details = append(details, formatField(ss, "Comments", values["comments"]))
 		}
+	case "settings":
+		details = append(details, formatField(ss, "Settings", fmt.Sprintf("%+v", settings)))
 	}
 
 	hint := ss.Muted.Render("Press b/esc to return to menu")
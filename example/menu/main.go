@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
@@ -67,6 +68,11 @@ func (m *MainMenu) ContextMenuItems(_ *model.App, index int) []model.ContextMenu
 		{ID: "play", Label: "󰐊  Play"},
 		{ID: "queue", Label: "󰆴  Add to Queue"},
 		{ID: "play_next", Label: "󰒭  Play Next"},
+		{ID: "playlist", Label: "󰲸  Add to Playlist", Children: []model.ContextMenuItem{
+			{ID: "liked", Label: "Liked Songs"},
+			{ID: "road_trip", Label: "Road Trip"},
+			{ID: "focus", Label: "Deep Focus"},
+		}},
 		{Separator: true},
 		{ID: "album", Label: "󰀥  Go to Album"},
 		{ID: "artist", Label: "󰠃  Go to Artist"},
@@ -89,7 +95,7 @@ func (m *MainMenu) ContextMenuAction(app *model.App, index int, item model.Conte
 	menuItem := m.menus[index]
 	popup, _ := model.NewPopup(model.PopupSpec{
 		Title:   "Context Action",
-		Content: fmt.Sprintf("Action '%s' on '%s'", item.Label, menuItem.Title),
+		Content: fmt.Sprintf("Action '%s' (%s) on '%s'", item.Label, strings.Join(item.Path, " › "), menuItem.Title),
 		Actions: []model.PopupAction{
 			{ID: "ok", Label: "OK", IsCancel: true},
 		},
@@ -137,9 +143,25 @@ func main() {
 	opts := model.DefaultOptions()
 	opts.StatusBar = &model.DefaultStatusBar{}
 	opts.ContextMenuOptions = model.ContextMenuOptions{MaxWidth: 24, MaxHeight: 7}
+	// F10 or alt+f/alt+h open the menu bar.
+	opts.MenuBar = []model.MenuBarMenu{
+		{Title: "File", Items: []model.ContextMenuItem{{ID: "quit", Label: "Quit", Accelerator: "q"}}},
+		{Title: "Help", Items: []model.ContextMenuItem{{ID: "about", Label: "About"}}},
+	}
 	// opts.DynamicRowCount = true
 	app := model.NewApp(opts)
 	app.With(model.WithMainMenu(mainMenu, nil))
+	app.RegisterCommand("quit", func(app *model.App, _ model.ContextMenuItem) (model.Page, tea.Cmd) {
+		return nil, func() tea.Msg {
+			app.Quit()
+			return nil
+		}
+	})
+	app.RegisterCommand("about", func(app *model.App, _ model.ContextMenuItem) (model.Page, tea.Cmd) {
+		popup, _ := model.NewPopup(model.PopupSpec{Title: "About", Content: "Menu demo"})
+		app.ShowPopup(popup)
+		return nil, app.RerenderCmd(true)
+	})
 
 	fmt.Println(app.Run())
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 
 	tea "charm.land/bubbletea/v2"
@@ -8,6 +9,9 @@ import (
 )
 
 func main() {
+	sidebar := flag.Bool("sidebar", false, "render the tabs as a vertical sidebar (ctrl+b collapses it)")
+	flag.Parse()
+
 	ops := model.DefaultOptions()
 	ops.AppName = "Multi-Tab Navigation Demo"
 
@@ -16,27 +20,65 @@ func main() {
 	ops.TabConfigs = []model.TabConfig{
 		{
 			Title:     "Dashboard",
+			Icon:      "◆",
 			Menu:      NewDashboardMenu(),
 			MenuTitle: &model.MenuItem{Title: "Dashboard"},
 		},
 		{
 			Title:     "Settings",
+			Icon:      "⚙",
 			Menu:      NewSettingsMenu(),
 			MenuTitle: &model.MenuItem{Title: "Settings"},
 		},
 		{
 			Title:     "Logs",
+			Icon:      "≡",
 			Menu:      NewLogsMenu(),
 			MenuTitle: &model.MenuItem{Title: "System Logs"},
 		},
 	}
 
+	// Right-click a tab header (or press F6, then shift+F10) for its menu.
+	ops.TabContextMenu = tabContextMenu{}
+
+	if *sidebar {
+		ops.TabLayout = model.TabLayoutSidebar
+		ops.TabSidebar = model.TabSidebarOptions{Width: 22, ToggleKey: "ctrl+b"}
+	}
+
 	app := model.NewApp(ops)
 	if err := app.Run(); err != nil {
 		fmt.Println("Error:", err)
 	}
 }
 
+// ── Tab Context Menu ──
+
+type tabContextMenu struct{}
+
+func (tabContextMenu) TabContextMenuItems(app *model.App, index int) []model.ContextMenuItem {
+	count := app.MustMain().TabCount()
+	return []model.ContextMenuItem{
+		{ID: "close", Label: "Close", Disabled: count <= 1},
+		{Separator: true},
+		{ID: "left", Label: "Move Left", Disabled: index == 0},
+		{ID: "right", Label: "Move Right", Disabled: index == count-1},
+	}
+}
+
+func (tabContextMenu) TabContextMenuAction(app *model.App, index int, item model.ContextMenuItem) (model.Page, tea.Cmd) {
+	main := app.MustMain()
+	switch item.ID {
+	case "close":
+		main.CloseTab(index)
+	case "left":
+		main.MoveTab(index, index-1)
+	case "right":
+		main.MoveTab(index, index+1)
+	}
+	return nil, app.RerenderCmd(true)
+}
+
 // ── Dashboard Tab ──
 
 type DashboardMenu struct {
@@ -118,19 +160,44 @@ func (m *ProjectsSubmenu) MenuViews() []model.MenuItem {
 	return m.items
 }
 
+// Action opens the project in its own closable tab, like a browser tab.
+// Drag tab headers to reorder them; click ✕ or middle-click to close.
 func (m *ProjectsSubmenu) Action(app *model.App, index int) (model.Page, tea.Cmd) {
-	itemTitle := m.items[index].Title
-	popup, _ := model.NewPopup(model.PopupSpec{
-		Title:   "Project Details",
-		Content: fmt.Sprintf("Details for %s\n\nStatus: %s\nTeam: 5 members\nDeadline: Q2 2025", itemTitle, m.items[index].Subtitle),
-		Actions: []model.PopupAction{
-			{ID: "ok", Label: "OK", IsCancel: true},
-		},
+	main := app.MustMain()
+	tab := main.AddTab(model.TabConfig{
+		Title:    m.items[index].Title,
+		Menu:     NewProjectTabMenu(m.items[index]),
+		Closable: true,
 	})
-	app.ShowPopup(popup)
+	main.ActivateTab(tab)
 	return nil, app.RerenderCmd(true)
 }
 
+// ── Project Tab ──
+
+type ProjectTabMenu struct {
+	model.DefaultMenu
+	items []model.MenuItem
+}
+
+func NewProjectTabMenu(project model.MenuItem) *ProjectTabMenu {
+	return &ProjectTabMenu{
+		items: []model.MenuItem{
+			{Title: "Status", Subtitle: project.Subtitle},
+			{Title: "Team", Subtitle: "5 members"},
+			{Title: "Deadline", Subtitle: "Q2 2025"},
+		},
+	}
+}
+
+func (m *ProjectTabMenu) GetMenuKey() string {
+	return "project_tab_menu"
+}
+
+func (m *ProjectTabMenu) MenuViews() []model.MenuItem {
+	return m.items
+}
+
 // ── Users Submenu ──
 
 type UsersSubmenu struct {
@@ -0,0 +1,159 @@
+// Wizard example — a first-run onboarding flow built with the Wizard widget.
+//
+// Steps: login, choose quality, choose the lossless codec (only shown for
+// lossless), choose the download directory with a FilePicker (skippable),
+// then review and submit.
+//
+// Navigation:
+//
+//	Tab/↑↓     — move between fields
+//	Enter      — next step (submit on review)
+//	Ctrl+N     — next step
+//	Ctrl+S     — skip the download directory step
+//	Esc        — previous step (back to menu on the first)
+//	q/Ctrl+C   — quit (from the menu)
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/model"
+	"github.com/anhoder/foxful-cli/style"
+)
+
+// MainMenu offers to run the onboarding wizard.
+type MainMenu struct {
+	model.DefaultMenu
+	menus []model.MenuItem
+}
+
+func NewMainMenu() *MainMenu {
+	return &MainMenu{
+		menus: []model.MenuItem{
+			{Title: "First-run Onboarding", Subtitle: "Login, quality and download directory"},
+		},
+	}
+}
+
+func (m *MainMenu) GetMenuKey() string {
+	return "main_menu"
+}
+
+func (m *MainMenu) MenuViews() []model.MenuItem {
+	return m.menus
+}
+
+func (m *MainMenu) SubMenu(_ *model.App, _ int) model.Menu {
+	return nil
+}
+
+func (m *MainMenu) Action(app *model.App, _ int) (model.Page, tea.Cmd) {
+	return NewWizardPage(app), nil
+}
+
+// WizardPage hosts the onboarding wizard.
+type WizardPage struct {
+	wizard *model.Wizard
+}
+
+func NewWizardPage(app *model.App) *WizardPage {
+	home, _ := os.UserHomeDir()
+	picker := model.NewFilePicker(home)
+
+	login := model.NewForm([]model.FormField{
+		{Key: "user", Label: "Username", Placeholder: "you@example.com", Required: true},
+		{Key: "password", Label: "Password", Type: model.FieldPassword, Placeholder: "ctrl+r to reveal", Required: true},
+		{Key: "remember", Label: "Remember me", Type: model.FieldCheckbox, Default: "true"},
+	})
+	quality := model.NewForm([]model.FormField{
+		{Key: "quality", Label: "Quality", Type: model.FieldRadio, Options: []string{"standard", "higher", "lossless"}, Default: "higher"},
+	})
+	codec := model.NewForm([]model.FormField{
+		{Key: "codec", Label: "Codec", Type: model.FieldSelect, Options: []string{"flac", "alac", "wav"}, Default: "flac"},
+	})
+
+	w := model.NewWizard([]model.WizardStep{
+		{Title: "Login", Form: login},
+		{Title: "Quality", Form: quality},
+		{Title: "Codec", Form: codec, When: func(values map[string]string) bool {
+			return values["quality"] == "lossless"
+		}},
+		{
+			Title:     "Download directory",
+			Body:      picker,
+			Skippable: true,
+			Values: func() map[string]string {
+				return map[string]string{"download_dir": picker.CurrentDir()}
+			},
+			Validate: func() error {
+				if _, err := os.Stat(picker.CurrentDir()); err != nil {
+					return fmt.Errorf("cannot use %s: %w", picker.CurrentDir(), err)
+				}
+				return nil
+			},
+		},
+	})
+	w.SetSize(app.WindowWidth()-4, app.WindowHeight()-6)
+	w.Focus()
+	return &WizardPage{wizard: w}
+}
+
+func (p *WizardPage) Type() model.PageType {
+	return model.PageType("wizard")
+}
+
+func (p *WizardPage) Msg() tea.Msg {
+	return nil
+}
+
+func (p *WizardPage) IgnoreQuitKeyMsg(_ tea.KeyMsg) bool {
+	return true
+}
+
+func (p *WizardPage) Update(msg tea.Msg, a *model.App) (model.Page, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		p.wizard.SetSize(msg.Width-4, msg.Height-6)
+		return p, nil
+	}
+	if _, ok := msg.(tea.KeyMsg); ok && p.wizard.Submitted() {
+		// Any key returns from the result screen.
+		return a.Main(), nil
+	}
+
+	cmd := p.wizard.Update(msg)
+	if p.wizard.Cancelled() {
+		return a.Main(), nil
+	}
+	return p, tea.Batch(cmd, a.RerenderCmd(true))
+}
+
+func (p *WizardPage) View(a *model.App) string {
+	ss := style.CurrentStyleSet()
+	content := p.wizard.View()
+	if p.wizard.Submitted() {
+		values := p.wizard.Values()
+		lines := []string{ss.Success.Render("✓ Setup complete"), ""}
+		delete(values, "password")
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			lines = append(lines, ss.MenuItem.Render(key+": ")+ss.Normal.Render(values[key]))
+		}
+		lines = append(lines, "", ss.Muted.Render("Press any key to return"))
+		content = strings.Join(lines, "\n")
+	}
+	return lipgloss.NewStyle().Padding(1, 2).Width(a.WindowWidth()).Render(content)
+}
+
+func main() {
+	ops := model.DefaultOptions()
+	app := model.NewApp(ops)
+
+	ops.MainMenu = NewMainMenu()
+
+	fmt.Println(app.Run())
+}
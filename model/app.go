@@ -1,6 +1,8 @@
 package model
 
 import (
+	"io"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -36,10 +38,37 @@ type App struct {
 	main    *Main
 
 	page       Page    // current page
-	modalStack []Modal // stack of active modals (popups, context menus); topmost is last
+	modalStack []Modal // stack of active modals (popups, context menus, custom modals); topmost is last
 
 	notifications      []*Notification // active notifications (newest at end)
 	nextNotificationID NotificationID
+	// notificationHistory 是所有已显示通知的环形缓冲区，toast 过期或被关闭
+	// 后仍保留在此处，供通知中心使用；只在主事件循环中访问。
+	notificationHistory notificationHistory
+	// focusedNotificationID 是拥有键盘焦点的通知，0 表示焦点在页面或弹窗上。
+	focusedNotificationID NotificationID
+	// terminalBlurred 记录终端窗口是否失去焦点（来自焦点报告），用于决定是否
+	// 将通知转发到桌面。未收到任何焦点事件时视为拥有焦点。
+	terminalBlurred bool
+	// desktopOutput 非 nil 时，桌面通知的转义序列在主事件循环中同步写入此处，
+	// 而不经过 bubbletea 渲染器；仅供测试捕获输出。
+	desktopOutput io.Writer
+	// notificationOverflow 统计因限流未显示的通知数，与因高度被挤出的通知一起
+	// 显示在 "+N more" 指示器中；notificationTimes 是限流窗口内的显示时间。
+	notificationOverflow int
+	notificationTimes    []time.Time
+	overflowBounds       notificationRect
+	overflowBoundsSet    bool
+	expandedGroups       map[string]bool // groups expanded via a summary's "Show all"
+
+	popupGeometries map[string]PopupGeometry  // remembered by PopupSpec.GeometryKey
+	commands        map[string]CommandHandler // run by menu bar items, see RegisterCommand
+
+	// modalTransitions 记录启用了动画的弹窗的过渡状态（含最近一次渲染的图层），
+	// closingModals 是已出栈但仍在播放关闭动画的图层。
+	modalTransitions      map[Modal]*modalTransition
+	closingModals         []*modalTransition
+	modalAnimationTicking bool
 
 	// styleSet is the app-scoped theme. When nil, StyleSet() falls back to the
 	// global style.CurrentStyleSet(). Set via SetStyleSet to isolate this app's
@@ -62,6 +91,17 @@ type App struct {
 	// program.Send。用于合并 Rerender 调用，防止 ticker 在帧渲染慢于 tick
 	// 间隔（高帧率 + 重渲染）时堆积无界数量的阻塞 goroutine。
 	rerenderPending atomic.Bool
+
+	// tasksMu 保护 tasks、finishedTasks 与 nextTaskID：任务 goroutine 完成时
+	// 会把自己从 tasks 移到 finishedTasks，而 UI 线程在渲染状态栏时读取列表。
+	// taskTicking、taskPopup 与 taskListShown 只在主事件循环中访问。
+	tasksMu       sync.Mutex
+	tasks         []*Task // running background tasks in start order
+	finishedTasks []*Task // finished tasks whose notification is pending
+	nextTaskID    TaskID
+	taskTicking   bool       // a taskTickMsg loop is scheduled
+	taskPopup     *Popup     // open task list popup, refreshed on each tick
+	taskListShown []TaskInfo // tasks last rendered in taskPopup
 }
 
 // StyleSet returns the app-scoped StyleSet if one was set via SetStyleSet,
@@ -167,6 +207,10 @@ func (a *App) Init() tea.Cmd {
 	if initPage, ok := a.page.(InitPage); ok {
 		cmds = append(cmds, initPage.Init(a))
 	}
+	// Tasks run before the program started could not wake the loop.
+	if a.taskCount() > 0 || a.hasFinishedTasks() {
+		cmds = append(cmds, a.handleTaskStarted())
+	}
 	return tea.Batch(cmds...)
 }
 
@@ -212,6 +256,11 @@ func (a *App) Update(msg tea.Msg) (model tea.Model, returnCmd tea.Cmd) {
 		if notificationHoverCmd != nil {
 			returnCmd = tea.Batch(notificationHoverCmd, returnCmd)
 		}
+		// Keep modal transitions running; pushes and pops happen anywhere in
+		// Update (and in callbacks it triggers).
+		if animationCmd := a.modalAnimationCmd(); animationCmd != nil {
+			returnCmd = tea.Batch(returnCmd, animationCmd)
+		}
 	}()
 	if _, ok := msg.(tea.KeyMsg); ok {
 		if !a.listeningKBEventL.TryLock() {
@@ -229,12 +278,58 @@ func (a *App) Update(msg tea.Msg) (model tea.Model, returnCmd tea.Cmd) {
 	// so modals can handle keys like 'q' as close keys.
 	// Notification messages are handled before modal interception so they work
 	// regardless of any open modal. These are never Key/Mouse messages, so the
-	// locks at the top of Update do not apply.
+	// locks at the top of Update do not apply. Terminal focus changes are only
+	// recorded here and still reach the page.
+	switch msg.(type) {
+	case tea.FocusMsg:
+		a.terminalBlurred = false
+	case tea.BlurMsg:
+		a.terminalBlurred = true
+	}
 	switch msgWithType := msg.(type) {
+	case modalAnimationTickMsg:
+		a.handleModalAnimationTick()
+		return a, nil
+	case formValidationMsg:
+		// Async form validation results go straight to their form, wherever
+		// it is hosted. A submission completed by the result closes the top
+		// popup like the key that requested it would have.
+		msgWithType.form.applyValidation(msgWithType)
+		if len(a.modalStack) > 0 {
+			if p, ok := a.modalStack[len(a.modalStack)-1].(*Popup); ok {
+				if p.dismissIfSubmitted(); p.dismissed() {
+					page, cmd := a.completeTopModal()
+					if page != nil {
+						a.setPage(page)
+					}
+					return a, tea.Batch(a.RerenderCmd(true), cmd)
+				}
+			}
+		}
+		return a, a.RerenderCmd(true)
+	case uv.UnknownOscEvent:
+		// kitty reports clicks on desktop notification buttons as OSC 99.
+		if handled, cmd := a.handleKittyNotificationReport(string(msgWithType)); handled {
+			return a, cmd
+		}
+	case contextMenuActionMsg:
+		page, cmd := msgWithType.action(a, msgWithType.item)
+		if page != nil {
+			a.setPage(page)
+		}
+		return a, tea.Batch(a.RerenderCmd(true), cmd)
 	case ShowNotificationMsg:
 		return a, a.handleShowNotification(msgWithType.Spec)
+	case SetTabBadgeMsg:
+		// Handled here so it reaches Main whichever page is shown.
+		if a.main != nil && a.main.tabs != nil {
+			a.main.SetTabBadge(a.main.TabIndex(msgWithType.Key), msgWithType.Badge)
+		}
+		return a, a.RerenderCmd(true)
 	case notificationExpireMsg:
-		a.handleExpire(msgWithType.id)
+		if cmd := a.handleExpire(msgWithType.id); cmd != nil {
+			return a, cmd
+		}
 		return a, a.RerenderCmd(true)
 	case updateNotificationMsg:
 		cmd := a.updateNotificationContent(msgWithType.id, msgWithType.spec)
@@ -247,7 +342,16 @@ func (a *App) Update(msg tea.Msg) (model tea.Model, returnCmd tea.Cmd) {
 		return a, a.RerenderCmd(true)
 	case clearAllNotificationsMsg:
 		a.notifications = nil
+		a.notificationOverflow = 0
 		return a, a.RerenderCmd(true)
+	case progressNotificationMsg:
+		return a, a.handleProgressNotification(msgWithType.handle)
+	case taskStartedMsg:
+		return a, a.handleTaskStarted()
+	case taskTickMsg:
+		return a, a.handleTaskTick()
+	case taskFinishedMsg:
+		return a, a.handleTaskFinished()
 	}
 
 	// Theme switch shortcut: cycle to the next theme in ThemeList.
@@ -263,6 +367,13 @@ func (a *App) Update(msg tea.Msg) (model tea.Model, returnCmd tea.Cmd) {
 		}
 	}
 
+	// Keyboard focus for notifications takes keys before modals and the page.
+	if k, ok := msg.(tea.KeyPressMsg); ok {
+		if handled, cmd := a.handleNotificationFocusKey(k.String()); handled {
+			return a, cmd
+		}
+	}
+
 	// Make sure these keys always quit (but only if no modal is handling them)
 	if len(a.modalStack) == 0 {
 		switch msgWithType := msg.(type) {
@@ -299,6 +410,9 @@ func (a *App) Update(msg tea.Msg) (model tea.Model, returnCmd tea.Cmd) {
 	// inside a notification are consumed; leaving an action clears its hover
 	// state while allowing the event to continue to the modal or page.
 	if mouseMsg, ok := msg.(tea.MouseMsg); ok {
+		if _, isClick := mouseMsg.(tea.MouseClickMsg); isClick && a.handleOverflowClick(mouseMsg.Mouse()) {
+			return a, a.RerenderCmd(true)
+		}
 		if notif := a.notificationAt(mouseMsg.Mouse()); notif != nil {
 			result := notif.handleMouse(mouseMsg)
 			if result.consumed {
@@ -306,6 +420,7 @@ func (a *App) Update(msg tea.Msg) (model tea.Model, returnCmd tea.Cmd) {
 				onAction := notif.spec.OnAction
 				if result.dismiss {
 					a.removeNotification(notif.id)
+					a.markNotificationRead(notif.id)
 				}
 				if result.dismiss || result.rerender {
 					cmds = append(cmds, a.RerenderCmd(true))
@@ -336,24 +451,30 @@ func (a *App) Update(msg tea.Msg) (model tea.Model, returnCmd tea.Cmd) {
 	}
 
 	// Modal input interception — only the topmost modal receives input.
+	var bodyCmd tea.Cmd
 	if len(a.modalStack) > 0 {
 		top := a.modalStack[len(a.modalStack)-1]
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
-			top.update(msg)
+			updateCmd := top.update(msg)
 			if top.dismissed() {
 				page, cmd := a.completeTopModal()
 				if page != nil {
 					a.setPage(page)
 				}
-				cmds := []tea.Cmd{a.RerenderCmd(true)}
+				cmds := []tea.Cmd{a.RerenderCmd(true), updateCmd}
 				if cmd != nil {
 					cmds = append(cmds, cmd)
 				}
 				return a, tea.Batch(cmds...)
 			}
-			return a, a.RerenderCmd(true)
+			return a, tea.Batch(a.RerenderCmd(true), updateCmd)
 		case tea.MouseMsg:
+			// No hit-testing until the open transition has ended: the modal
+			// isn't at its final bounds yet.
+			if a.modalOpening(top) {
+				return a, nil
+			}
 			handled, mouseCmd := top.handleMouse(msg)
 			if handled {
 				if top.dismissed() {
@@ -403,9 +524,12 @@ func (a *App) Update(msg tea.Msg) (model tea.Model, returnCmd tea.Cmd) {
 				}
 				return a, tea.Batch(cmds...)
 			} else if mouse.Button == tea.MouseRight {
-				// Right-click outside: dismiss if modal allows passthrough, then forward to page
+				// Right-click outside: dismiss if modal allows passthrough, then forward to page.
+				// A modal declining DismissOutside stays open and consumes the click.
 				if top.allowsRightClickPassthrough() {
-					top.dismissOutside()
+					if !top.dismissOutside() {
+						return a, a.RerenderCmd(true)
+					}
 					page, modalCmd := a.completeTopModal()
 					if page != nil {
 						a.setPage(page)
@@ -428,13 +552,20 @@ func (a *App) Update(msg tea.Msg) (model tea.Model, returnCmd tea.Cmd) {
 			return a, nil
 		}
 		// Forward non-input messages (ticks, etc.) to the page so it continues
-		// updating while a modal is open.
+		// updating while a modal is open, and to a popup's body (e.g. cursor
+		// blinks of a form).
+		if popup, ok := top.(*Popup); ok && popup.body != nil {
+			bodyCmd = popup.body.Update(msg)
+		}
 	}
 
 	page, cmd := a.page.Update(msg, a)
 	if page != nil {
 		a.setPage(page)
 	}
+	if bodyCmd != nil {
+		return a, tea.Batch(bodyCmd, cmd, a.RerenderCmd(true))
+	}
 	return a, cmd
 }
 
@@ -442,6 +573,7 @@ func (a *App) View() tea.View {
 	var v tea.View
 	v.AltScreen = a.options.AltScreen
 	v.MouseMode = a.options.MouseMode
+	v.ReportFocus = a.options.NotificationOptions.Desktop.Enabled
 
 	if a.quiting || a.WindowHeight() <= 0 || a.WindowWidth() <= 0 || a.page == nil {
 		return v
@@ -449,8 +581,9 @@ func (a *App) View() tea.View {
 
 	baseContent := a.page.View(a)
 
-	// Composite modals on top of the page content (if any).
-	if len(a.modalStack) > 0 {
+	// Composite modals on top of the page content (if any), including ones
+	// still playing their close transition.
+	if len(a.modalStack) > 0 || len(a.closingModals) > 0 {
 		baseContent = a.compositeModals(baseContent)
 	}
 
@@ -681,7 +814,20 @@ func (a *App) pushModal(m Modal) {
 	if m == nil {
 		return
 	}
+	a.applyPopupWindowKeys(m)
+	a.restorePopupGeometry(m)
 	a.modalStack = append(a.modalStack, m)
+	a.startModalOpen(m)
+}
+
+// popModal removes the topmost modal, starting its close transition.
+func (a *App) popModal() Modal {
+	topIndex := len(a.modalStack) - 1
+	top := a.modalStack[topIndex]
+	a.modalStack = a.modalStack[:topIndex]
+	a.rememberPopupGeometry(top)
+	a.startModalClose(top)
+	return top
 }
 
 // ShowPopup pushes a validated popup onto the modal stack.
@@ -698,7 +844,7 @@ func (a *App) ShowPopup(p *Popup) {
 // Does nothing if the stack is empty.
 func (a *App) DismissPopup() {
 	if len(a.modalStack) > 0 {
-		a.modalStack = a.modalStack[:len(a.modalStack)-1]
+		a.popModal()
 	}
 }
 
@@ -708,13 +854,11 @@ func (a *App) completeTopModal() (Page, tea.Cmd) {
 	if len(a.modalStack) == 0 {
 		return nil, nil
 	}
-	topIndex := len(a.modalStack) - 1
-	top := a.modalStack[topIndex]
-	a.modalStack = a.modalStack[:topIndex]
-	return top.complete(a)
+	return a.popModal().complete(a)
 }
 
-// HasPopup returns whether a modal (popup or context menu) is currently active.
+// HasPopup returns whether a modal (popup, context menu or custom modal) is
+// currently active.
 func (a *App) HasPopup() bool {
 	return len(a.modalStack) > 0
 }
@@ -735,6 +879,8 @@ func (a *App) TopModalBounds() (x, y, w, h int, ok bool) {
 	case *ContextMenu:
 		x, y, w, h = m.Bounds()
 		return x, y, w, h, true
+	case *customModal:
+		return m.bounds.x, m.bounds.y, m.bounds.w, m.bounds.h, true
 	}
 	return 0, 0, 0, 0, false
 }
@@ -748,23 +894,41 @@ func (a *App) compositeModals(baseContent string) string {
 
 	layers := []*layout.Layer{layout.NewLayer(baseContent)}
 	for _, modal := range a.modalStack {
-		// Type-switch to render Popup vs ContextMenu
+		// Type-switch to render Popup, ContextMenu or a CustomModal
+		var content string
+		var x, y int
 		switch m := modal.(type) {
 		case *Popup:
+			m.SetTermSize(w, h)
 			rendered := m.render(ss.Popup)
 			popupH := lipgloss.Height(rendered.content)
 			popupW := layout.Width(rendered.content)
-			x, y := m.computePosition(w, h, popupW, popupH)
+			x, y = m.computePosition(w, h, popupW, popupH)
 			m.setBounds(x, y, popupW, popupH, rendered.actionBounds)
-			m.SetTermSize(w, h)
-			layers = append(layers, layout.NewLayer(rendered.content).X(x).Y(y))
+			content = rendered.content
 		case *ContextMenu:
 			rendered := m.renderModal(ss, w, h)
 			menuH := lipgloss.Height(rendered.content)
 			menuW := layout.Width(rendered.content)
-			x, y := m.computePosition(w, h, menuW, menuH)
+			x, y = m.computePosition(w, h, menuW, menuH)
 			m.setModalBounds(x, y, menuW, menuH, rendered.itemBounds)
-			layers = append(layers, layout.NewLayer(rendered.content).X(x).Y(y))
+			content = rendered.content
+		case *customModal:
+			content, x, y = m.render(w, h)
+		default:
+			continue
+		}
+		content, x, y = a.animateModalLayer(modal, content, x, y, ss.Popup.Surface)
+		layers = append(layers, layout.NewLayer(content).X(x).Y(y))
+		if cm, ok := modal.(*ContextMenu); ok && !a.modalOpening(cm) {
+			layers = append(layers, cm.submenuLayers(ss, w, h)...)
+		}
+	}
+	// Modals that already left the stack are drawn on top while they close.
+	for _, tr := range a.closingModals {
+		if t := a.transitionProgress(tr); t < 1 {
+			content, x, y := tr.apply(tr.visibility(t), ss.Popup.Surface)
+			layers = append(layers, layout.NewLayer(content).X(x).Y(y))
 		}
 	}
 	return layout.NewCompositor(layers...).Render()
@@ -777,6 +941,11 @@ func (a *App) compositeModals(baseContent string) string {
 // Info/Success notifications use the configured default timeout; Warning/Error
 // notifications persist until dismissed manually.
 //
+// A notification over NotificationOptions.RateLimit is not shown; it only
+// goes to the notification center and the "+N more" indicator, and is not
+// forwarded to the desktop. Its returned ID then matches no live
+// notification, so UpdateNotification and DismissNotification ignore it.
+//
 // Safe to call from goroutines, including during Init(); internally sends a
 // message to the Update loop via a non-blocking goroutine to avoid deadlocks
 // when called before the event loop starts.
@@ -827,63 +996,116 @@ func (a *App) ClearAllNotifications() {
 
 // handleShowNotification creates a notification and returns a timeout Cmd if needed.
 func (a *App) handleShowNotification(spec NotificationSpec) tea.Cmd {
+	_, cmd := a.showNotification(spec)
+	return cmd
+}
+
+// showNotification creates a notification, or coalesces it into a live one
+// with the same DedupKey, and returns the ID of the notification showing it.
+// Notifications over the rate limit are only recorded in the history and
+// counted in the overflow indicator; they return ID 0.
+func (a *App) showNotification(spec NotificationSpec) (NotificationID, tea.Cmd) {
+	spec = cloneNotificationSpec(spec)
+	if spec.DedupKey != "" {
+		if n, summary := a.findDedupNotification(spec.DedupKey); n != nil {
+			return n.id, a.coalesceNotification(n, summary, spec)
+		}
+	}
+
 	a.nextNotificationID++
 	id := a.nextNotificationID
-	spec = cloneNotificationSpec(spec)
+	now := time.Now()
+	a.recordNotification(id, spec, now)
+	if a.notificationRateLimited(now) {
+		a.notificationOverflow++
+		return 0, a.RerenderCmd(true)
+	}
 
 	notif := &Notification{
 		id:            id,
 		spec:          spec,
-		createdAt:     time.Now(),
+		createdAt:     now,
+		count:         1,
 		hoveredAction: -1,
+		focusedAction: -1,
 	}
-	a.notifications = append(a.notifications, notif)
+	a.insertNotification(notif)
 
 	cmds := []tea.Cmd{a.RerenderCmd(true)}
+	if cmd := a.desktopNotificationCmd(id, spec); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	if cmd := a.scheduleNotificationExpiry(notif, a.initialNotificationTimeout(spec)); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	return id, tea.Batch(cmds...)
+}
 
-	// Interactive notifications remain visible when no timeout is explicit.
-	// Otherwise Info/Success use the configured default and Warning/Error persist.
+// initialNotificationTimeout returns the timeout of a newly shown notification.
+// Interactive notifications remain visible when no timeout is explicit.
+// Otherwise Info/Success use the configured default and Warning/Error persist.
+func (a *App) initialNotificationTimeout(spec NotificationSpec) time.Duration {
 	timeout := spec.Timeout
-	if timeout == 0 && len(spec.Actions) == 0 {
+	if timeout == 0 && len(spec.Actions) == 0 && spec.Progress == nil {
 		if spec.Level == NotificationInfo || spec.Level == NotificationSuccess {
-			timeout = a.options.NotificationOptions.DefaultTimeout
-			if timeout == 0 {
-				timeout = 4 * time.Second
-			}
+			timeout = a.defaultNotificationTimeout()
 		}
 	}
+	return timeout
+}
 
-	if timeout > 0 {
-		notif.expireAt = time.Now().Add(timeout)
-		cmds = append(cmds, tea.Tick(timeout, func(time.Time) tea.Msg {
-			return notificationExpireMsg{id: id}
-		}))
-	}
+// scheduleNotificationExpiry sets the expiry of n and returns the tick that
+// checks it, or clears the expiry and returns nil for a zero timeout.
+func (a *App) scheduleNotificationExpiry(n *Notification, timeout time.Duration) tea.Cmd {
+	if timeout <= 0 {
+		n.expireAt = time.Time{}
+		return nil
+	}
+	id := n.id
+	n.expireAt = time.Now().Add(timeout)
+	return tea.Tick(timeout, func(time.Time) tea.Msg {
+		return notificationExpireMsg{id: id}
+	})
+}
 
-	return tea.Batch(cmds...)
+// defaultNotificationTimeout returns NotificationOptions.DefaultTimeout, or 4s
+// when unset.
+func (a *App) defaultNotificationTimeout() time.Duration {
+	if timeout := a.options.NotificationOptions.DefaultTimeout; timeout > 0 {
+		return timeout
+	}
+	return 4 * time.Second
 }
 
-// removeNotification removes a notification by ID.
+// removeNotification removes a notification by ID, including members of a
+// group summary. The overflow indicator resets once the stack is empty.
 func (a *App) removeNotification(id NotificationID) {
+	defer a.resetOverflowWhenEmpty()
 	for i, n := range a.notifications {
 		if n.id == id {
 			a.notifications = append(a.notifications[:i], a.notifications[i+1:]...)
 			return
 		}
 	}
+	if n, summary := a.findLiveNotification(id); summary != nil {
+		a.removeGroupMember(summary, n)
+	}
 }
 
 // handleExpire checks expireAt before removing. When UpdateNotification clears
 // or extends the timeout, stale ticks are ignored because expireAt was updated.
-func (a *App) handleExpire(id NotificationID) {
-	for _, n := range a.notifications {
-		if n.id == id {
-			if !n.expireAt.IsZero() && time.Now().After(n.expireAt) {
-				a.removeNotification(id)
-			}
-			return
-		}
+// A notification with keyboard focus is held until it loses focus; the
+// returned Cmd re-checks it later.
+func (a *App) handleExpire(id NotificationID) tea.Cmd {
+	n, _ := a.findLiveNotification(id)
+	if n == nil || n.expireAt.IsZero() || !time.Now().After(n.expireAt) {
+		return nil
+	}
+	if n.focused {
+		return a.scheduleNotificationExpiry(n, notificationFocusHold)
 	}
+	a.removeNotification(id)
+	return nil
 }
 
 // updateNotificationContent updates the spec of an existing notification and
@@ -891,22 +1113,18 @@ func (a *App) handleExpire(id NotificationID) {
 // auto-expire" (unlike initial creation which falls back to DefaultTimeout for
 // Info/Success). Returns a tea.Tick if a new timeout should be set.
 func (a *App) updateNotificationContent(id NotificationID, spec NotificationSpec) tea.Cmd {
-	for _, n := range a.notifications {
-		if n.id == id {
-			n.spec = cloneNotificationSpec(spec)
-			n.resetInteraction()
-			// Update expiration: 0 means no timeout for updates.
-			if spec.Timeout > 0 {
-				n.expireAt = time.Now().Add(spec.Timeout)
-				return tea.Tick(spec.Timeout, func(time.Time) tea.Msg {
-					return notificationExpireMsg{id: id}
-				})
-			}
-			n.expireAt = time.Time{} // clear expiration
-			return nil
-		}
-	}
-	return nil
+	n, summary := a.findLiveNotification(id)
+	if n == nil {
+		return nil
+	}
+	n.spec = cloneNotificationSpec(spec)
+	n.resetInteraction()
+	a.updateNotificationRecord(id, n.spec)
+	if summary != nil {
+		a.refreshGroupSummary(summary)
+	}
+	// Update expiration: 0 means no timeout for updates.
+	return a.scheduleNotificationExpiry(n, spec.Timeout)
 }
 
 // notificationAt returns the notification at the given mouse position,
@@ -940,6 +1158,7 @@ func (a *App) compositeNotifications(baseContent string) string {
 
 	layers := []*layout.Layer{layout.NewLayer(baseContent)}
 	currentHeight := 0
+	hidden := 0
 	for _, n := range a.notifications {
 		n.clearBounds()
 	}
@@ -956,6 +1175,7 @@ func (a *App) compositeNotifications(baseContent string) string {
 			for j := i; j >= 0; j-- {
 				a.notifications[j].hoveredAction = -1
 			}
+			hidden = i + 1
 			break // Oldest notifications are pushed out of view.
 		}
 
@@ -966,6 +1186,11 @@ func (a *App) compositeNotifications(baseContent string) string {
 		currentHeight += notifH
 	}
 
+	// Rate-limited and pushed-out notifications are summarized as "+N more".
+	if overflow, x, y := a.placeNotificationOverflow(ss.Notification, a.notificationOverflow+hidden, currentHeight); overflow != "" {
+		layers = append(layers, layout.NewLayer(overflow).X(x).Y(y))
+	}
+
 	return layout.NewCompositor(layers...).Render()
 }
 
@@ -995,7 +1220,7 @@ func renderNotificationActions(n *Notification, styles style.NotificationStyleSe
 	bounds := make([]notificationRect, len(n.spec.Actions))
 	for i, action := range n.spec.Actions {
 		buttonStyle := styles.Action
-		if i == n.hoveredAction {
+		if i == n.hoveredAction || (n.focused && i == n.focusedAction) {
 			buttonStyle = styles.ActionHover
 		}
 		button := buttonStyle.Render(action.Label)
@@ -1065,25 +1290,39 @@ func (a *App) renderNotification(n *Notification, styles style.NotificationStyle
 		frameStyle = styles.ErrorFrame
 		icon = styles.ErrorIcon
 	}
+	// A heavier border marks keyboard focus without relying on color, so it
+	// stays visible in AccessibleMode.
+	if n.focused {
+		frameStyle = frameStyle.Border(lipgloss.ThickBorder())
+	}
 
 	// Whole width includes two border and two padding columns.
 	contentWidth := max(maxWidth-4, 10)
 	actions := renderNotificationActions(n, styles, contentWidth)
 
+	title := spec.Title
+	if n.count > 1 {
+		title = strings.TrimSpace(title + " ×" + strconv.Itoa(n.count))
+	}
 	titleHeight := 0
 	titleText := ""
-	if spec.Title != "" {
+	if title != "" {
 		titleHeight = 1
-		titleText = icon + spec.Title
+		titleText = icon + title
+	}
+
+	progressHeight := 0
+	if spec.Progress != nil {
+		progressHeight = 1
 	}
 
 	actionSpacer := 0
-	if actions.height > 0 && (spec.Title != "" || spec.Message != "") {
+	if actions.height > 0 && (spec.Title != "" || spec.Message != "" || progressHeight > 0) {
 		actionSpacer = 1
 	}
 	bodyLimit := max(maxLines, 1)
 	if maxHeight > 0 {
-		bodyLimit = min(bodyLimit, max(0, maxHeight-2-titleHeight-actionSpacer-actions.height))
+		bodyLimit = min(bodyLimit, max(0, maxHeight-2-titleHeight-progressHeight-actionSpacer-actions.height))
 	}
 
 	var bodyLines []string
@@ -1096,7 +1335,7 @@ func (a *App) renderNotification(n *Notification, styles style.NotificationStyle
 			bodyLines[last] = ansi.Truncate(bodyLines[last], contentWidth-1, "…")
 		}
 	}
-	if titleHeight == 0 && len(bodyLines) == 0 {
+	if titleHeight == 0 && len(bodyLines) == 0 && progressHeight == 0 {
 		actionSpacer = 0
 	}
 
@@ -1127,13 +1366,16 @@ func (a *App) renderNotification(n *Notification, styles style.NotificationStyle
 	if len(remainingLines) > 0 {
 		blocks = append(blocks, styles.Message.Width(contentWidth).Render(strings.Join(remainingLines, "\n")))
 	}
+	if progressHeight > 0 {
+		blocks = append(blocks, a.renderNotificationProgress(*spec.Progress, styles, contentWidth))
+	}
 
 	rendered := notificationRender{}
 	if actions.height > 0 {
 		if len(blocks) > 0 {
 			blocks = append(blocks, lipgloss.NewStyle().Width(contentWidth).Background(styles.Surface).Render(""))
 		}
-		actionY := 1 + len(allLines) + actionSpacer
+		actionY := 1 + len(allLines) + progressHeight + actionSpacer
 		for _, bound := range actions.bounds {
 			rendered.actionBounds = append(rendered.actionBounds, notificationRect{
 				x: 2 + bound.x,
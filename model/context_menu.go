@@ -1,10 +1,13 @@
 package model
 
 import (
+	"slices"
 	"strings"
+	"unicode"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/layout"
 	"github.com/anhoder/foxful-cli/style"
 	"github.com/charmbracelet/x/ansi"
 )
@@ -14,6 +17,10 @@ const (
 	contextMenuMinInnerWidth  = 8
 	contextMenuHorizontalPad  = 2 // 1 cell on each side of an item label
 	contextMenuScrollbarWidth = 1
+	contextMenuSubmenuArrow   = "›"
+	contextMenuCheckMark      = "✓"
+	contextMenuRadioOn        = "●"
+	contextMenuRadioOff       = "○"
 )
 
 // ContextMenuItem describes a single entry in a context menu.
@@ -23,6 +30,42 @@ type ContextMenuItem struct {
 	Disabled  bool
 	Separator bool // when true, renders as a separator line; other fields ignored
 	Header    bool // 分组标题行：不可选中、加粗、显示 Label
+
+	// Children turns the item into a submenu, opened beside it on hover or
+	// with right/enter. Selecting the item itself does nothing.
+	Children []ContextMenuItem
+
+	// Checkable items show a check mark while Checked; selecting one toggles
+	// Checked before the item is passed to Menu.ContextMenuAction.
+	Checkable bool
+	Checked   bool
+	// RadioGroup makes the item one choice of a group: selecting it checks it
+	// and unchecks the other items of the same group in this menu.
+	RadioGroup string
+	// KeepOpen keeps the menu open after a checkable or radio item is
+	// toggled; the action still runs.
+	KeepOpen bool
+
+	// Accelerator is a key (e.g. "ctrl+d") shown right-aligned that selects
+	// the item while the menu is open.
+	Accelerator string
+	// Mnemonic is a letter of Label, underlined, that selects the item when
+	// typed. Mnemonics take precedence over the h/j/k/l navigation keys.
+	Mnemonic rune
+
+	// Path is set on the item passed to Menu.ContextMenuAction: the IDs from
+	// the top-level item down to the selected one.
+	Path []string
+}
+
+// contextMenuActionFunc runs the action of a selected context menu item.
+type contextMenuActionFunc func(app *App, item ContextMenuItem) (Page, tea.Cmd)
+
+// contextMenuActionMsg runs the action of an item toggled in a menu that
+// stays open.
+type contextMenuActionMsg struct {
+	action contextMenuActionFunc
+	item   ContextMenuItem
 }
 
 // ContextMenu is a vertical list modal anchored at mouse coordinates.
@@ -30,6 +73,7 @@ type ContextMenuItem struct {
 type ContextMenu struct {
 	menu         Menu
 	itemIndex    int // the menu list item that was right-clicked
+	action       contextMenuActionFunc
 	items        []ContextMenuItem
 	mouseX       int
 	mouseY       int
@@ -48,6 +92,26 @@ type ContextMenu struct {
 	bounds     popupRect
 	boundsSet  bool
 	itemBounds []popupRect // absolute screen coordinates for each selectable item
+
+	// Cascading submenus. Only the root menu is on the modal stack; it routes
+	// input down the chain of open submenus and draws them as extra layers.
+	options      ContextMenuOptions
+	parent       *ContextMenu
+	submenu      *ContextMenu // open child menu, or nil
+	submenuIndex int          // item the submenu was opened from (-1 = none)
+
+	// A submenu is placed right of anchorRight, or flipped to end at
+	// anchorLeft; anchorY is its top row.
+	anchorLeft  int
+	anchorRight int
+	anchorY     int
+
+	// Menu bar dropdowns: bar is the bar that opened the menu. Dismissed
+	// without a selection, the bar switches to dropdown barNext (-1 = none);
+	// barByKey records whether the keyboard dismissed it.
+	bar      *menuBar
+	barNext  int
+	barByKey bool
 }
 
 // NewContextMenu constructs an unlimited context menu anchored at (mouseX, mouseY).
@@ -56,16 +120,30 @@ func NewContextMenu(menu Menu, itemIndex int, items []ContextMenuItem, mouseX, m
 }
 
 func newContextMenu(menu Menu, itemIndex int, items []ContextMenuItem, mouseX, mouseY int, options ContextMenuOptions) *ContextMenu {
+	cm := newContextMenuFunc(items, mouseX, mouseY, options, func(app *App, item ContextMenuItem) (Page, tea.Cmd) {
+		return menu.ContextMenuAction(app, itemIndex, item)
+	})
+	cm.menu, cm.itemIndex = menu, itemIndex
+	return cm
+}
+
+// newContextMenuFunc constructs a context menu whose selection runs action,
+// for surfaces other than the menu list.
+func newContextMenuFunc(items []ContextMenuItem, mouseX, mouseY int, options ContextMenuOptions, action contextMenuActionFunc) *ContextMenu {
 	return &ContextMenu{
-		menu:      menu,
-		itemIndex: itemIndex,
-		items:     items,
+		action:    action,
+		itemIndex: -1,
+		items:     slices.Clone(items), // check states are toggled in place
 		mouseX:    mouseX,
 		mouseY:    mouseY,
 		maxWidth:  max(options.MaxWidth, 0),
 		maxHeight: max(options.MaxHeight, 0),
 		focused:   -1,
 		hovered:   -1,
+
+		options:      options,
+		submenuIndex: -1,
+		barNext:      -1,
 	}
 }
 
@@ -135,26 +213,195 @@ func (cm *ContextMenu) dismissEscape() {
 // complete is called after dismissal to execute the selected action.
 func (cm *ContextMenu) complete(app *App) (Page, tea.Cmd) {
 	if cm.isCanceled || cm.selected == nil {
+		if cm.bar != nil {
+			cm.bar.dropdownClosed(app, cm.barNext, cm.barByKey)
+		}
 		return nil, nil
 	}
-	return cm.menu.ContextMenuAction(app, cm.itemIndex, *cm.selected)
+	return cm.action(app, *cm.selected)
+}
+
+// hasSubmenu reports whether the item at index opens a submenu.
+func (cm *ContextMenu) hasSubmenu(index int) bool {
+	return index >= 0 && index < len(cm.items) && len(cm.items[index].Children) > 0
+}
+
+// selectItem selects the leaf item at index and dismisses the menu.
+func (cm *ContextMenu) selectItem(index int) {
+	item := cm.items[index]
+	item.Path = []string{item.ID}
+	cm.selected = &item
+	cm.isDismissed = true
+}
+
+// activate selects the item at index: it opens a submenu, or toggles a
+// checkable or radio item and selects it. A toggled KeepOpen item leaves the
+// menu open and returns a command running the action instead.
+func (cm *ContextMenu) activate(index int) tea.Cmd {
+	if cm.hasSubmenu(index) {
+		cm.openSubmenu(index, true)
+		return nil
+	}
+	item := &cm.items[index]
+	toggled := item.Checkable || item.RadioGroup != ""
+	switch {
+	case item.RadioGroup != "":
+		for i := range cm.items {
+			if cm.items[i].RadioGroup == item.RadioGroup {
+				cm.items[i].Checked = i == index
+			}
+		}
+	case item.Checkable:
+		item.Checked = !item.Checked
+	}
+	if !toggled || !item.KeepOpen {
+		cm.selectItem(index)
+		return nil
+	}
+	msg := contextMenuActionMsg{action: cm.action, item: *item}
+	msg.item.Path = cm.pathTo(index)
+	return func() tea.Msg { return msg }
 }
 
-func (cm *ContextMenu) update(msg tea.Msg) {
+// pathTo returns the IDs from the top-level menu down to the item at index.
+func (cm *ContextMenu) pathTo(index int) []string {
+	path := []string{cm.items[index].ID}
+	for parent := cm.parent; parent != nil; parent = parent.parent {
+		path = append([]string{parent.items[parent.submenuIndex].ID}, path...)
+	}
+	return path
+}
+
+// keyTarget returns the item selected by key in this menu: by its
+// accelerator or, with mnemonics, by its mnemonic letter. -1 if none.
+func (cm *ContextMenu) keyTarget(key string, mnemonics bool) int {
+	for i, item := range cm.items {
+		if !cm.isSelectable(i) {
+			continue
+		}
+		if item.Accelerator != "" && item.Accelerator == key {
+			return i
+		}
+		if mnemonics && item.Mnemonic != 0 && len([]rune(key)) == 1 &&
+			unicode.ToLower([]rune(key)[0]) == unicode.ToLower(item.Mnemonic) {
+			return i
+		}
+	}
+	return -1
+}
+
+// activateAccelerator activates the item whose accelerator is key in any
+// menu of the open chain, deepest first.
+func (cm *ContextMenu) activateAccelerator(key string) (bool, tea.Cmd) {
+	if cm.submenu != nil {
+		if ok, cmd := cm.submenu.activateAccelerator(key); ok {
+			cm.collectSubmenu()
+			return true, cmd
+		}
+	}
+	index := cm.keyTarget(key, false)
+	if index < 0 {
+		return false, nil
+	}
+	cm.focused = index
+	return true, cm.activate(index)
+}
+
+// openSubmenu opens the submenu of the item at index, optionally focusing
+// its first item (keyboard navigation).
+func (cm *ContextMenu) openSubmenu(index int, focusFirst bool) {
+	if cm.submenu == nil || cm.submenuIndex != index {
+		cm.submenu = newContextMenuFunc(cm.items[index].Children, 0, 0, cm.options, cm.action)
+		cm.submenu.parent = cm
+		cm.submenuIndex = index
+	}
+	if focusFirst && cm.submenu.focused == -1 {
+		cm.submenu.focused = cm.submenu.firstSelectableFrom(0, 1)
+	}
+}
+
+func (cm *ContextMenu) closeSubmenu() {
+	cm.submenu = nil
+	cm.submenuIndex = -1
+}
+
+// collectSubmenu closes a dismissed submenu. A selection made in it becomes
+// this menu's selection, with this level's ID prepended to its path.
+func (cm *ContextMenu) collectSubmenu() {
+	child := cm.submenu
+	if child == nil || !child.isDismissed {
+		return
+	}
+	parentID := cm.items[cm.submenuIndex].ID
+	cm.closeSubmenu()
+	if child.isCanceled || child.selected == nil {
+		return
+	}
+	item := *child.selected
+	item.Path = append([]string{parentID}, item.Path...)
+	cm.selected = &item
+	cm.isDismissed = true
+}
+
+func (cm *ContextMenu) update(msg tea.Msg) tea.Cmd {
 	keyMsg, ok := msg.(tea.KeyMsg)
 	if !ok {
-		return
+		return nil
+	}
+	if cm.parent == nil {
+		if ok, cmd := cm.activateAccelerator(keyMsg.String()); ok {
+			return cmd
+		}
+		if cm.bar != nil {
+			if index := cm.bar.mnemonicIndex(keyMsg.String(), true); index >= 0 {
+				cm.switchBarDropdown(index, true)
+				return nil
+			}
+		}
+	}
+	if cm.submenu != nil {
+		// Keys go down the chain once a submenu was entered from the keyboard;
+		// one opened by hovering is only closed by them.
+		if cm.submenu.focused >= 0 || cm.submenu.submenu != nil {
+			cmd := cm.submenu.update(msg)
+			cm.collectSubmenu()
+			return cmd
+		}
+		switch keyMsg.String() {
+		case "esc", "left", "h":
+			cm.closeSubmenu()
+			return nil
+		}
+	}
+	if index := cm.keyTarget(keyMsg.String(), true); index >= 0 {
+		cm.focused = index
+		return cm.activate(index)
 	}
 
 	switch keyMsg.String() {
 	case "esc":
 		cm.dismissEscape()
+		cm.barByKey = true
+	case "left", "h":
+		// Closes one level; the top-level menu stays open, or switches to the
+		// previous menu bar dropdown.
+		if cm.parent != nil {
+			cm.dismissEscape()
+		} else if cm.bar != nil {
+			cm.switchBarDropdown(cm.bar.step(-1), true)
+		}
+	case "right", "l":
+		if cm.hasSubmenu(cm.focused) && cm.isSelectable(cm.focused) {
+			cm.openSubmenu(cm.focused, true)
+		} else if cm.parent == nil && cm.bar != nil {
+			cm.switchBarDropdown(cm.bar.step(1), true)
+		}
 	case "enter":
 		if cm.focused >= 0 && cm.focused < len(cm.items) && cm.isSelectable(cm.focused) {
-			cm.selected = &cm.items[cm.focused]
-			cm.isDismissed = true
+			return cm.activate(cm.focused)
 		}
 	case "up", "k":
+		cm.closeSubmenu()
 		if cm.focused == -1 {
 			cm.focused = cm.firstSelectableFrom(len(cm.items)-1, -1)
 		} else {
@@ -162,6 +409,7 @@ func (cm *ContextMenu) update(msg tea.Msg) {
 		}
 		cm.ensureFocusedVisible()
 	case "down", "j":
+		cm.closeSubmenu()
 		if cm.focused == -1 {
 			cm.focused = cm.firstSelectableFrom(0, 1)
 		} else {
@@ -169,6 +417,7 @@ func (cm *ContextMenu) update(msg tea.Msg) {
 		}
 		cm.ensureFocusedVisible()
 	}
+	return nil
 }
 
 func (cm *ContextMenu) visibleItemCount() int {
@@ -197,6 +446,7 @@ func (cm *ContextMenu) isScrollable() bool {
 func (cm *ContextMenu) scrollBy(delta int) {
 	cm.scrollOffset = min(max(cm.scrollOffset+delta, 0), cm.maxScrollOffset())
 	cm.hovered = -1
+	cm.closeSubmenu()
 }
 
 func (cm *ContextMenu) ensureFocusedVisible() {
@@ -211,7 +461,56 @@ func (cm *ContextMenu) ensureFocusedVisible() {
 	}
 }
 
+// switchBarDropdown dismisses a menu bar dropdown to open the one at index.
+func (cm *ContextMenu) switchBarDropdown(index int, byKey bool) {
+	cm.dismissEscape()
+	cm.barNext, cm.barByKey = index, byKey
+}
+
+// handleBarMouse handles the mouse over the menu bar while a dropdown is
+// open: hovering another title switches to its dropdown, clicking the open
+// one closes it.
+func (cm *ContextMenu) handleBarMouse(msg tea.MouseMsg) bool {
+	mouse := msg.Mouse()
+	index := cm.bar.titleAt(mouse.X, mouse.Y)
+	if index < 0 {
+		return false
+	}
+	switch msg.(type) {
+	case tea.MouseClickMsg:
+		if index == cm.bar.focused {
+			cm.dismissEscape()
+		} else {
+			cm.switchBarDropdown(index, false)
+		}
+	case tea.MouseMotionMsg:
+		if index != cm.bar.focused {
+			cm.switchBarDropdown(index, false)
+		}
+	}
+	return true
+}
+
+// handleMouse gives the open submenu chain the first chance at the event, so
+// a click outside every menu of the chain is unhandled and closes them all.
 func (cm *ContextMenu) handleMouse(msg tea.MouseMsg) (bool, tea.Cmd) {
+	if cm.bar != nil && cm.handleBarMouse(msg) {
+		return true, nil
+	}
+	var submenuCmd tea.Cmd
+	if cm.submenu != nil {
+		handled, cmd := cm.submenu.handleMouse(msg)
+		if handled {
+			cm.collectSubmenu()
+			return true, cmd
+		}
+		submenuCmd = cmd
+	}
+	handled, cmd := cm.handleOwnMouse(msg)
+	return handled, tea.Batch(submenuCmd, cmd)
+}
+
+func (cm *ContextMenu) handleOwnMouse(msg tea.MouseMsg) (bool, tea.Cmd) {
 	mouse := msg.Mouse()
 	oldHovered := cm.hovered
 	cm.hovered = cm.itemAt(mouse.X, mouse.Y)
@@ -227,7 +526,8 @@ func (cm *ContextMenu) handleMouse(msg tea.MouseMsg) (bool, tea.Cmd) {
 	}
 
 	if !cm.boundsSet {
-		return true, hoverCmd
+		// A submenu opened since the last render doesn't own any area yet.
+		return cm.parent == nil, hoverCmd
 	}
 
 	// Check if mouse is inside bounds
@@ -250,12 +550,21 @@ func (cm *ContextMenu) handleMouse(msg tea.MouseMsg) (bool, tea.Cmd) {
 		}
 	}
 
+	// Hovering an item opens its submenu and closes any other one.
+	if cm.hovered >= 0 && cm.hovered != cm.submenuIndex {
+		cm.closeSubmenu()
+		if cm.hasSubmenu(cm.hovered) {
+			cm.openSubmenu(cm.hovered, false)
+		}
+	}
+
 	// Handle click inside menu
 	if _, isClick := msg.(tea.MouseClickMsg); isClick && mouse.Button == tea.MouseLeft {
 		if cm.hovered >= 0 && cm.hovered < len(cm.items) && cm.isSelectable(cm.hovered) {
-			cm.selected = &cm.items[cm.hovered]
-			cm.isDismissed = true
-			return true, hoverCmd
+			if cm.hasSubmenu(cm.hovered) {
+				return true, hoverCmd // already opened by hovering
+			}
+			return true, tea.Batch(hoverCmd, cm.activate(cm.hovered))
 		}
 	}
 
@@ -279,7 +588,7 @@ func (cm *ContextMenu) itemStyle(styles style.StyleSet, index int) lipgloss.Styl
 		return styles.Popup.ContextMenuItemDisabled
 	case index == cm.hovered:
 		return styles.Popup.ContextMenuItemHover
-	case index == cm.focused:
+	case index == cm.focused, index == cm.submenuIndex:
 		return styles.Popup.ContextMenuItemFocused
 	default:
 		return styles.Popup.ContextMenuItem
@@ -317,6 +626,8 @@ func (cm *ContextMenu) renderModal(styles style.StyleSet, termW, termH int) moda
 			maxLabelWidth = max(maxLabelWidth, lipgloss.Width(item.Label))
 		}
 	}
+	columns := cm.labelColumns()
+	maxLabelWidth += columns.indicator + columns.right
 
 	scrollbarWidth := 0
 	if scrolling {
@@ -351,17 +662,16 @@ func (cm *ContextMenu) renderModal(styles style.StyleSet, termW, termH int) moda
 				Width(itemWidth).
 				Render(strings.Repeat("─", itemWidth))
 		} else {
-			label := ansi.Truncate(item.Label, labelWidth, "…")
 			if item.Header {
 				row = styles.Popup.ContextMenuHeader.
 					Width(itemWidth).
 					Padding(0, 1).
-					Render(label)
+					Render(ansi.Truncate(item.Label, labelWidth, "…"))
 			} else {
 				row = cm.itemStyle(styles, itemIndex).
 					Width(itemWidth).
 					Padding(0, 1).
-					Render(label)
+					Render(itemLabel(item, labelWidth, columns))
 			}
 		}
 
@@ -395,16 +705,99 @@ func (cm *ContextMenu) renderModal(styles style.StyleSet, termW, termH int) moda
 	}
 }
 
+// contextMenuColumns are the widths reserved around the item labels of a
+// menu: the check/radio indicator on the left, and the accelerator and
+// submenu arrow on the right (each including its gap).
+type contextMenuColumns struct {
+	indicator int
+	right     int
+}
+
+func (cm *ContextMenu) labelColumns() contextMenuColumns {
+	var columns contextMenuColumns
+	for _, item := range cm.items {
+		if item.Separator || item.Header {
+			continue
+		}
+		if item.Checkable || item.RadioGroup != "" {
+			columns.indicator = 2
+		}
+		if right := itemRightLabel(item); right != "" {
+			columns.right = max(columns.right, 1+lipgloss.Width(right))
+		}
+	}
+	return columns
+}
+
+// itemRightLabel returns the right-aligned part of an item's row.
+func itemRightLabel(item ContextMenuItem) string {
+	parts := make([]string, 0, 2)
+	if item.Accelerator != "" {
+		parts = append(parts, item.Accelerator)
+	}
+	if len(item.Children) > 0 {
+		parts = append(parts, contextMenuSubmenuArrow)
+	}
+	return strings.Join(parts, " ")
+}
+
+// itemLabel lays out an item's row text within width cells.
+func itemLabel(item ContextMenuItem, width int, columns contextMenuColumns) string {
+	indicator := ""
+	if columns.indicator > 0 {
+		mark := " "
+		switch {
+		case item.RadioGroup != "" && item.Checked:
+			mark = contextMenuRadioOn
+		case item.RadioGroup != "":
+			mark = contextMenuRadioOff
+		case item.Checkable && item.Checked:
+			mark = contextMenuCheckMark
+		}
+		indicator = mark + " "
+	}
+	label := ansi.Truncate(item.Label, max(width-columns.indicator-columns.right, 1), "…")
+	label = underlineMnemonic(label, item.Mnemonic)
+	right := itemRightLabel(item)
+	if right == "" {
+		return indicator + label
+	}
+	gap := max(width-columns.indicator-lipgloss.Width(label)-lipgloss.Width(right), 1)
+	return indicator + label + strings.Repeat(" ", gap) + right
+}
+
+// underlineMnemonic underlines the first occurrence of the mnemonic letter in
+// a plain-text label.
+func underlineMnemonic(label string, mnemonic rune) string {
+	if mnemonic == 0 || strings.ContainsRune(label, ansi.ESC) {
+		return label
+	}
+	for i, r := range label {
+		if unicode.ToLower(r) == unicode.ToLower(mnemonic) {
+			end := i + len(string(r))
+			return label[:i] + ansi.Style{}.Underline(true).String() + label[i:end] +
+				ansi.Style{}.Underline(false).String() + label[end:]
+		}
+	}
+	return label
+}
+
 // computePosition calculates the top-left (x, y) for the context menu.
 // Applies flip+clamp to keep the menu fully visible.
 func (cm *ContextMenu) computePosition(termW, termH, menuW, menuH int) (int, int) {
 	// Default: start one row below the clicked item
 	x := cm.mouseX
 	y := cm.mouseY + 1
+	flipX := cm.mouseX - menuW
+	if cm.parent != nil {
+		// Submenu: beside the parent menu, its first item level with the
+		// parent item.
+		x, y, flipX = cm.anchorRight, cm.anchorY, cm.anchorLeft-menuW
+	}
 
 	// Flip horizontally if it would overflow right edge
 	if x+menuW > termW {
-		x = cm.mouseX - menuW
+		x = flipX
 		if x < 0 {
 			x = 0
 		}
@@ -449,6 +842,30 @@ func (cm *ContextMenu) setModalBounds(x, y, w, h int, itemBounds []popupRect) {
 	}
 }
 
+// submenuLayers renders the chain of open submenus beside their parent items
+// and returns them as layers above the root menu.
+func (cm *ContextMenu) submenuLayers(styles style.StyleSet, termW, termH int) []*layout.Layer {
+	var layers []*layout.Layer
+	for parent := cm; parent.submenu != nil; parent = parent.submenu {
+		row := parent.itemBounds[parent.submenuIndex]
+		if row.w == 0 {
+			// The parent item was scrolled out of view.
+			parent.closeSubmenu()
+			break
+		}
+		child := parent.submenu
+		child.anchorLeft = parent.bounds.x
+		child.anchorRight = parent.bounds.x + parent.bounds.w
+		child.anchorY = row.y - 1 // the child's top border sits above its first item
+		rendered := child.renderModal(styles, termW, termH)
+		menuW, menuH := layout.Width(rendered.content), lipgloss.Height(rendered.content)
+		x, y := child.computePosition(termW, termH, menuW, menuH)
+		child.setModalBounds(x, y, menuW, menuH, rendered.itemBounds)
+		layers = append(layers, layout.NewLayer(rendered.content).X(x).Y(y))
+	}
+	return layers
+}
+
 // allowsRightClickPassthrough returns true for ContextMenu, allowing right-click outside to reopen.
 func (cm *ContextMenu) allowsRightClickPassthrough() bool {
 	return true
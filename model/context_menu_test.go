@@ -494,3 +494,253 @@ func TestTransparentThemeContextMenuHoverUsesPrimary(t *testing.T) {
 		t.Fatalf("transparent hover foreground = %v, want primary %v", got, primary)
 	}
 }
+
+// pathMenu records the item passed to ContextMenuAction.
+type pathMenu struct {
+	testMenu
+	chosen *ContextMenuItem
+}
+
+func (m *pathMenu) ContextMenuAction(_ *App, _ int, item ContextMenuItem) (Page, tea.Cmd) {
+	m.chosen = &item
+	return nil, nil
+}
+
+func TestContextMenuCascadingSubmenus(t *testing.T) {
+	items := []ContextMenuItem{
+		{ID: "play", Label: "Play"},
+		{ID: "playlist", Label: "Add to playlist", Children: []ContextMenuItem{
+			{ID: "fav", Label: "Favorites"},
+			{ID: "more", Label: "More", Children: []ContextMenuItem{{ID: "road", Label: "Road trip"}}},
+		}},
+	}
+	menu := &pathMenu{}
+	app := NewApp(DefaultOptions())
+	app.page = &notificationMouseSpyPage{}
+	app.windowWidth, app.windowHeight = 60, 20
+	cm := NewContextMenu(menu, 0, items, 50, 2)
+	app.pushModal(cm)
+	base := strings.TrimSuffix(strings.Repeat(strings.Repeat(" ", 60)+"\n", 20), "\n")
+	render := func() string { return ansi.Strip(app.compositeModals(base)) }
+	render()
+
+	// Hovering opens the submenu; it flips left of the menu near the right edge.
+	row := cm.itemBounds[1]
+	app.Update(tea.MouseMotionMsg(tea.Mouse{X: row.x + 1, Y: row.y}))
+	out := render()
+	if cm.submenu == nil || !strings.Contains(out, "Favorites") || !strings.Contains(out, "›") {
+		t.Fatalf("hover should open the submenu:\n%s", out)
+	}
+	if sub := cm.submenu; sub.bounds.x+sub.bounds.w != cm.bounds.x || sub.itemBounds[0].y != row.y {
+		t.Fatalf("submenu at %+v, want left of %+v level with row %d", sub.bounds, cm.bounds, row.y)
+	}
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))
+	if cm.submenu != nil || !app.HasPopup() {
+		t.Fatal("esc should close only the submenu")
+	}
+
+	// Keyboard: down, right into the submenu, left back out.
+	cm.focused = 1
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyRight}))
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyLeft}))
+	if cm.submenu != nil || !app.HasPopup() {
+		t.Fatal("left should close one level")
+	}
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyRight}))
+	render()
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	if app.HasPopup() || menu.chosen == nil || strings.Join(menu.chosen.Path, "/") != "playlist/more/road" {
+		t.Fatalf("chosen = %+v, want path playlist/more/road", menu.chosen)
+	}
+
+	// An outside click closes the whole chain.
+	cm = NewContextMenu(menu, 0, items, 5, 2)
+	app.pushModal(cm)
+	render()
+	cm.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+	cm.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+	cm.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	render()
+	sub := cm.submenu
+	if sub == nil || sub.bounds.x != cm.bounds.x+cm.bounds.w {
+		t.Fatal("submenu should open right of the menu")
+	}
+	app.Update(tea.MouseClickMsg(tea.Mouse{X: sub.itemBounds[0].x, Y: sub.itemBounds[0].y, Button: tea.MouseLeft}))
+	if strings.Join(menu.chosen.Path, "/") != "playlist/fav" || app.HasPopup() {
+		t.Fatalf("clicking a submenu item: chosen = %+v", menu.chosen)
+	}
+	app.pushModal(NewContextMenu(menu, 0, items, 5, 2))
+	render()
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyUp}))
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyRight}))
+	render()
+	app.Update(tea.MouseClickMsg(tea.Mouse{X: 50, Y: 15, Button: tea.MouseLeft}))
+	if app.HasPopup() {
+		t.Fatal("an outside click should close the whole chain")
+	}
+}
+
+func TestContextMenuCheckRadioAcceleratorAndMnemonic(t *testing.T) {
+	items := []ContextMenuItem{
+		{ID: "shuffle", Label: "Shuffle", Checkable: true, KeepOpen: true, Mnemonic: 's'},
+		{Separator: true},
+		{ID: "off", Label: "Off", RadioGroup: "repeat", Checked: true},
+		{ID: "one", Label: "One", RadioGroup: "repeat", KeepOpen: true},
+		{ID: "delete", Label: "Delete", Accelerator: "ctrl+d"},
+	}
+	menu := &pathMenu{}
+	app := NewApp(DefaultOptions())
+	app.page = &notificationMouseSpyPage{}
+	app.windowWidth, app.windowHeight = 60, 20
+	cm := NewContextMenu(menu, 3, items, 2, 2)
+	app.pushModal(cm)
+
+	out := ansi.Strip(cm.renderModal(style.CurrentStyleSet(), 60, 20).content)
+	for _, want := range []string{"  Shuffle", "● Off", "○ One", "Delete  ctrl+d"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("menu lacks %q:\n%s", want, out)
+		}
+	}
+	if items[2].Checked != true {
+		t.Fatal("the caller's items must not be modified")
+	}
+
+	// Mnemonic toggles a KeepOpen item: the menu stays, the action runs via a message.
+	cmd := cm.update(tea.KeyPressMsg(tea.Key{Code: 's', Text: "s"}))
+	if cm.dismissed() || !cm.items[0].Checked || cmd == nil {
+		t.Fatal("toggling a KeepOpen item should keep the menu open")
+	}
+	app.Update(cmd())
+	if menu.chosen == nil || menu.chosen.ID != "shuffle" || !menu.chosen.Checked {
+		t.Fatalf("chosen = %+v", menu.chosen)
+	}
+
+	// Radio: checking one unchecks the rest of its group.
+	cm.focused = 3
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	if cm.items[2].Checked || !cm.items[3].Checked || !app.HasPopup() {
+		t.Fatalf("radio group = %v/%v", cm.items[2].Checked, cm.items[3].Checked)
+	}
+
+	// The accelerator selects its item and closes the menu.
+	app.Update(tea.KeyPressMsg(tea.Key{Code: 'd', Mod: tea.ModCtrl}))
+	if app.HasPopup() || menu.chosen.ID != "delete" {
+		t.Fatalf("accelerator: open=%v chosen=%+v", app.HasPopup(), menu.chosen)
+	}
+}
+
+// tabMenuHook records the tab passed to TabContextMenuAction.
+type tabMenuHook struct {
+	tab int
+}
+
+func (h *tabMenuHook) TabContextMenuItems(_ *App, _ int) []ContextMenuItem {
+	return []ContextMenuItem{{ID: "close", Label: "Close"}, {ID: "duplicate", Label: "Duplicate"}}
+}
+
+func (h *tabMenuHook) TabContextMenuAction(_ *App, index int, _ ContextMenuItem) (Page, tea.Cmd) {
+	h.tab = index
+	return nil, nil
+}
+
+func TestKeyboardContextMenuForMenuItemsAndTabs(t *testing.T) {
+	app, main := newMainForMenuMouseTest(t)
+	main.selectedIndex = 1
+	main.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyF10, Mod: tea.ModShift}), app)
+	if !app.HasPopup() {
+		t.Fatal("shift+f10 should open the selected item's context menu")
+	}
+	cm := app.modalStack[len(app.modalStack)-1].(*ContextMenu)
+	if cm.itemIndex != 1 || cm.mouseY != main.menuListStartRow+1 || cm.focused != 0 {
+		t.Fatalf("menu for item %d at row %d, focused %d", cm.itemIndex, cm.mouseY, cm.focused)
+	}
+
+	hook := &tabMenuHook{tab: -1}
+	app, main = newDynamicTabsMain(t, func(o *Options) { o.TabContextMenu = hook })
+	app.page = &notificationMouseSpyPage{}
+	main.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyF6}), app)
+	main.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyRight}), app)
+	if main.focusZone != focusZoneTabs || main.tabHighlight() != 1 || main.ActiveTab() != 0 {
+		t.Fatalf("focus zone %d, highlighted tab %d", main.focusZone, main.tabHighlight())
+	}
+	main.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyF10, Mod: tea.ModShift}), app)
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	if app.HasPopup() || hook.tab != 1 {
+		t.Fatalf("tab context menu action got tab %d", hook.tab)
+	}
+	// The focus zone key is configurable; F6 then reaches the page.
+	app, main = newDynamicTabsMain(t, func(o *Options) { o.ContextMenuOptions.FocusZoneKey = "ctrl+g" })
+	main.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyF6}), app)
+	if main.focusZone != focusZoneMenu {
+		t.Fatal("f6 should not switch the focus zone once another key is configured")
+	}
+	main.Update(tea.KeyPressMsg(tea.Key{Code: 'g', Mod: tea.ModCtrl}), app)
+	if main.focusZone != focusZoneTabs {
+		t.Fatalf("ctrl+g should switch the focus zone, got %d", main.focusZone)
+	}
+}
+
+// volumeComponent is a status bar component with a context menu.
+type volumeComponent struct{}
+
+func (volumeComponent) View(_ *App, _ *Main) string { return "VOL" }
+
+func (volumeComponent) HandleMouse(_ tea.Mouse, _, _ int) (bool, tea.Cmd) { return false, nil }
+
+func (volumeComponent) IsMouseOver(_, _ int) bool { return true }
+
+func (volumeComponent) StatusBarContextMenuItems(_ *App) []ContextMenuItem {
+	return []ContextMenuItem{{ID: "mute", Label: "Mute"}}
+}
+
+func (volumeComponent) StatusBarContextMenuAction(_ *App, _ ContextMenuItem) (Page, tea.Cmd) {
+	return nil, nil
+}
+
+// emptyAreaHook supplies the blank-space context menu.
+type emptyAreaHook struct{}
+
+func (emptyAreaHook) EmptyAreaContextMenuItems(_ *App) []ContextMenuItem {
+	return []ContextMenuItem{{ID: "refresh", Label: "Refresh"}}
+}
+
+func (emptyAreaHook) EmptyAreaContextMenuAction(_ *App, _ ContextMenuItem) (Page, tea.Cmd) {
+	return nil, nil
+}
+
+func TestRightClickOpensSurfaceContextMenus(t *testing.T) {
+	app, main := newDynamicTabsMain(t, func(o *Options) {
+		o.TabContextMenu = &tabMenuHook{}
+		o.EmptyAreaContextMenu = emptyAreaHook{}
+		o.StatusBar = &DefaultStatusBar{Components: []StatusBarComponent{volumeComponent{}}}
+	})
+	view := ansi.Strip(main.View(app))
+	row := main.statusBarRowY(app)
+	line := strings.Split(view, "\n")[row]
+	i := strings.Index(line, "VOL")
+	if i < 0 {
+		t.Fatalf("status bar row %d lacks the component:\n%s", row, view)
+	}
+	volX := ansi.StringWidth(line[:i])
+
+	for _, tc := range []struct {
+		name   string
+		x, y   int
+		wantID string
+	}{
+		{"tab header", main.tabs.tabSpans(style.CurrentStyleSet())[1].start + 1, main.tabBarTop() + 1, "close"},
+		{"status bar component", volX, row, "mute"},
+		{"empty area", 2, app.windowHeight - 3, "refresh"},
+	} {
+		app.modalStack = nil
+		main.mouseClickHandle(tea.Mouse{X: tc.x, Y: tc.y, Button: tea.MouseRight}, app)
+		if !app.HasPopup() {
+			t.Fatalf("%s: right click opened no context menu", tc.name)
+		}
+		if cm := app.modalStack[len(app.modalStack)-1].(*ContextMenu); cm.items[0].ID != tc.wantID {
+			t.Fatalf("%s: first item = %q, want %q", tc.name, cm.items[0].ID, tc.wantID)
+		}
+	}
+}
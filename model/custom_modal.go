@@ -0,0 +1,121 @@
+package model
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// CustomModal is an overlay that is neither a Popup nor a ContextMenu, e.g. an
+// emoji picker grid, a floating lyrics window or a mini player. Show it with
+// App.PushModal; it then stacks with the built-in modals, receives input only
+// while it is on top, and follows the same outside-click rules.
+type CustomModal interface {
+	// View renders the modal for a terminal of the given size. The content is
+	// drawn as-is: the modal paints its own frame and background.
+	View(termWidth, termHeight int) string
+
+	// Placement returns the anchor and offset used to position the rendered
+	// content, clamped to the screen like a Popup.
+	Placement() (anchor PopupAnchor, offsetX, offsetY int)
+
+	// Update handles a key press while the modal is on top.
+	Update(msg tea.KeyMsg) tea.Cmd
+
+	// HandleMouse handles a mouse event inside the modal's bounds, with
+	// coordinates relative to its top-left corner. Events outside the bounds
+	// are not delivered: a left click there calls DismissOutside.
+	HandleMouse(msg tea.MouseMsg) tea.Cmd
+
+	// Dismissed reports whether the modal should be removed from the stack.
+	// It is checked after every Update and HandleMouse.
+	Dismissed() bool
+
+	// DismissOutside is called on a left click outside the modal. Return
+	// false to stay open; the click is consumed either way.
+	DismissOutside() bool
+
+	// Complete is called once after the modal was removed from the stack. A
+	// non-nil Page replaces the current page.
+	Complete(a *App) (Page, tea.Cmd)
+}
+
+// RightClickPassthrough can be implemented by a CustomModal that, like a
+// context menu, closes on a right click outside it and lets the click reach
+// the page underneath. DismissOutside can still keep it open, consuming the
+// click.
+type RightClickPassthrough interface {
+	AllowsRightClickPassthrough() bool
+}
+
+// customModal adapts a CustomModal to the internal Modal protocol and tracks
+// its screen bounds.
+type customModal struct {
+	m         CustomModal
+	bounds    popupRect
+	boundsSet bool
+}
+
+// PushModal pushes a custom modal onto the modal stack. The topmost modal
+// receives input first. A nil modal is ignored.
+func (a *App) PushModal(m CustomModal) {
+	if m == nil {
+		return
+	}
+	a.pushModal(&customModal{m: m})
+}
+
+func (c *customModal) update(msg tea.Msg) tea.Cmd {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		return c.m.Update(key)
+	}
+	return nil
+}
+
+func (c *customModal) handleMouse(msg tea.MouseMsg) (bool, tea.Cmd) {
+	mouse := msg.Mouse()
+	if !c.boundsSet || !c.bounds.contains(mouse.X, mouse.Y) {
+		return false, nil
+	}
+	mouse.X -= c.bounds.x
+	mouse.Y -= c.bounds.y
+	var relative tea.MouseMsg
+	switch msg.(type) {
+	case tea.MouseClickMsg:
+		relative = tea.MouseClickMsg(mouse)
+	case tea.MouseReleaseMsg:
+		relative = tea.MouseReleaseMsg(mouse)
+	case tea.MouseWheelMsg:
+		relative = tea.MouseWheelMsg(mouse)
+	default:
+		relative = tea.MouseMotionMsg(mouse)
+	}
+	return true, c.m.HandleMouse(relative)
+}
+
+func (c *customModal) dismissed() bool {
+	return c.m.Dismissed()
+}
+
+func (c *customModal) dismissOutside() bool {
+	return c.m.DismissOutside()
+}
+
+func (c *customModal) complete(app *App) (Page, tea.Cmd) {
+	return c.m.Complete(app)
+}
+
+func (c *customModal) allowsRightClickPassthrough() bool {
+	p, ok := c.m.(RightClickPassthrough)
+	return ok && p.AllowsRightClickPassthrough()
+}
+
+// render renders the modal and records its bounds for hit-testing.
+func (c *customModal) render(termW, termH int) (string, int, int) {
+	content := c.m.View(termW, termH)
+	w, h := lipgloss.Width(content), lipgloss.Height(content)
+	anchor, offsetX, offsetY := c.m.Placement()
+	x, y := placeAnchored(anchor, offsetX, offsetY, termW, termH, w, h)
+	c.bounds = popupRect{x: x, y: y, w: w, h: h}
+	c.boundsSet = true
+	return content, x, y
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// gridModal is a minimal CustomModal recording the input it receives.
+type gridModal struct {
+	keys      []string
+	clicks    []tea.Mouse
+	stayOpen  bool
+	done      bool
+	completed int
+}
+
+func (g *gridModal) View(int, int) string { return "┌──┐\n│ab│\n└──┘" }
+func (g *gridModal) Placement() (PopupAnchor, int, int) {
+	return AnchorTopLeft, 10, 5
+}
+func (g *gridModal) Update(msg tea.KeyMsg) tea.Cmd {
+	g.keys = append(g.keys, msg.String())
+	g.done = msg.String() == "enter"
+	return nil
+}
+func (g *gridModal) HandleMouse(msg tea.MouseMsg) tea.Cmd {
+	if _, ok := msg.(tea.MouseClickMsg); ok {
+		g.clicks = append(g.clicks, msg.Mouse())
+	}
+	return nil
+}
+func (g *gridModal) Dismissed() bool               { return g.done }
+func (g *gridModal) DismissOutside() bool          { g.done = !g.stayOpen; return g.done }
+func (g *gridModal) Complete(*App) (Page, tea.Cmd) { g.completed++; return nil, nil }
+
+// passthroughGridModal is a gridModal letting right clicks through.
+type passthroughGridModal struct{ gridModal }
+
+func (g *passthroughGridModal) AllowsRightClickPassthrough() bool { return true }
+
+func TestCustomModalStacksRendersAndRoutesInput(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	app.page = &notificationMouseSpyPage{}
+	app.windowWidth, app.windowHeight = 40, 12
+
+	grid := &gridModal{stayOpen: true}
+	app.PushModal(grid)
+	app.PushModal(nil)
+	base := strings.TrimSuffix(strings.Repeat(strings.Repeat(" ", 40)+"\n", 12), "\n")
+	lines := strings.Split(app.compositeModals(base), "\n")
+	if !strings.Contains(lines[6], "│ab│") || strings.Index(lines[6], "│") != 10 {
+		t.Fatalf("modal not drawn at (10, 5):\n%s", strings.Join(lines, "\n"))
+	}
+	if x, y, w, h, ok := app.TopModalBounds(); !ok || x != 10 || y != 5 || w != 4 || h != 3 {
+		t.Fatalf("TopModalBounds = %d,%d %dx%d ok=%v", x, y, w, h, ok)
+	}
+
+	app.Update(tea.MouseClickMsg(tea.Mouse{X: 12, Y: 6, Button: tea.MouseLeft}))
+	if len(grid.clicks) != 1 || grid.clicks[0].X != 2 || grid.clicks[0].Y != 1 {
+		t.Fatalf("clicks = %+v, want one at modal-relative (2, 1)", grid.clicks)
+	}
+	app.Update(tea.MouseClickMsg(tea.Mouse{X: 0, Y: 0, Button: tea.MouseLeft}))
+	if !app.HasPopup() || grid.completed != 0 {
+		t.Fatal("a modal declining DismissOutside should stay open")
+	}
+
+	// Right-click passthrough also respects the DismissOutside veto.
+	app.PushModal(&passthroughGridModal{gridModal{stayOpen: true}})
+	app.Update(tea.MouseClickMsg(tea.Mouse{X: 0, Y: 0, Button: tea.MouseRight}))
+	if len(app.modalStack) != 2 {
+		t.Fatal("a passthrough modal declining DismissOutside should stay open on a right click")
+	}
+	app.modalStack = app.modalStack[:1]
+
+	app.Update(tea.KeyPressMsg(tea.Key{Code: 'x', Text: "x"}))
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	if strings.Join(grid.keys, ",") != "x,enter" || app.HasPopup() || grid.completed != 1 {
+		t.Fatalf("keys=%v open=%v completed=%d", grid.keys, app.HasPopup(), grid.completed)
+	}
+}
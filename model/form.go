@@ -1,11 +1,13 @@
 package model
 
 import (
+	"context"
 	"strings"
 
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/layout"
 	"github.com/anhoder/foxful-cli/style"
 )
 
@@ -16,50 +18,107 @@ type FormField struct {
 	Placeholder string
 	Required    bool
 	Validate    func(string) error
+
+	// Type selects the input widget; the zero value is a single-line text
+	// input. Validate receives the value in its text form (see Form.Values).
+	Type FormFieldType
+	// Default is the initial value in its text form, restored by Reset.
+	Default string
+	// Options are the choices of FieldSelect and FieldRadio fields.
+	Options []string
+	// Min, Max and Step configure FieldNumber fields. Values are limited to
+	// [Min, Max] when Max > Min; Step defaults to 1.
+	Min, Max, Step float64
+	// Rows is the visible height of a FieldTextarea (default 3).
+	Rows int
+	// Section groups consecutive fields under a heading drawn above the
+	// first of them.
+	Section string
+	// ValidateAsync runs off the UI thread once the value passes the other
+	// checks, e.g. to ask a service whether a username is taken. ctx is
+	// cancelled when the value is edited; the field shows a pending marker
+	// until it returns.
+	ValidateAsync func(ctx context.Context, value string) error
+	// Visible shows the field only while it returns true for the current
+	// values of all fields. Hidden fields are skipped by navigation and
+	// validation and left out of Values.
+	Visible func(values map[string]string) bool
 }
 
 // Form is a composable multi-field input widget with validation and focus management.
 type Form struct {
 	fields []FormField
 	inputs []textinput.Model
+	states []formFieldState
 	errors []error
 
+	validators  []FormValidator
+	crossErrors []error // per field, from validators
+
 	focusedIdx int
 	focused    bool
 	submitted  bool
+	// submitRequested is set while a submit waits for async validators;
+	// applyValidation completes it and then calls onAsyncSubmit, if set.
+	submitRequested bool
+	onAsyncSubmit   func()
 
 	width  int
 	height int
+
+	binding *formBinding // set by NewFormFor
 }
 
 // NewForm creates a new Form with the given field definitions.
 // Returns a Form ready for embedding in a host model.
 func NewForm(fields []FormField) *Form {
 	inputs := make([]textinput.Model, len(fields))
+	states := make([]formFieldState, len(fields))
 	errors := make([]error, len(fields))
 
 	for i, field := range fields {
-		ti := textinput.New()
-		ti.Placeholder = field.Placeholder
-		ti.CharLimit = 256
-		inputs[i] = ti
+		inputs[i] = newFieldInput(field)
+		states[i].choice = -1
 	}
 
-	return &Form{
-		fields:     fields,
-		inputs:     inputs,
-		errors:     errors,
-		focusedIdx: 0,
-		focused:    false,
-		submitted:  false,
+	f := &Form{
+		fields:      fields,
+		inputs:      inputs,
+		states:      states,
+		errors:      errors,
+		crossErrors: make([]error, len(fields)),
+		focusedIdx:  0,
+		focused:     false,
+		submitted:   false,
+	}
+	for i, field := range fields {
+		f.setFieldValue(i, field.Default)
+	}
+	return f
+}
+
+// focusInput focuses the textinput of field idx, if it has one.
+func (f *Form) focusInput(idx int) tea.Cmd {
+	if !f.fields[idx].Type.usesInput() {
+		return nil
 	}
+	return f.inputs[idx].Focus()
+}
+
+// blurField blurs field idx and closes its dropdown.
+func (f *Form) blurField(idx int) {
+	f.inputs[idx].Blur()
+	f.states[idx].open = false
 }
 
 // Focus marks the form as focused and focuses the first field.
 func (f *Form) Focus() {
 	f.focused = true
 	if len(f.inputs) > 0 {
-		f.inputs[f.focusedIdx].Focus()
+		if f.hidden(f.focusedIdx) {
+			f.focusedIdx = f.visibleFrom(f.focusedIdx, 1)
+		}
+		f.focusInput(f.focusedIdx)
 	}
 }
 
@@ -67,7 +126,7 @@ func (f *Form) Focus() {
 func (f *Form) Blur() {
 	f.focused = false
 	for i := range f.inputs {
-		f.inputs[i].Blur()
+		f.blurField(i)
 	}
 }
 
@@ -82,14 +141,34 @@ func (f *Form) SetSize(width, height int) {
 	f.height = height
 }
 
-// Update handles input events for the form.
+// Update handles input events for the form. Hosts must also forward the
+// results of async validators; App applies them itself.
 func (f *Form) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(formValidationMsg); ok {
+		if msg.form == f {
+			f.applyValidation(msg)
+		}
+		return nil
+	}
 	if !f.focused || len(f.inputs) == 0 {
 		return nil
 	}
 
+	// An edit invalidates the field's async and form-level results.
+	idx, before := f.focusedIdx, f.fieldValue(f.focusedIdx)
+	cmd := f.update(msg)
+	if f.fieldValue(idx) != before {
+		f.fieldEdited(idx)
+	}
+	return cmd
+}
+
+func (f *Form) update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if handled, cmd := f.updateField(msg); handled {
+			return cmd
+		}
 		key := msg.String()
 		switch key {
 		case "tab", "down":
@@ -108,53 +187,82 @@ func (f *Form) Update(msg tea.Msg) tea.Cmd {
 	}
 
 	// Forward message to focused input
+	if !f.fields[f.focusedIdx].Type.usesInput() {
+		return nil
+	}
 	var cmd tea.Cmd
 	f.inputs[f.focusedIdx], cmd = f.inputs[f.focusedIdx].Update(msg)
 	return cmd
 }
 
-// nextField moves focus to the next field.
+// nextField moves focus to the next visible field.
 func (f *Form) nextField() tea.Cmd {
-	// Validate current field on blur
-	f.validateField(f.focusedIdx)
-
-	// Move focus
-	f.inputs[f.focusedIdx].Blur()
-	f.focusedIdx++
-	if f.focusedIdx >= len(f.inputs) {
-		f.focusedIdx = 0
-	}
-	return f.inputs[f.focusedIdx].Focus()
+	return f.moveFocus(1)
 }
 
-// prevField moves focus to the previous field.
+// prevField moves focus to the previous visible field.
 func (f *Form) prevField() tea.Cmd {
+	return f.moveFocus(-1)
+}
+
+// moveFocus validates the focused field and moves focus dir (-1/1) fields
+// away, skipping hidden ones.
+func (f *Form) moveFocus(dir int) tea.Cmd {
 	// Validate current field on blur
-	f.validateField(f.focusedIdx)
+	left := f.focusedIdx
+	f.validateField(left)
+	f.states[left].touched = true
+	f.runValidators()
+	asyncCmd := f.startAsync(left)
 
 	// Move focus
-	f.inputs[f.focusedIdx].Blur()
-	f.focusedIdx--
-	if f.focusedIdx < 0 {
-		f.focusedIdx = len(f.inputs) - 1
+	f.blurField(left)
+	f.focusedIdx = f.visibleFrom(left+dir, dir)
+	return tea.Batch(asyncCmd, f.focusInput(f.focusedIdx))
+}
+
+// visibleFrom returns the first visible field from idx on in direction dir,
+// wrapping around; idx itself (wrapped) when none is visible.
+func (f *Form) visibleFrom(idx, dir int) int {
+	n := len(f.fields)
+	idx = (idx%n + n) % n
+	for range n {
+		if !f.hidden(idx) {
+			return idx
+		}
+		idx = ((idx+dir)%n + n) % n
 	}
-	return f.inputs[f.focusedIdx].Focus()
+	return idx
 }
 
-// trySubmit attempts to submit the form if all validations pass.
+// trySubmit attempts to submit the form if all validations pass. Async
+// validators not yet run for the current values are started; the submission
+// completes once they are done, unless a field is edited meanwhile.
 func (f *Form) trySubmit() tea.Cmd {
 	// Validate all fields
+	var cmds []tea.Cmd
 	for i := range f.fields {
 		f.validateField(i)
+		f.states[i].touched = true
+	}
+	f.runValidators()
+	for i := range f.fields {
+		cmds = append(cmds, f.startAsync(i))
+	}
+	f.submitRequested = f.Pending()
+	if f.submitRequested {
+		return tea.Batch(cmds...)
 	}
+	f.submit()
+	return nil
+}
 
-	// Check if form is valid
-	if f.IsValid() {
+// submit marks the form submitted if it is valid and, with a binding, its
+// values convert.
+func (f *Form) submit() {
+	if f.IsValid() && (f.binding == nil || f.binding.decode(f)) {
 		f.submitted = true
-		return nil
 	}
-
-	return nil
 }
 
 // validateField validates a single field and stores the error.
@@ -164,8 +272,13 @@ func (f *Form) validateField(idx int) {
 	}
 
 	field := f.fields[idx]
-	value := f.inputs[idx].Value()
-	empty := strings.TrimSpace(value) == ""
+	value := f.fieldValue(idx)
+	empty := f.fieldEmpty(idx)
+
+	if f.hidden(idx) {
+		f.errors[idx] = nil
+		return
+	}
 
 	// Check required
 	if field.Required && empty {
@@ -180,6 +293,12 @@ func (f *Form) validateField(idx int) {
 		return
 	}
 
+	// Numbers and dates must parse before custom validation sees them.
+	if err := checkFieldType(field, value); err != nil {
+		f.errors[idx] = err
+		return
+	}
+
 	// Run custom validation if provided
 	if field.Validate != nil {
 		f.errors[idx] = field.Validate(value)
@@ -203,21 +322,42 @@ func (e *requiredFieldError) Error() string {
 	return T(MsgFieldRequired)
 }
 
-// IsValid returns true if all field validations pass.
+// IsValid returns true if all field validations pass and none is pending.
 func (f *Form) IsValid() bool {
-	for _, err := range f.errors {
-		if err != nil {
+	for i := range f.fields {
+		if f.hidden(i) {
+			continue
+		}
+		if f.fieldError(i) != nil || f.states[i].async.pending {
 			return false
 		}
 	}
 	return true
 }
 
-// Values returns a map of field keys to their current values.
+// Values returns a map of field keys to their current values in text form:
+// "true"/"false" for checkboxes, the chosen option (or "") for selects and
+// radios, lines joined by "\n" for textareas. Hidden fields are left out.
 func (f *Form) Values() map[string]string {
-	values := make(map[string]string, len(f.fields))
+	values := f.allValues()
+	for i, field := range f.fields {
+		if f.hidden(i) {
+			delete(values, field.Key)
+		}
+	}
+	return values
+}
+
+// TypedValues returns a map of field keys to their current values as Go
+// values: bool for checkboxes, float64 for numbers and time.Time for dates
+// (nil while empty or invalid), string for the other types. Hidden fields
+// are left out.
+func (f *Form) TypedValues() map[string]any {
+	values := make(map[string]any, len(f.fields))
 	for i, field := range f.fields {
-		values[field.Key] = f.inputs[i].Value()
+		if !f.hidden(i) {
+			values[field.Key] = f.typedFieldValue(i)
+		}
 	}
 	return values
 }
@@ -227,15 +367,25 @@ func (f *Form) Submitted() bool {
 	return f.submitted
 }
 
-// Reset clears the form submission state and all field values and errors.
+// Reset clears the form submission state and errors and restores every field
+// to its Default value.
 func (f *Form) Reset() {
-	f.submitted = false
+	f.submitted, f.submitRequested = false, false
 	for i := range f.inputs {
-		f.inputs[i].SetValue("")
+		f.setFieldValue(i, f.fields[i].Default)
+		f.cancelAsync(i)
+		f.states[i].open, f.states[i].touched = false, false
 		f.errors[i] = nil
+		f.crossErrors[i] = nil
 	}
 }
 
+// capturesKey reports whether the form needs a key a hosting popup would
+// otherwise use to close, e.g. esc closing a select dropdown.
+func (f *Form) capturesKey(key string) bool {
+	return key == "esc" && f.focused && len(f.states) > 0 && f.states[f.focusedIdx].open
+}
+
 // View renders the form.
 func (f *Form) View() string {
 	if len(f.fields) == 0 {
@@ -254,8 +404,23 @@ func (f *Form) View() string {
 	}
 
 	var b strings.Builder
+	dropdown, dropdownY := -1, 0
 
+	shown, section := false, ""
 	for i, field := range f.fields {
+		if f.hidden(i) {
+			continue
+		}
+
+		// Section heading
+		if field.Section != "" && field.Section != section {
+			if shown {
+				b.WriteString("\n")
+			}
+			b.WriteString(styles.Title.Render(field.Section))
+			b.WriteString("\n")
+		}
+
 		// Label - right-aligned in fixed-width column
 		labelStyle := styles.MenuItem
 		if i == f.focusedIdx && f.focused {
@@ -294,19 +459,32 @@ func (f *Form) View() string {
 		if availWidth < 20 {
 			availWidth = 20
 		}
-		f.inputs[i].SetWidth(availWidth)
 
-		inputView := f.inputs[i].View()
+		inputView := f.fieldView(i, i == f.focusedIdx && f.focused, availWidth, styles)
 
-		// Render line
+		// Render line; further lines of a textarea line up under the first.
+		if f.states[i].open {
+			dropdown = i
+			dropdownY = strings.Count(b.String(), "\n") + 1
+		}
+		indent := styles.AppBackground.Render(strings.Repeat(" ", labelWidth+3))
 		b.WriteString(labelStyle.Render(paddedLabel))
 		b.WriteString(styles.AppBackground.Render(" "))
-		b.WriteString(inputView)
+		b.WriteString(strings.ReplaceAll(inputView, "\n", "\n"+indent))
 		b.WriteString("\n")
 
+		shown, section = true, field.Section
+
+		// Pending async validation
+		if f.errors[i] == nil && f.states[i].async.pending {
+			b.WriteString(styles.AppBackground.Render(strings.Repeat(" ", labelWidth+3)))
+			b.WriteString(styles.Muted.Render(T(MsgFieldValidating)))
+			b.WriteString("\n")
+		}
+
 		// Error message
-		if f.errors[i] != nil {
-			errorMsg := f.errors[i].Error()
+		if err := f.fieldError(i); err != nil {
+			errorMsg := err.Error()
 			errorStyle := styles.Error
 			padding := styles.AppBackground.Render(strings.Repeat(" ", labelWidth+3))
 			b.WriteString(padding)
@@ -315,5 +493,10 @@ func (f *Form) View() string {
 		}
 	}
 
-	return strings.TrimSuffix(b.String(), "\n")
+	out := strings.TrimSuffix(b.String(), "\n")
+	if dropdown >= 0 {
+		// The open select's dropdown overlays the fields below it.
+		out = layout.Overlay(out, f.selectDropdownView(dropdown, styles), labelWidth+3, dropdownY)
+	}
+	return out
 }
@@ -0,0 +1,621 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// FormFieldType selects the input widget of a FormField.
+type FormFieldType int
+
+const (
+	// FieldText is a single-line text input (default).
+	FieldText FormFieldType = iota
+	// FieldCheckbox is a boolean toggle; space or x toggles it. Required
+	// means it must be checked.
+	FieldCheckbox
+	// FieldSelect picks one of Options from a dropdown opened with enter or
+	// space; left/right cycle without opening it.
+	FieldSelect
+	// FieldRadio picks one of Options, all shown inline; left/right move the
+	// choice.
+	FieldRadio
+	// FieldTextarea is a multi-line text input of Rows visible rows. Enter
+	// inserts a line break; up/down leave the field from its first/last line.
+	FieldTextarea
+	// FieldNumber is a numeric input; up/down step the value by Step within
+	// Min/Max.
+	FieldNumber
+	// FieldPassword is a masked text input; ctrl+r reveals the value.
+	FieldPassword
+	// FieldDate is a date input in FormDateLayout; up/down step it by a day.
+	FieldDate
+)
+
+// FormDateLayout is the text format of FieldDate values.
+const FormDateLayout = time.DateOnly
+
+const (
+	formRevealKey       = "ctrl+r"
+	formTextareaRows    = 3
+	formCheckboxOn      = "[x]"
+	formCheckboxOff     = "[ ]"
+	formSelectIndicator = "▾"
+)
+
+// formFieldState is the input state of the non-textinput field types.
+type formFieldState struct {
+	checked bool // FieldCheckbox
+	choice  int  // FieldSelect, FieldRadio: index into Options, -1 = none
+	open    bool // FieldSelect: the dropdown is open
+	cursor  int  // FieldSelect: highlighted option of the open dropdown
+	area    formTextarea
+
+	touched bool // validated on its own, by leaving it or on submit
+	async   formAsyncState
+}
+
+// usesInput reports whether the field type edits its value in a textinput.
+func (t FormFieldType) usesInput() bool {
+	switch t {
+	case FieldText, FieldNumber, FieldPassword, FieldDate:
+		return true
+	}
+	return false
+}
+
+// newFieldInput returns the textinput of a field.
+func newFieldInput(field FormField) textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = field.Placeholder
+	ti.CharLimit = 256
+	switch field.Type {
+	case FieldPassword:
+		ti.EchoMode = textinput.EchoPassword
+	case FieldDate:
+		if ti.Placeholder == "" {
+			ti.Placeholder = "YYYY-MM-DD"
+		}
+	}
+	return ti
+}
+
+// fieldValue returns the value of field idx as text: "true"/"false" for
+// checkboxes, the chosen option for selects and radios.
+func (f *Form) fieldValue(idx int) string {
+	field, state := f.fields[idx], &f.states[idx]
+	switch field.Type {
+	case FieldCheckbox:
+		return strconv.FormatBool(state.checked)
+	case FieldSelect, FieldRadio:
+		if state.choice < 0 || state.choice >= len(field.Options) {
+			return ""
+		}
+		return field.Options[state.choice]
+	case FieldTextarea:
+		return state.area.value()
+	}
+	return f.inputs[idx].Value()
+}
+
+// setFieldValue sets the value of field idx from its text form (see
+// fieldValue). Unknown options leave a select or radio without a choice.
+func (f *Form) setFieldValue(idx int, value string) {
+	field, state := f.fields[idx], &f.states[idx]
+	switch field.Type {
+	case FieldCheckbox:
+		state.checked, _ = strconv.ParseBool(value)
+	case FieldSelect, FieldRadio:
+		state.choice = -1
+		for i, option := range field.Options {
+			if option == value {
+				state.choice = i
+			}
+		}
+		state.cursor = max(state.choice, 0)
+	case FieldTextarea:
+		state.area.setValue(value)
+	default:
+		f.inputs[idx].SetValue(value)
+	}
+}
+
+// typedFieldValue returns the value of field idx as a Go value: bool for
+// checkboxes, float64 for numbers, time.Time for dates (nil while empty or
+// invalid) and string otherwise.
+func (f *Form) typedFieldValue(idx int) any {
+	value := f.fieldValue(idx)
+	switch f.fields[idx].Type {
+	case FieldCheckbox:
+		return f.states[idx].checked
+	case FieldNumber:
+		if n, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			return n
+		}
+		return nil
+	case FieldDate:
+		if t, err := time.Parse(FormDateLayout, strings.TrimSpace(value)); err == nil {
+			return t
+		}
+		return nil
+	}
+	return value
+}
+
+// fieldEmpty reports whether field idx counts as empty for Required.
+func (f *Form) fieldEmpty(idx int) bool {
+	if f.fields[idx].Type == FieldCheckbox {
+		return !f.states[idx].checked
+	}
+	return strings.TrimSpace(f.fieldValue(idx)) == ""
+}
+
+// checkFieldType validates a non-empty value against the field type.
+func checkFieldType(field FormField, value string) error {
+	value = strings.TrimSpace(value)
+	switch field.Type {
+	case FieldNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return formFieldError(T(MsgFieldInvalidNumber))
+		}
+		if field.bounded() && (n < field.Min || n > field.Max) {
+			return formFieldError(Tf(MsgFieldOutOfRange, formatNumber(field.Min), formatNumber(field.Max)))
+		}
+	case FieldDate:
+		if _, err := time.Parse(FormDateLayout, value); err != nil {
+			return formFieldError(Tf(MsgFieldInvalidDate, FormDateLayout))
+		}
+	}
+	return nil
+}
+
+// formFieldError is a validation message produced by the form itself.
+type formFieldError string
+
+func (e formFieldError) Error() string { return string(e) }
+
+// bounded reports whether Min/Max limit a number field.
+func (field FormField) bounded() bool {
+	return field.Max > field.Min
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// updateField handles a key for the type-specific behaviour of the focused
+// field. It returns false for keys left to the form's navigation and the
+// textinput.
+func (f *Form) updateField(msg tea.KeyMsg) (bool, tea.Cmd) {
+	idx := f.focusedIdx
+	field, state := f.fields[idx], &f.states[idx]
+	key := msg.String()
+	changed := true
+
+	switch field.Type {
+	case FieldCheckbox:
+		switch key {
+		case "space", "x":
+			state.checked = !state.checked
+		default:
+			return false, nil
+		}
+	case FieldSelect:
+		if state.open {
+			switch key {
+			case "up", "k":
+				state.cursor = (state.cursor - 1 + len(field.Options)) % len(field.Options)
+			case "down", "j":
+				state.cursor = (state.cursor + 1) % len(field.Options)
+			case "enter", "space":
+				state.choice, state.open = state.cursor, false
+			case "esc":
+				state.open = false
+			}
+			// The open dropdown keeps every key.
+			f.revalidate(idx)
+			return true, nil
+		}
+		switch key {
+		case "enter", "space":
+			if len(field.Options) > 0 {
+				state.open, state.cursor = true, max(state.choice, 0)
+			}
+			return true, nil
+		case "left", "right":
+			f.stepChoice(idx, key)
+		default:
+			return false, nil
+		}
+	case FieldRadio:
+		switch key {
+		case "left", "right", "space":
+			f.stepChoice(idx, key)
+		default:
+			return false, nil
+		}
+	case FieldTextarea:
+		switch key {
+		case "up":
+			return state.area.moveLine(-1), nil
+		case "down":
+			return state.area.moveLine(1), nil
+		case "enter":
+			state.area.insert("\n")
+		case "tab", "shift+tab":
+			return false, nil
+		default:
+			changed = state.area.edit(msg)
+			if !changed {
+				return false, nil
+			}
+		}
+	case FieldNumber:
+		switch key {
+		case "up", "down":
+			f.stepNumber(idx, key == "up")
+		default:
+			if text := msg.Key().Text; text != "" && strings.Trim(text, "0123456789.-+eE") != "" {
+				return true, nil // not part of a number
+			}
+			return false, nil
+		}
+	case FieldDate:
+		switch key {
+		case "up", "down":
+			f.stepDate(idx, key == "up")
+		default:
+			return false, nil
+		}
+	case FieldPassword:
+		if key != formRevealKey {
+			return false, nil
+		}
+		if f.inputs[idx].EchoMode == textinput.EchoPassword {
+			f.inputs[idx].EchoMode = textinput.EchoNormal
+		} else {
+			f.inputs[idx].EchoMode = textinput.EchoPassword
+		}
+		return true, nil
+	default:
+		return false, nil
+	}
+	if changed {
+		f.revalidate(idx)
+	}
+	return true, nil
+}
+
+// stepChoice moves the choice of a select or radio field: left to the
+// previous option, right and space to the next one.
+func (f *Form) stepChoice(idx int, key string) {
+	n := len(f.fields[idx].Options)
+	if n == 0 {
+		return
+	}
+	state := &f.states[idx]
+	switch {
+	case state.choice < 0:
+		state.choice = 0
+	case key == "left":
+		state.choice = (state.choice - 1 + n) % n
+	default:
+		state.choice = (state.choice + 1) % n
+	}
+	state.cursor = state.choice
+}
+
+// stepNumber adds or subtracts Step (default 1), clamped to Min/Max.
+func (f *Form) stepNumber(idx int, up bool) {
+	field := f.fields[idx]
+	step := field.Step
+	if step <= 0 {
+		step = 1
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(f.inputs[idx].Value()), 64)
+	switch {
+	case err != nil && field.bounded():
+		n = field.Min
+	case err != nil:
+		n = 0
+	case up:
+		n += step
+	default:
+		n -= step
+	}
+	if field.bounded() {
+		n = min(max(n, field.Min), field.Max)
+	}
+	f.inputs[idx].SetValue(formatNumber(n))
+	f.inputs[idx].CursorEnd()
+}
+
+// stepDate moves a date field by one day; an empty or invalid one starts at
+// today.
+func (f *Form) stepDate(idx int, up bool) {
+	t, err := time.Parse(FormDateLayout, strings.TrimSpace(f.inputs[idx].Value()))
+	switch {
+	case err != nil:
+		t = time.Now()
+	case up:
+		t = t.AddDate(0, 0, 1)
+	default:
+		t = t.AddDate(0, 0, -1)
+	}
+	f.inputs[idx].SetValue(t.Format(FormDateLayout))
+	f.inputs[idx].CursorEnd()
+}
+
+// revalidate re-runs the validation of a field edited without a textinput
+// once it shows an error, so fixing the value clears it right away.
+func (f *Form) revalidate(idx int) {
+	if f.errors[idx] != nil {
+		f.validateField(idx)
+	}
+}
+
+// fieldView renders the input of field idx, width columns wide. Textareas
+// span several lines.
+func (f *Form) fieldView(idx int, focused bool, width int, styles style.StyleSet) string {
+	field, state := f.fields[idx], &f.states[idx]
+	marker, text := styles.Muted, styles.Muted
+	if focused {
+		marker, text = styles.Prompt, styles.Normal
+	}
+
+	switch field.Type {
+	case FieldCheckbox:
+		box := formCheckboxOff
+		if state.checked {
+			box = formCheckboxOn
+		}
+		out := marker.Render(box)
+		if field.Placeholder != "" {
+			out += styles.AppBackground.Render(" ") + text.Render(field.Placeholder)
+		}
+		return out
+	case FieldSelect:
+		label := field.Placeholder
+		st := styles.Muted
+		if state.choice >= 0 && state.choice < len(field.Options) {
+			label, st = field.Options[state.choice], text
+		}
+		return st.Render(ansi.Truncate(label, max(width-2, 1), "…")) + styles.AppBackground.Render(" ") + marker.Render(formSelectIndicator)
+	case FieldRadio:
+		parts := make([]string, len(field.Options))
+		for i, option := range field.Options {
+			mark := contextMenuRadioOff
+			if i == state.choice {
+				mark = contextMenuRadioOn
+			}
+			parts[i] = marker.Render(mark) + styles.AppBackground.Render(" ") + text.Render(option)
+		}
+		return ansi.Truncate(strings.Join(parts, styles.AppBackground.Render("  ")), width, "…")
+	case FieldTextarea:
+		rows := field.Rows
+		if rows <= 0 {
+			rows = formTextareaRows
+		}
+		return state.area.view(width, rows, focused, field.Placeholder, text, styles)
+	}
+
+	f.inputs[idx].SetWidth(width)
+	return f.inputs[idx].View()
+}
+
+// selectDropdownView renders the open dropdown of a select field.
+func (f *Form) selectDropdownView(idx int, styles style.StyleSet) string {
+	field, state := f.fields[idx], f.states[idx]
+	width := 0
+	for _, option := range field.Options {
+		width = max(width, lipgloss.Width(option))
+	}
+	lines := make([]string, len(field.Options))
+	for i, option := range field.Options {
+		st := styles.Popup.ContextMenuItem
+		if i == state.cursor {
+			st = styles.Popup.ContextMenuItemFocused
+		}
+		lines[i] = st.Width(width + 2).Render(" " + option)
+	}
+	return styles.Popup.ContextMenuFrame.Render(strings.Join(lines, "\n"))
+}
+
+// formTextarea is a minimal multi-line text editor.
+type formTextarea struct {
+	lines     [][]rune
+	row, col  int
+	scrollTop int
+}
+
+func (t *formTextarea) value() string {
+	lines := make([]string, len(t.lines))
+	for i, line := range t.lines {
+		lines[i] = string(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (t *formTextarea) setValue(value string) {
+	t.lines = nil
+	for _, line := range strings.Split(value, "\n") {
+		t.lines = append(t.lines, []rune(line))
+	}
+	t.row = len(t.lines) - 1
+	t.col = len(t.lines[t.row])
+	t.scrollTop = 0
+}
+
+func (t *formTextarea) ensureLine() {
+	if len(t.lines) == 0 {
+		t.lines = [][]rune{nil}
+		t.row, t.col = 0, 0
+	}
+}
+
+// insert inserts text at the cursor; "\n" splits the line.
+func (t *formTextarea) insert(text string) {
+	t.ensureLine()
+	for _, r := range text {
+		line := t.lines[t.row]
+		if r == '\n' {
+			rest := append([]rune(nil), line[t.col:]...)
+			t.lines[t.row] = line[:t.col]
+			t.lines = append(t.lines[:t.row+1], append([][]rune{rest}, t.lines[t.row+1:]...)...)
+			t.row, t.col = t.row+1, 0
+			continue
+		}
+		t.lines[t.row] = append(line[:t.col], append([]rune{r}, line[t.col:]...)...)
+		t.col++
+	}
+}
+
+// moveLine moves the cursor one line up (-1) or down (1). It returns false at
+// the first/last line.
+func (t *formTextarea) moveLine(dir int) bool {
+	t.ensureLine()
+	row := t.row + dir
+	if row < 0 || row >= len(t.lines) {
+		return false
+	}
+	t.row = row
+	t.col = min(t.col, len(t.lines[row]))
+	return true
+}
+
+// edit applies an editing key. It returns false for keys it doesn't handle.
+func (t *formTextarea) edit(msg tea.KeyMsg) bool {
+	t.ensureLine()
+	switch msg.String() {
+	case "left":
+		if t.col > 0 {
+			t.col--
+		} else if t.row > 0 {
+			t.row--
+			t.col = len(t.lines[t.row])
+		}
+	case "right":
+		if t.col < len(t.lines[t.row]) {
+			t.col++
+		} else if t.row < len(t.lines)-1 {
+			t.row, t.col = t.row+1, 0
+		}
+	case "home", "ctrl+a":
+		t.col = 0
+	case "end", "ctrl+e":
+		t.col = len(t.lines[t.row])
+	case "backspace":
+		switch {
+		case t.col > 0:
+			line := t.lines[t.row]
+			t.lines[t.row] = append(line[:t.col-1], line[t.col:]...)
+			t.col--
+		case t.row > 0:
+			prev := t.lines[t.row-1]
+			t.col = len(prev)
+			t.lines[t.row-1] = append(prev, t.lines[t.row]...)
+			t.lines = append(t.lines[:t.row], t.lines[t.row+1:]...)
+			t.row--
+		}
+	case "delete":
+		line := t.lines[t.row]
+		switch {
+		case t.col < len(line):
+			t.lines[t.row] = append(line[:t.col], line[t.col+1:]...)
+		case t.row < len(t.lines)-1:
+			t.lines[t.row] = append(line, t.lines[t.row+1]...)
+			t.lines = append(t.lines[:t.row+1], t.lines[t.row+2:]...)
+		}
+	default:
+		text := msg.Key().Text
+		if text == "" {
+			return false
+		}
+		t.insert(text)
+	}
+	return true
+}
+
+// formTextareaRow is a visual row of a textarea: runes [start, end) of line.
+type formTextareaRow struct {
+	line, start, end int
+}
+
+// wrap splits the lines into visual rows at most width cells wide. The
+// cursor line gets an extra row when the cursor sits past a full last row.
+func (t *formTextarea) wrap(width int, focused bool) (rows []formTextareaRow, cursor int) {
+	for i, runes := range t.lines {
+		start, w := 0, 0
+		for j, r := range runes {
+			rw := ansi.StringWidth(string(r))
+			if w+rw > width && j > start {
+				rows = append(rows, formTextareaRow{i, start, j})
+				start, w = j, 0
+			}
+			w += rw
+		}
+		rows = append(rows, formTextareaRow{i, start, len(runes)})
+		if focused && i == t.row && t.col == len(runes) && w+1 > width && len(runes) > 0 {
+			rows = append(rows, formTextareaRow{i, len(runes), len(runes)})
+		}
+	}
+	for n, row := range rows {
+		if row.line == t.row && t.col >= row.start && (t.col < row.end || t.col == len(t.lines[row.line])) {
+			cursor = n
+			if t.col < row.end {
+				break
+			}
+		}
+	}
+	return rows, cursor
+}
+
+// view renders rows visual rows, wrapping long lines at width cells and
+// scrolled to keep the cursor visible, with the cursor cell reversed while
+// focused.
+func (t *formTextarea) view(width, rows int, focused bool, placeholder string, text lipgloss.Style, styles style.StyleSet) string {
+	t.ensureLine()
+	width = max(width, 1)
+	visual, cursorRow := t.wrap(width, focused)
+	if cursorRow < t.scrollTop {
+		t.scrollTop = cursorRow
+	} else if cursorRow >= t.scrollTop+rows {
+		t.scrollTop = cursorRow - rows + 1
+	}
+	t.scrollTop = min(t.scrollTop, max(len(visual)-rows, 0))
+	empty := len(t.lines) == 1 && len(t.lines[0]) == 0
+	cursor := lipgloss.NewStyle().Reverse(true)
+
+	out := make([]string, rows)
+	for i := range rows {
+		n := t.scrollTop + i
+		var line string
+		switch {
+		case empty && i == 0 && !focused:
+			line = styles.Muted.Render(ansi.Truncate(placeholder, width, "…"))
+		case n < len(visual):
+			row := visual[n]
+			runes := t.lines[row.line][row.start:row.end]
+			if focused && n == cursorRow {
+				col := t.col - row.start
+				at, after := " ", ""
+				if col < len(runes) {
+					at, after = string(runes[col]), string(runes[col+1:])
+				}
+				line = text.Render(string(runes[:col])) + cursor.Render(at) + text.Render(after)
+			} else {
+				line = text.Render(string(runes))
+			}
+		}
+		out[i] = line + styles.AppBackground.Render(strings.Repeat(" ", max(width-lipgloss.Width(line), 0)))
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,376 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Struct-backed forms. NewFormFor derives the fields of a Form from the
+// exported fields of a struct and writes the submitted values back into it.
+//
+// Each field may carry a `form` tag of comma-separated options (a literal
+// comma is written `\,`):
+//
+//	label=Server URL   label (default: the Go field name)
+//	placeholder=…      placeholder
+//	required           must not be empty (bools: must be checked)
+//	type=password      widget: text, password, textarea, number, date, select, radio
+//	min=1,max=10       number range, or string length in characters
+//	step=5             number step of up/down
+//	rows=5             textarea height
+//	regex=^[a-z]+$     strings must match
+//	oneof=a|b|c        allowed values, picked with a select (or radio)
+//	-                  skip the field
+//
+// bool fields become checkboxes, integer and float fields numbers, time.Time
+// fields dates and strings text inputs. Nested structs become sections whose
+// heading is the struct field's label.
+
+// formBinding ties the fields of a Form to the struct they were built from.
+type formBinding struct {
+	target reflect.Value // the struct
+	index  [][]int       // per form field, the struct field index path
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// NewFormFor builds a Form from the struct v points to, initialised with its
+// current values. On submit the values are decoded back into *v; values that
+// do not convert to the field's Go type are shown as errors on their fields
+// and block the submission. Field keys are the dotted Go field paths, e.g.
+// "Server.URL".
+func NewFormFor(v any) (*Form, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form: NewFormFor needs a non-nil pointer to a struct, got %T", v)
+	}
+	binding := &formBinding{target: rv.Elem()}
+	fields, err := binding.collect(rv.Elem(), nil, "", "")
+	if err != nil {
+		return nil, err
+	}
+	f := NewForm(fields)
+	f.binding = binding
+	return f, nil
+}
+
+// collect appends the form fields of struct value sv. index, key and section
+// are those of the struct within the target.
+func (b *formBinding) collect(sv reflect.Value, index []int, key, section string) ([]FormField, error) {
+	var fields []FormField
+	st := sv.Type()
+	for i := range st.NumField() {
+		sf := st.Field(i)
+		tag, tagged := sf.Tag.Lookup("form")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		opts := parseFormTag(tag)
+		fieldIndex := append(append([]int(nil), index...), i)
+		fieldKey := sf.Name
+		if key != "" {
+			fieldKey = key + "." + sf.Name
+		}
+		label := sf.Name
+		if l, ok := opts["label"]; ok {
+			label = l
+		}
+
+		if sf.Type.Kind() == reflect.Struct && sf.Type != timeType {
+			if sf.Anonymous && !tagged {
+				// Embedded structs are flattened into the current section.
+				nested, err := b.collect(sv.Field(i), fieldIndex, key, section)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, nested...)
+				continue
+			}
+			heading := label
+			if section != "" {
+				heading = section + " / " + label
+			}
+			nested, err := b.collect(sv.Field(i), fieldIndex, fieldKey, heading)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, nested...)
+			continue
+		}
+
+		field, err := structFormField(sf, sv.Field(i), opts)
+		if err != nil {
+			return nil, fmt.Errorf("form: field %s: %w", fieldKey, err)
+		}
+		field.Key, field.Label, field.Section = fieldKey, label, section
+		fields = append(fields, field)
+		b.index = append(b.index, fieldIndex)
+	}
+	return fields, nil
+}
+
+// parseFormTag splits a form tag into its options; flags map to "".
+func parseFormTag(tag string) map[string]string {
+	opts := make(map[string]string)
+	var part strings.Builder
+	flush := func() {
+		if s := strings.TrimSpace(part.String()); s != "" {
+			name, value, _ := strings.Cut(s, "=")
+			opts[strings.TrimSpace(name)] = value
+		}
+		part.Reset()
+	}
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			part.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			flush()
+		default:
+			part.WriteByte(tag[i])
+		}
+	}
+	flush()
+	return opts
+}
+
+// structFormField builds the form field of struct field sf holding fv.
+func structFormField(sf reflect.StructField, fv reflect.Value, opts map[string]string) (FormField, error) {
+	field := FormField{Placeholder: opts["placeholder"]}
+	_, field.Required = opts["required"]
+
+	switch {
+	case sf.Type == timeType:
+		field.Type = FieldDate
+		if t := fv.Interface().(time.Time); !t.IsZero() {
+			field.Default = t.Format(FormDateLayout)
+		}
+	case sf.Type.Kind() == reflect.Bool:
+		field.Type = FieldCheckbox
+		field.Default = strconv.FormatBool(fv.Bool())
+	case isIntKind(sf.Type.Kind()):
+		field.Type = FieldNumber
+		field.Default = strconv.FormatInt(fv.Int(), 10)
+	case isUintKind(sf.Type.Kind()):
+		field.Type = FieldNumber
+		field.Default = strconv.FormatUint(fv.Uint(), 10)
+	case sf.Type.Kind() == reflect.Float32 || sf.Type.Kind() == reflect.Float64:
+		field.Type = FieldNumber
+		field.Default = strconv.FormatFloat(fv.Float(), 'f', -1, sf.Type.Bits())
+	case sf.Type.Kind() == reflect.String:
+		field.Default = fv.String()
+	default:
+		return field, fmt.Errorf("unsupported type %s", sf.Type)
+	}
+
+	if oneof, ok := opts["oneof"]; ok {
+		field.Options = strings.Split(oneof, "|")
+		field.Type = FieldSelect
+	}
+	if name, ok := opts["type"]; ok {
+		t, ok := formFieldTypes[name]
+		if !ok {
+			return field, fmt.Errorf("unknown type %q", name)
+		}
+		field.Type = t
+	}
+
+	var validators []func(string) error
+	var err error
+	numeric := field.Type == FieldNumber
+	lo, hasMin := opts["min"]
+	hi, hasMax := opts["max"]
+	if hasMin {
+		if field.Min, err = strconv.ParseFloat(lo, 64); err != nil {
+			return field, fmt.Errorf("bad min %q", lo)
+		}
+		validators = append(validators, minValidator(field.Min, numeric))
+	}
+	if hasMax {
+		if field.Max, err = strconv.ParseFloat(hi, 64); err != nil {
+			return field, fmt.Errorf("bad max %q", hi)
+		}
+		validators = append(validators, maxValidator(field.Max, numeric))
+	}
+	if !(hasMin && hasMax) {
+		// Min/Max only clamp number fields when both are given.
+		field.Min, field.Max = 0, 0
+	}
+	if step, ok := opts["step"]; ok {
+		if field.Step, err = strconv.ParseFloat(step, 64); err != nil {
+			return field, fmt.Errorf("bad step %q", step)
+		}
+	}
+	if rows, ok := opts["rows"]; ok {
+		if field.Rows, err = strconv.Atoi(rows); err != nil {
+			return field, fmt.Errorf("bad rows %q", rows)
+		}
+	}
+	if pattern, ok := opts["regex"]; ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return field, fmt.Errorf("bad regex: %w", err)
+		}
+		validators = append(validators, func(value string) error {
+			if !re.MatchString(value) {
+				return formFieldError(Tf(MsgFieldPatternMismatch, pattern))
+			}
+			return nil
+		})
+	}
+	if field.Options != nil && field.Type.usesInput() {
+		// Typed in rather than picked, so check it.
+		options := field.Options
+		validators = append(validators, func(value string) error {
+			for _, option := range options {
+				if value == option {
+					return nil
+				}
+			}
+			return formFieldError(Tf(MsgFieldNotOneOf, strings.Join(options, ", ")))
+		})
+	}
+
+	if len(validators) > 0 {
+		field.Validate = func(value string) error {
+			for _, validate := range validators {
+				if err := validate(value); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return field, nil
+}
+
+var formFieldTypes = map[string]FormFieldType{
+	"text":     FieldText,
+	"checkbox": FieldCheckbox,
+	"select":   FieldSelect,
+	"radio":    FieldRadio,
+	"textarea": FieldTextarea,
+	"number":   FieldNumber,
+	"password": FieldPassword,
+	"date":     FieldDate,
+}
+
+// minValidator checks a number's value or a string's length against min.
+func minValidator(min float64, numeric bool) func(string) error {
+	return func(value string) error {
+		if numeric {
+			if n, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && n < min {
+				return formFieldError(Tf(MsgFieldTooSmall, formatNumber(min)))
+			}
+		} else if float64(utf8.RuneCountInString(value)) < min {
+			return formFieldError(Tf(MsgFieldTooShort, formatNumber(min)))
+		}
+		return nil
+	}
+}
+
+// maxValidator is minValidator for an upper bound.
+func maxValidator(max float64, numeric bool) func(string) error {
+	return func(value string) error {
+		if numeric {
+			if n, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && n > max {
+				return formFieldError(Tf(MsgFieldTooLarge, formatNumber(max)))
+			}
+		} else if float64(utf8.RuneCountInString(value)) > max {
+			return formFieldError(Tf(MsgFieldTooLong, formatNumber(max)))
+		}
+		return nil
+	}
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+// decode converts every field's value to its struct field's type and, when
+// all convert, stores them. Conversion errors are set on their fields.
+func (b *formBinding) decode(f *Form) bool {
+	values := make([]reflect.Value, len(b.index))
+	ok := true
+	for i, index := range b.index {
+		v, err := convertFormValue(f.fieldValue(i), b.target.FieldByIndex(index).Type())
+		if err != nil {
+			f.errors[i] = err
+			ok = false
+			continue
+		}
+		values[i] = v
+	}
+	if !ok {
+		return false
+	}
+	for i, index := range b.index {
+		b.target.FieldByIndex(index).Set(values[i])
+	}
+	return true
+}
+
+// convertFormValue converts a field value in text form to type t. Strings
+// are kept verbatim; empty values of other types convert to the zero value.
+func convertFormValue(value string, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if t.Kind() != reflect.String {
+		value = strings.TrimSpace(value)
+	}
+	if value == "" {
+		return v, nil
+	}
+	switch {
+	case t == timeType:
+		tm, err := time.Parse(FormDateLayout, value)
+		if err != nil {
+			return v, formFieldError(Tf(MsgFieldInvalidDate, FormDateLayout))
+		}
+		v.Set(reflect.ValueOf(tm))
+	case t.Kind() == reflect.String:
+		v.SetString(value)
+	case t.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return v, formFieldError(T(MsgFieldInvalidValue))
+		}
+		v.SetBool(b)
+	case isIntKind(t.Kind()):
+		n, err := strconv.ParseInt(value, 10, t.Bits())
+		if errors.Is(err, strconv.ErrRange) {
+			lo, hi := int64(-1)<<(t.Bits()-1), int64(1)<<(t.Bits()-1)-1
+			return v, formFieldError(Tf(MsgFieldOutOfRange, strconv.FormatInt(lo, 10), strconv.FormatInt(hi, 10)))
+		} else if err != nil {
+			return v, formFieldError(T(MsgFieldInvalidInteger))
+		}
+		v.SetInt(n)
+	case isUintKind(t.Kind()):
+		n, err := strconv.ParseUint(value, 10, t.Bits())
+		if errors.Is(err, strconv.ErrRange) || (err != nil && strings.HasPrefix(value, "-")) {
+			hi := uint64(math.MaxUint64) >> (64 - t.Bits())
+			return v, formFieldError(Tf(MsgFieldOutOfRange, "0", strconv.FormatUint(hi, 10)))
+		} else if err != nil {
+			return v, formFieldError(T(MsgFieldInvalidInteger))
+		}
+		v.SetUint(n)
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		n, err := strconv.ParseFloat(value, t.Bits())
+		if err != nil {
+			return v, formFieldError(T(MsgFieldInvalidNumber))
+		}
+		v.SetFloat(n)
+	}
+	return v, nil
+}
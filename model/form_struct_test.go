@@ -0,0 +1,116 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+type formTestSettings struct {
+	Name   string `form:"label=Name,required,min=3,placeholder=your name"`
+	Port   int    `form:"min=1,max=65535"`
+	Mode   string `form:"oneof=fast|safe"`
+	Debug  bool
+	Server struct {
+		URL     string `form:"label=Server URL,regex=^https?://[a-z]{1\\,20}"`
+		Timeout uint8
+	}
+	Since  time.Time
+	Secret string `form:"-"`
+	hidden string
+}
+
+func TestNewFormForBuildsAndDecodes(t *testing.T) {
+	settings := formTestSettings{Name: "box", Port: 8080, Mode: "safe"}
+	settings.Server.Timeout = 30
+	form, err := NewFormFor(&settings)
+	if err != nil {
+		t.Fatalf("NewFormFor: %v", err)
+	}
+	form.SetSize(80, 30)
+
+	var keys []string
+	for _, field := range form.fields {
+		keys = append(keys, field.Key)
+	}
+	if got := strings.Join(keys, " "); got != "Name Port Mode Debug Server.URL Server.Timeout Since" {
+		t.Fatalf("keys = %q", got)
+	}
+	types := []FormFieldType{FieldText, FieldNumber, FieldSelect, FieldCheckbox, FieldText, FieldNumber, FieldDate}
+	for i, want := range types {
+		if form.fields[i].Type != want {
+			t.Errorf("%s type = %v, want %v", keys[i], form.fields[i].Type, want)
+		}
+	}
+	if form.fields[4].Section != "Server" || form.fields[4].Label != "Server URL" {
+		t.Errorf("Server.URL section/label = %q/%q", form.fields[4].Section, form.fields[4].Label)
+	}
+	if got := form.Values(); got["Port"] != "8080" || got["Mode"] != "safe" || got["Server.Timeout"] != "30" {
+		t.Errorf("Values() should start from the struct, got %v", got)
+	}
+	view := ansi.Strip(form.View())
+	if !strings.Contains(view, "Server\n") {
+		t.Errorf("View() should draw the Server section heading:\n%s", view)
+	}
+
+	// Tag validators.
+	form.inputs[0].SetValue("ab")
+	form.inputs[1].SetValue("70000")
+	form.inputs[4].SetValue("ftp://x")
+	form.trySubmit()
+	for _, i := range []int{0, 1, 4} {
+		if form.errors[i] == nil {
+			t.Errorf("%s should be invalid", keys[i])
+		}
+	}
+
+	// Values the tags accept but the Go types do not.
+	form.inputs[0].SetValue("router")
+	form.inputs[1].SetValue("1.5")
+	form.inputs[4].SetValue("https://example")
+	form.inputs[5].SetValue("300")
+	form.trySubmit()
+	if form.Submitted() || form.errors[1] == nil || form.errors[5] == nil || form.errors[0] != nil {
+		t.Fatalf("conversion errors should block submit, errors = %v", form.errors)
+	}
+	if settings.Name != "box" {
+		t.Error("a failed submit should leave the struct untouched")
+	}
+
+	form.inputs[1].SetValue("443")
+	form.inputs[5].SetValue("5")
+	form.inputs[6].SetValue("2026-01-02")
+	form.states[3].checked = true
+	form.trySubmit()
+	if !form.Submitted() {
+		t.Fatalf("submit failed, errors = %v", form.errors)
+	}
+	if settings.Name != "router" || settings.Port != 443 || !settings.Debug ||
+		settings.Server.URL != "https://example" || settings.Server.Timeout != 5 || settings.Since.Day() != 2 {
+		t.Errorf("decoded settings = %+v", settings)
+	}
+
+	if _, err := NewFormFor(settings); err == nil {
+		t.Error("NewFormFor should reject a non-pointer")
+	}
+}
+
+func TestNewFormForFloat32RoundTrip(t *testing.T) {
+	settings := struct {
+		Volume float32 `form:"min=0,max=1"`
+		Gain   float64
+	}{Volume: 0.8, Gain: 0.1}
+	form, err := NewFormFor(&settings)
+	if err != nil {
+		t.Fatalf("NewFormFor: %v", err)
+	}
+	if got := form.Values(); got["Volume"] != "0.8" || got["Gain"] != "0.1" {
+		t.Fatalf("Values() = %v, want the shortest representation for each size", got)
+	}
+	form.trySubmit()
+	if !form.Submitted() || settings.Volume != 0.8 || settings.Gain != 0.1 {
+		t.Fatalf("round trip = %+v, submitted %v, errors %v", settings, form.Submitted(), form.errors)
+	}
+}
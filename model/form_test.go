@@ -4,8 +4,13 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
+	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestNewForm(t *testing.T) {
@@ -446,3 +451,142 @@ func TestFormUpdateUnfocused(t *testing.T) {
 		t.Error("Update should return nil when form is not focused")
 	}
 }
+
+func TestFormRichFieldTypes(t *testing.T) {
+	form := NewForm([]FormField{
+		{Key: "agree", Label: "Agree", Type: FieldCheckbox, Required: true},
+		{Key: "color", Label: "Color", Type: FieldSelect, Options: []string{"red", "green", "blue"}},
+		{Key: "size", Label: "Size", Type: FieldRadio, Options: []string{"S", "M", "L"}, Default: "M"},
+		{Key: "notes", Label: "Notes", Type: FieldTextarea},
+		{Key: "count", Label: "Count", Type: FieldNumber, Min: 0, Max: 10, Step: 5, Default: "5"},
+		{Key: "secret", Label: "Secret", Type: FieldPassword},
+		{Key: "day", Label: "Day", Type: FieldDate},
+	})
+	form.SetSize(80, 30)
+	form.Focus()
+	space := tea.KeyPressMsg(tea.Key{Code: tea.KeySpace, Text: " "})
+	tab := tea.KeyPressMsg(tea.Key{Code: tea.KeyTab})
+
+	// Checkbox: required means checked.
+	form.validateField(0)
+	if form.errors[0] == nil {
+		t.Error("unchecked required checkbox should be invalid")
+	}
+	form.Update(space)
+	if form.errors[0] != nil || form.Values()["agree"] != "true" {
+		t.Errorf("space should check the box, values = %v, err = %v", form.Values(), form.errors[0])
+	}
+
+	// Select: enter opens the dropdown, esc is captured, down+enter picks.
+	form.Update(tab)
+	form.Update(keyMsg("enter"))
+	if !form.states[1].open || !strings.Contains(ansi.Strip(form.View()), "green") {
+		t.Fatal("enter should open the select dropdown")
+	}
+	if !form.capturesKey("esc") {
+		t.Error("an open dropdown should capture esc")
+	}
+	form.Update(keyMsg("down"))
+	form.Update(keyMsg("down"))
+	form.Update(keyMsg("enter"))
+	if form.states[1].open || form.Values()["color"] != "blue" {
+		t.Errorf("color = %q, want blue with dropdown closed", form.Values()["color"])
+	}
+
+	// Radio: default applied, right moves on.
+	form.Update(tab)
+	form.Update(keyMsg("right"))
+	if got := form.Values()["size"]; got != "L" {
+		t.Errorf("size = %q, want L", got)
+	}
+
+	// Textarea: enter inserts a newline.
+	form.Update(tab)
+	form.Update(keyMsg("a"))
+	form.Update(keyMsg("enter"))
+	form.Update(keyMsg("b"))
+	if got := form.Values()["notes"]; got != "a\nb" {
+		t.Errorf("notes = %q, want a\\nb", got)
+	}
+
+	// Number: steps clamp to the range; typed values outside it are errors.
+	form.Update(tab) // down moves within the textarea; tab leaves it
+	if form.focusedIdx != 4 {
+		t.Fatalf("focusedIdx = %d, want 4", form.focusedIdx)
+	}
+	form.Update(keyMsg("up"))
+	form.Update(keyMsg("up"))
+	if got := form.Values()["count"]; got != "10" {
+		t.Errorf("count = %q, want 10 (clamped)", got)
+	}
+	form.inputs[4].SetValue("42")
+	form.validateField(4)
+	if form.errors[4] == nil {
+		t.Error("number out of range should be invalid")
+	}
+	form.inputs[4].SetValue("7")
+	form.validateField(4)
+
+	// Password: masked until revealed.
+	form.Update(tab)
+	form.Update(keyMsg("p"))
+	if strings.Contains(ansi.Strip(form.View()), "Secret: p") {
+		t.Error("password should be masked")
+	}
+	form.Update(tea.KeyPressMsg(tea.Key{Code: 'r', Mod: tea.ModCtrl}))
+	if form.inputs[5].EchoMode != textinput.EchoNormal {
+		t.Error("ctrl+r should reveal the password")
+	}
+
+	// Date: must parse.
+	form.Update(tab)
+	form.inputs[6].SetValue("tomorrow")
+	form.validateField(6)
+	if form.errors[6] == nil || !strings.Contains(form.View(), "Must be a date") {
+		t.Error("invalid date should show an error")
+	}
+	form.inputs[6].SetValue("2026-03-01")
+	form.validateField(6)
+
+	typed := form.TypedValues()
+	if typed["agree"] != true || typed["count"] != 7.0 || typed["color"] != "blue" {
+		t.Errorf("TypedValues() = %v", typed)
+	}
+	if day, ok := typed["day"].(time.Time); !ok || day.Month() != time.March {
+		t.Errorf("day = %v, want a March time.Time", typed["day"])
+	}
+
+	form.Reset()
+	if got := form.Values(); got["size"] != "M" || got["agree"] != "false" || got["count"] != "5" {
+		t.Errorf("Reset() should restore defaults, got %v", got)
+	}
+}
+
+func TestFormTextareaWrapsByCellWidth(t *testing.T) {
+	var area formTextarea
+	area.setValue("漢字かなカナ\nabcdefgh")
+	ss := style.CurrentStyleSet()
+	text := lipgloss.NewStyle()
+
+	// Unfocused: both lines wrap at 5 cells; wide runes never split a row.
+	lines := strings.Split(ansi.Strip(area.view(5, 5, false, "", text, ss)), "\n")
+	want := []string{"漢字 ", "かな ", "カナ ", "abcde", "fgh  "}
+	for i, line := range lines {
+		if line != want[i] || ansi.StringWidth(lines[i]) != 5 {
+			t.Fatalf("row %d = %q, want %q (all rows %q)", i, line, want[i], lines)
+		}
+	}
+
+	// Focused at the end of the first line: the view scrolls by rows and
+	// every row stays within the width.
+	area.row, area.col = 0, len(area.lines[0])
+	lines = strings.Split(ansi.Strip(area.view(5, 2, true, "", text, ss)), "\n")
+	if lines[0] != "かな " || lines[1] != "カナ " {
+		t.Fatalf("cursor rows = %q", lines)
+	}
+	for _, line := range lines {
+		if ansi.StringWidth(line) != 5 {
+			t.Fatalf("row %q is %d cells wide, want 5", line, ansi.StringWidth(line))
+		}
+	}
+}
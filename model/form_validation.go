@@ -0,0 +1,164 @@
+package model
+
+import (
+	"context"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Form validation beyond FormField.Validate: async validators run as tea.Cmds
+// and report back with a formValidationMsg, form-level validators see every
+// value, and FormField.Visible hides fields depending on the others.
+//
+// A field is validated when it loses focus and on submit. Its async
+// validator starts once the synchronous checks pass and is cancelled when
+// the value is edited; a submission waits until none is pending.
+
+// FormValidator checks the form as a whole, e.g. that a password confirmation
+// matches or that a date range is ordered. values holds the visible fields
+// (see Form.Values); the returned errors are shown on the fields with those
+// keys once they have been validated on their own.
+type FormValidator func(values map[string]string) map[string]error
+
+// formAsyncState is the state of a field's async validator.
+type formAsyncState struct {
+	seq     int // identifies the latest run
+	cancel  context.CancelFunc
+	pending bool
+	checked bool   // err is the result for value
+	value   string // value of the latest run
+	err     error
+}
+
+// formValidationMsg carries the result of an async validator.
+type formValidationMsg struct {
+	form     *Form
+	idx, seq int
+	err      error
+}
+
+// AddValidator adds a form-level validator.
+func (f *Form) AddValidator(validator FormValidator) {
+	f.validators = append(f.validators, validator)
+}
+
+// Pending reports whether an async validator is running.
+func (f *Form) Pending() bool {
+	for i := range f.states {
+		if f.states[i].async.pending {
+			return true
+		}
+	}
+	return false
+}
+
+// startAsync runs the async validator of field idx if its value passed the
+// synchronous checks and has not been checked yet.
+func (f *Form) startAsync(idx int) tea.Cmd {
+	field, st := f.fields[idx], &f.states[idx].async
+	value := f.fieldValue(idx)
+	if field.ValidateAsync == nil || f.errors[idx] != nil || f.fieldEmpty(idx) || f.hidden(idx) {
+		f.cancelAsync(idx)
+		return nil
+	}
+	if st.value == value && (st.pending || st.checked) {
+		return nil
+	}
+	f.cancelAsync(idx)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	st.cancel, st.pending, st.value = cancel, true, value
+	form, seq := f, st.seq
+	return func() tea.Msg {
+		return formValidationMsg{form: form, idx: idx, seq: seq, err: field.ValidateAsync(ctx, value)}
+	}
+}
+
+// cancelAsync cancels the async validator of field idx and forgets its
+// result. Late results are dropped by their seq.
+func (f *Form) cancelAsync(idx int) {
+	st := &f.states[idx].async
+	if st.cancel != nil {
+		st.cancel()
+	}
+	*st = formAsyncState{seq: st.seq + 1}
+}
+
+// applyValidation records the result of an async validator and completes a
+// submission that was waiting for it.
+func (f *Form) applyValidation(msg formValidationMsg) {
+	if msg.idx < 0 || msg.idx >= len(f.states) {
+		return
+	}
+	st := &f.states[msg.idx].async
+	if !st.pending || msg.seq != st.seq {
+		return
+	}
+	st.cancel()
+	st.cancel, st.pending, st.checked, st.err = nil, false, true, msg.err
+	if !f.submitRequested || f.Pending() {
+		return
+	}
+	f.submitRequested = false
+	f.submit()
+	if f.submitted && f.onAsyncSubmit != nil {
+		f.onAsyncSubmit()
+	}
+}
+
+// fieldEdited drops the validation results of an edited field.
+func (f *Form) fieldEdited(idx int) {
+	f.submitRequested = false
+	f.cancelAsync(idx)
+	f.crossErrors[idx] = nil
+}
+
+// runValidators runs the form-level validators, attaching their errors to
+// visible fields that have been validated on their own.
+func (f *Form) runValidators() {
+	clear(f.crossErrors)
+	if len(f.validators) == 0 {
+		return
+	}
+	keys := make(map[string]int, len(f.fields))
+	for i, field := range f.fields {
+		keys[field.Key] = i
+	}
+	values := f.Values()
+	for _, validator := range f.validators {
+		for key, err := range validator(values) {
+			idx, ok := keys[key]
+			if !ok || err == nil || f.hidden(idx) || !f.states[idx].touched || f.crossErrors[idx] != nil {
+				continue
+			}
+			f.crossErrors[idx] = err
+		}
+	}
+}
+
+// fieldError returns the error shown on field idx: its own validation error,
+// then its async validator's, then a form-level one.
+func (f *Form) fieldError(idx int) error {
+	if f.errors[idx] != nil {
+		return f.errors[idx]
+	}
+	if st := f.states[idx].async; st.checked && st.err != nil {
+		return st.err
+	}
+	return f.crossErrors[idx]
+}
+
+// allValues returns the values of every field, hidden ones included.
+func (f *Form) allValues() map[string]string {
+	values := make(map[string]string, len(f.fields))
+	for i, field := range f.fields {
+		values[field.Key] = f.fieldValue(i)
+	}
+	return values
+}
+
+// hidden reports whether FormField.Visible hides field idx.
+func (f *Form) hidden(idx int) bool {
+	visible := f.fields[idx].Visible
+	return visible != nil && !visible(f.allValues())
+}
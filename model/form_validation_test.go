@@ -0,0 +1,177 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// formValidationMsgs runs cmd and the commands it batches, returning the
+// async validation results.
+func formValidationMsgs(cmd tea.Cmd) []formValidationMsg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		var msgs []formValidationMsg
+		for _, c := range msg {
+			msgs = append(msgs, formValidationMsgs(c)...)
+		}
+		return msgs
+	case formValidationMsg:
+		return []formValidationMsg{msg}
+	}
+	return nil
+}
+
+func TestFormAsyncCrossFieldAndConditionalValidation(t *testing.T) {
+	var contexts []context.Context
+	form := NewForm([]FormField{
+		{Key: "user", Label: "User", ValidateAsync: func(ctx context.Context, value string) error {
+			contexts = append(contexts, ctx)
+			if value == "taken" {
+				return errors.New("username taken")
+			}
+			return nil
+		}},
+		{Key: "kind", Label: "Kind", Type: FieldRadio, Options: []string{"personal", "company"}, Default: "personal"},
+		{Key: "company", Label: "Company", Required: true, Visible: func(values map[string]string) bool {
+			return values["kind"] == "company"
+		}},
+		{Key: "pass", Label: "Password", Type: FieldPassword},
+		{Key: "confirm", Label: "Confirm", Type: FieldPassword},
+	})
+	form.AddValidator(func(values map[string]string) map[string]error {
+		if values["pass"] != values["confirm"] {
+			return map[string]error{"confirm": errors.New("passwords differ")}
+		}
+		return nil
+	})
+	form.SetSize(80, 30)
+	form.Focus()
+	tab := tea.KeyPressMsg(tea.Key{Code: tea.KeyTab})
+	shiftTab := tea.KeyPressMsg(tea.Key{Code: tea.KeyTab, Mod: tea.ModShift})
+
+	// Leaving the field starts its async validator; the field shows it.
+	form.inputs[0].SetValue("taken")
+	results := formValidationMsgs(form.Update(tab))
+	if len(results) != 1 || !form.Pending() || !strings.Contains(ansi.Strip(form.View()), T(MsgFieldValidating)) {
+		t.Fatalf("leaving the field should start one pending check, got %d", len(results))
+	}
+	form.Update(results[0])
+	if form.Pending() || form.fieldError(0) == nil || !strings.Contains(form.View(), "username taken") {
+		t.Fatalf("async error should be shown, err = %v", form.fieldError(0))
+	}
+
+	// Editing cancels a running check and drops its late result.
+	form.Update(shiftTab)
+	form.Update(keyMsg("s"))
+	stale := formValidationMsgs(form.Update(tab))
+	form.Update(shiftTab)
+	form.Update(keyMsg("s"))
+	if contexts[len(contexts)-1].Err() == nil {
+		t.Error("an edit should cancel the running check")
+	}
+	form.Update(stale[0])
+	if form.Pending() || form.fieldError(0) != nil {
+		t.Error("a cancelled check's result should be dropped")
+	}
+
+	// Hidden fields are skipped and left out of Values until shown.
+	results = formValidationMsgs(form.Update(tab))
+	form.Update(tab)
+	if form.focusedIdx != 3 {
+		t.Fatalf("focusedIdx = %d, want 3 (company hidden)", form.focusedIdx)
+	}
+	if _, ok := form.Values()["company"]; ok || strings.Contains(form.View(), "Company") {
+		t.Error("hidden field should be left out of Values and View")
+	}
+	form.setFieldValue(1, "company")
+	if _, ok := form.Values()["company"]; !ok {
+		t.Error("company should show once kind is company")
+	}
+
+	// Form-level errors land on the named field; pending checks block submit.
+	form.inputs[3].SetValue("secret")
+	form.inputs[4].SetValue("secreT")
+	form.inputs[2].SetValue("ACME")
+	form.trySubmit()
+	if form.Submitted() || !errors.Is(form.fieldError(4), form.crossErrors[4]) || form.crossErrors[4] == nil {
+		t.Fatalf("mismatch should block submit, confirm err = %v", form.fieldError(4))
+	}
+	form.inputs[4].SetValue("secret")
+	form.trySubmit()
+	if form.Submitted() || !form.Pending() {
+		t.Fatal("submit should wait for the pending username check")
+	}
+	form.Update(results[0])
+	if !form.Submitted() {
+		t.Fatalf("the check's result should complete the submit, errors = %v / %v", form.errors, form.crossErrors)
+	}
+}
+
+func TestFormAsyncSubmitCompletesInHosts(t *testing.T) {
+	asyncForm := func() *Form {
+		return NewForm([]FormField{{Key: "user", Label: "User", ValidateAsync: func(context.Context, string) error {
+			return nil
+		}}})
+	}
+	enter := tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter})
+
+	// A wizard moves on once the check it waited for passes.
+	login := asyncForm()
+	w := NewWizard([]WizardStep{
+		{Title: "Login", Form: login},
+		{Title: "Quality", Form: NewForm([]FormField{{Key: "quality", Label: "Quality"}})},
+	})
+	w.Focus()
+	w.Update(keyMsg("a"))
+	results := formValidationMsgs(w.Update(enter))
+	if len(results) != 1 || w.Current() != 0 {
+		t.Fatalf("enter should wait for the check, got %d checks, step %d", len(results), w.Current())
+	}
+	w.Update(results[0])
+	if w.Current() != 1 || login.Submitted() {
+		t.Fatalf("Current() = %d, want 1 once the check passed", w.Current())
+	}
+
+	// An edit while waiting drops the submit.
+	form := asyncForm()
+	form.Focus()
+	form.Update(keyMsg("a"))
+	results = formValidationMsgs(form.Update(enter))
+	form.Update(keyMsg("b"))
+	form.Update(results[0])
+	if form.Submitted() {
+		t.Fatal("an edit should cancel the requested submit")
+	}
+
+	// A popup hosting the form closes with its default action.
+	app := NewApp(DefaultOptions())
+	form = asyncForm()
+	var result *PopupResult
+	popup, err := NewPopup(PopupSpec{
+		Title:    "Login",
+		Body:     form,
+		Actions:  []PopupAction{{ID: "login", Label: "Login"}},
+		OnResult: func(r PopupResult) { result = &r },
+	})
+	if err != nil {
+		t.Fatalf("NewPopup() error = %v", err)
+	}
+	app.ShowPopup(popup)
+	popup.update(keyMsg("a"))
+	results = formValidationMsgs(popup.update(enter))
+	if len(results) != 1 || popup.dismissed() {
+		t.Fatal("the popup should stay open while the check runs")
+	}
+	app.Update(results[0])
+	if app.HasPopup() || result == nil || result.ActionID != "login" {
+		t.Fatalf("the completed submit should close the popup, result = %+v", result)
+	}
+}
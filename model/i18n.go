@@ -10,21 +10,59 @@ import (
 type MessageID string
 
 const (
-	MsgLoading        MessageID = "loading"
-	MsgHintNavigate   MessageID = "hint.navigate"
-	MsgHintConfirm    MessageID = "hint.confirm"
-	MsgHintBack       MessageID = "hint.back"
-	MsgHintQuit       MessageID = "hint.quit"
-	MsgHintSearch     MessageID = "hint.search"
-	MsgNoData         MessageID = "no_data"
-	MsgNoColumns      MessageID = "no_columns"
-	MsgEmptyDirectory MessageID = "empty_directory"
-	MsgReadError      MessageID = "read_error"
-	MsgYes            MessageID = "yes"
-	MsgNo             MessageID = "no"
-	MsgConfirm        MessageID = "confirm"
-	MsgCancel         MessageID = "cancel"
-	MsgFieldRequired  MessageID = "field_required"
+	MsgLoading              MessageID = "loading"
+	MsgHintNavigate         MessageID = "hint.navigate"
+	MsgHintConfirm          MessageID = "hint.confirm"
+	MsgHintBack             MessageID = "hint.back"
+	MsgHintQuit             MessageID = "hint.quit"
+	MsgHintSearch           MessageID = "hint.search"
+	MsgNoData               MessageID = "no_data"
+	MsgNoColumns            MessageID = "no_columns"
+	MsgEmptyDirectory       MessageID = "empty_directory"
+	MsgReadError            MessageID = "read_error"
+	MsgYes                  MessageID = "yes"
+	MsgNo                   MessageID = "no"
+	MsgConfirm              MessageID = "confirm"
+	MsgCancel               MessageID = "cancel"
+	MsgFieldRequired        MessageID = "field_required"
+	MsgFieldInvalidNumber   MessageID = "field_invalid_number"
+	MsgFieldOutOfRange      MessageID = "field_out_of_range"
+	MsgFieldInvalidDate     MessageID = "field_invalid_date"
+	MsgFieldInvalidInteger  MessageID = "field_invalid_integer"
+	MsgFieldInvalidValue    MessageID = "field_invalid_value"
+	MsgFieldTooSmall        MessageID = "field_too_small"
+	MsgFieldTooLarge        MessageID = "field_too_large"
+	MsgFieldTooShort        MessageID = "field_too_short"
+	MsgFieldTooLong         MessageID = "field_too_long"
+	MsgFieldPatternMismatch MessageID = "field_pattern_mismatch"
+	MsgFieldNotOneOf        MessageID = "field_not_one_of"
+	MsgFieldValidating      MessageID = "field_validating"
+	MsgWizardReview         MessageID = "wizard_review"
+	MsgWizardNext           MessageID = "wizard_next"
+	MsgWizardBack           MessageID = "wizard_back"
+	MsgWizardSkip           MessageID = "wizard_skip"
+	MsgWizardSkipped        MessageID = "wizard_skipped"
+	MsgWizardSubmit         MessageID = "wizard_submit"
+	MsgClose                MessageID = "close"
+	MsgTasks                MessageID = "tasks"
+	MsgTasksRunning         MessageID = "tasks_running"
+	MsgNoTasks              MessageID = "no_tasks"
+	MsgTaskCompleted        MessageID = "task_completed"
+	MsgTaskCancelled        MessageID = "task_cancelled"
+	MsgNotifications        MessageID = "notifications"
+	MsgNoNotifications      MessageID = "no_notifications"
+	MsgClearAll             MessageID = "clear_all"
+	MsgAll                  MessageID = "all"
+	MsgLevelInfo            MessageID = "level.info"
+	MsgLevelSuccess         MessageID = "level.success"
+	MsgLevelWarning         MessageID = "level.warning"
+	MsgLevelError           MessageID = "level.error"
+	MsgNotificationGroup    MessageID = "notification_group"
+	MsgShowAll              MessageID = "show_all"
+	MsgMoreNotifications    MessageID = "more_notifications"
+	MsgPopupWindowMode      MessageID = "popup_window_mode"
+	MsgPopupSearchNoMatches MessageID = "popup_search_no_matches"
+	MsgPopupSearchInvalid   MessageID = "popup_search_invalid"
 )
 
 // Catalog stores localized message tables and the currently selected locale.
@@ -121,21 +159,59 @@ var defaultCatalog = newDefaultCatalog()
 func newDefaultCatalog() *Catalog {
 	catalog := NewCatalog()
 	catalog.Register("en", map[MessageID]string{
-		MsgLoading:        "Loading...",
-		MsgHintNavigate:   "Navigate",
-		MsgHintConfirm:    "Confirm",
-		MsgHintBack:       "Back",
-		MsgHintQuit:       "Quit",
-		MsgHintSearch:     "Search",
-		MsgNoData:         "No data",
-		MsgNoColumns:      "No columns",
-		MsgEmptyDirectory: "(empty directory)",
-		MsgReadError:      "Error: %s",
-		MsgYes:            "Yes",
-		MsgNo:             "No",
-		MsgConfirm:        "Confirm",
-		MsgCancel:         "Cancel",
-		MsgFieldRequired:  "This field is required",
+		MsgLoading:              "Loading...",
+		MsgHintNavigate:         "Navigate",
+		MsgHintConfirm:          "Confirm",
+		MsgHintBack:             "Back",
+		MsgHintQuit:             "Quit",
+		MsgHintSearch:           "Search",
+		MsgNoData:               "No data",
+		MsgNoColumns:            "No columns",
+		MsgEmptyDirectory:       "(empty directory)",
+		MsgReadError:            "Error: %s",
+		MsgYes:                  "Yes",
+		MsgNo:                   "No",
+		MsgConfirm:              "Confirm",
+		MsgCancel:               "Cancel",
+		MsgFieldRequired:        "This field is required",
+		MsgFieldInvalidNumber:   "Must be a number",
+		MsgFieldOutOfRange:      "Must be between %s and %s",
+		MsgFieldInvalidDate:     "Must be a date (%s)",
+		MsgFieldInvalidInteger:  "Must be a whole number",
+		MsgFieldInvalidValue:    "Invalid value",
+		MsgFieldTooSmall:        "Must be at least %s",
+		MsgFieldTooLarge:        "Must be at most %s",
+		MsgFieldTooShort:        "Must be at least %s characters",
+		MsgFieldTooLong:         "Must be at most %s characters",
+		MsgFieldPatternMismatch: "Must match %s",
+		MsgFieldNotOneOf:        "Must be one of: %s",
+		MsgFieldValidating:      "Checking…",
+		MsgWizardReview:         "Review",
+		MsgWizardNext:           "next",
+		MsgWizardBack:           "back",
+		MsgWizardSkip:           "skip",
+		MsgWizardSkipped:        "Skipped",
+		MsgWizardSubmit:         "submit",
+		MsgClose:                "Close",
+		MsgTasks:                "Background tasks",
+		MsgTasksRunning:         "%d tasks",
+		MsgNoTasks:              "No running tasks",
+		MsgTaskCompleted:        "Completed",
+		MsgTaskCancelled:        "Cancelled",
+		MsgNotifications:        "Notifications",
+		MsgNoNotifications:      "No notifications",
+		MsgClearAll:             "Clear all",
+		MsgAll:                  "All",
+		MsgLevelInfo:            "Info",
+		MsgLevelSuccess:         "Success",
+		MsgLevelWarning:         "Warning",
+		MsgLevelError:           "Error",
+		MsgNotificationGroup:    "%s (%d)",
+		MsgShowAll:              "Show all",
+		MsgMoreNotifications:    "+%d more",
+		MsgPopupWindowMode:      "←↑↓→ move · shift resize · m maximize · enter done",
+		MsgPopupSearchNoMatches: "No matches",
+		MsgPopupSearchInvalid:   "Invalid pattern",
 	})
 	return catalog
 }
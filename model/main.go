@@ -32,6 +32,7 @@ type Main struct {
 	menuStartRow     int
 	menuStartColumn  int
 	menuBottomRow    int
+	layoutSidebarW   int // sidebar width the menu was laid out for
 	menuListStartRow int // actual row where the first menu item renders
 
 	menuCurPage  int
@@ -103,9 +104,22 @@ type Main struct {
 	hoverPointerActive bool
 
 	// Multi-tab navigation (when Options.EnableTabs is true)
-	tabs      *Tabs      // nil when EnableTabs is false
-	activeTab int        // current tab index (0-based)
-	tabStates []tabState // per-tab isolated state (parallel to Options.TabConfigs)
+	tabs       *Tabs       // nil when EnableTabs is false
+	activeTab  int         // current tab index (0-based)
+	tabConfigs []TabConfig // runtime tab definitions (initialized from Options.TabConfigs)
+	tabStates  []tabState  // per-tab isolated state (parallel to tabConfigs)
+
+	// Keyboard focus outside the menu list (F6): the tab or breadcrumb segment
+	// that keyboard navigation and the context menu key act on.
+	focusZone         mainFocusZone
+	focusedTab        int
+	focusedBreadcrumb int // display index in the breadcrumb segments
+
+	menuBar *menuBar // nil without Options.MenuBar
+
+	// draggingTab is the index of the tab header being dragged to reorder,
+	// or -1 when no drag is in progress.
+	draggingTab int
 }
 
 // tabState holds the per-tab navigation state that is saved/restored on tab switch.
@@ -176,42 +190,21 @@ func NewMain(app *App, options *Options) (m *Main) {
 		hoveredBreadcrumbIdx: -1,
 		hoveredMenuItemIdx:   -1,
 		hoveredTabIdx:        -1,
+		draggingTab:          -1,
 		hoveredBackButton:    false,
 		hoverPointerActive:   false,
+		menuBar:              newMenuBar(options.MenuBar),
 	}
 
 	// Initialize multi-tab navigation if enabled
 	if options.EnableTabs && len(options.TabConfigs) > 0 {
-		// Create Tabs widget with titles extracted from TabConfigs
-		titles := make([]string, len(options.TabConfigs))
-		for i, cfg := range options.TabConfigs {
-			titles[i] = cfg.Title
-		}
-		m.tabs = NewTabs(titles)
-		m.tabs.SetBorder(true)
-		m.tabs.Focus()
-
-		// Initialize per-tab state snapshots
-		m.tabStates = make([]tabState, len(options.TabConfigs))
-		for i, cfg := range options.TabConfigs {
-			m.tabStates[i] = tabState{
-				menu:          cfg.Menu,
-				menuTitle:     cfg.MenuTitle,
-				menuList:      cfg.Menu.MenuViews(),
-				selectedIndex: 0,
-				menuCurPage:   1,
-				menuStack:     &util.Stack{},
-			}
+		for _, cfg := range options.TabConfigs {
+			m.appendTab(cfg)
 		}
 
 		// Load initial tab (tab 0)
 		m.activeTab = 0
-		firstState := m.tabStates[0]
-		m.menu = firstState.menu
-		m.menuTitle = firstState.menuTitle
-		m.menuList = firstState.menuList
-		m.menuStack = firstState.menuStack
-		// selectedIndex, menuCurPage already default to 0/1
+		m.loadTabState(0)
 	} else {
 		// Standard single-menu mode (EnableTabs=false or no TabConfigs)
 		m.menuList = m.menu.MenuViews()
@@ -374,100 +367,112 @@ func (m *Main) Update(msg tea.Msg, a *App) (Page, tea.Cmd) {
 		}
 		return m, nil
 	case tea.WindowSizeMsg:
-		m.isDualColumn = msg.Width >= 75 && m.options.DualColumn
-		m.menuStartRow = msg.Height / 3
-		if m.options.MaxMenuStartRow > 0 {
-			if m.menuStartRow > m.options.MaxMenuStartRow {
-				m.menuStartRow = m.options.MaxMenuStartRow
-			}
-		}
-		if !m.options.WhetherDisplayTitle && m.menuStartRow > 1 {
-			m.menuStartRow--
+		m.layout(msg.Width, msg.Height)
+		return m, a.RerenderCmd(true)
+	}
+
+	return m, nil
+}
+
+// layout derives the menu geometry (columns, rows, page size and hit-test
+// rows) from the window size.
+func (m *Main) layout(width, height int) {
+	// A tab sidebar takes its columns off the left edge; the menu is laid
+	// out in the remaining width and then shifted right past the sidebar.
+	sidebarW := m.sidebarWidth()
+	m.layoutSidebarW = sidebarW
+	width -= sidebarW
+	m.isDualColumn = width >= 75 && m.options.DualColumn
+	m.menuStartRow = height / 3
+	if m.options.MaxMenuStartRow > 0 {
+		if m.menuStartRow > m.options.MaxMenuStartRow {
+			m.menuStartRow = m.options.MaxMenuStartRow
 		}
-		// When status bar is at top, it replaces the title bar (forced override).
-		if m.statusBar != nil && m.options.StatusBarPosition == StatusBarTop {
-			m.options.WhetherDisplayTitle = false
+	}
+	if !m.options.WhetherDisplayTitle && m.menuStartRow > 1 {
+		m.menuStartRow--
+	}
+	// When status bar is at top, it replaces the title bar (forced override).
+	if m.statusBar != nil && m.options.StatusBarPosition == StatusBarTop {
+		m.options.WhetherDisplayTitle = false
+	}
+	if m.isDualColumn {
+		switch {
+		case width < 100:
+			m.menuStartColumn = width / 5
+		case width < 150:
+			m.menuStartColumn = width / 4
+		default:
+			m.menuStartColumn = width / 3
 		}
-		if m.isDualColumn {
-			switch {
-			case msg.Width < 100:
-				m.menuStartColumn = msg.Width / 5
-			case msg.Width < 150:
-				m.menuStartColumn = msg.Width / 4
-			default:
-				m.menuStartColumn = msg.Width / 3
-			}
+	} else {
+		if width < 100 {
+			m.menuStartColumn = width / 3
 		} else {
-			if msg.Width < 100 {
-				m.menuStartColumn = msg.Width / 3
-			} else {
-				m.menuStartColumn = msg.Width * 2 / 5
-			}
-		}
-		if m.menuStartColumn < 5 {
-			m.menuStartColumn = 5
+			m.menuStartColumn = width * 2 / 5
 		}
+	}
+	if m.menuStartColumn < 5 {
+		m.menuStartColumn = 5
+	}
+	m.menuStartColumn += sidebarW
 
-		bottomHeight := 13
-		if m.options.BottomHeight > 0 {
-			bottomHeight = m.options.BottomHeight
-		}
-		// Reserve space for status bar (DefaultStatusBar = 1 row).
-		if m.statusBar != nil {
-			bottomHeight++
-		}
-		if m.options.DynamicRowCount {
-			maxEntries := (msg.Height - m.menuStartRow - bottomHeight) * m.getNumColumns()
-			if maxEntries > 10 {
-				m.menuPageSize = maxEntries
-			} else {
-				m.menuPageSize = 10
-			}
+	bottomHeight := 13
+	if m.options.BottomHeight > 0 {
+		bottomHeight = m.options.BottomHeight
+	}
+	// Reserve space for status bar (DefaultStatusBar = 1 row).
+	if m.statusBar != nil {
+		bottomHeight++
+	}
+	if m.options.DynamicRowCount {
+		maxEntries := (height - m.menuStartRow - bottomHeight) * m.getNumColumns()
+		if maxEntries > 10 {
+			m.menuPageSize = maxEntries
 		} else {
 			m.menuPageSize = 10
 		}
+	} else {
+		m.menuPageSize = 10
+	}
 
-		// Compute actual menu list start row and bottom row for hit-testing
-		titleStartRow := m.computeTitleStartRow()
+	// Compute actual menu list start row and bottom row for hit-testing
+	titleStartRow := m.computeTitleStartRow()
 
-		// Leading rows before the menu list:
-		// - Title bar (if displayed): 1 row
-		// - Status bar at top (if configured): 1 row (replaces title bar, same layout effect)
-		// - Filler blank lines: strings.Repeat("\n", titleStartRow-1) → getLines produces titleStartRow parts
-		// - Menu title: 1 row
-		// - Gap "": empty string renders as 1 visual row in JoinVertical
-		leadingRows := 0
-		if m.options.WhetherDisplayTitle {
-			leadingRows++
-		}
-		// When status bar is at top, it occupies row 0 like the title bar does,
-		// so leadingRows must account for it even though WhetherDisplayTitle is forced false.
-		if m.statusBar != nil && m.options.StatusBarPosition == StatusBarTop {
-			leadingRows++
-		}
-		if titleStartRow > 1 {
-			leadingRows += titleStartRow
-		}
-		leadingRows += 2 // menu title (1) + gap "" (1 visual row, see line 429)
+	// Leading rows before the menu list:
+	// - Title bar (if displayed): 1 row
+	// - Status bar at top (if configured): 1 row (replaces title bar, same layout effect)
+	// - Filler blank lines: strings.Repeat("\n", titleStartRow-1) → getLines produces titleStartRow parts
+	// - Menu title: 1 row
+	// - Gap "": empty string renders as 1 visual row in JoinVertical
+	leadingRows := 0
+	if m.options.WhetherDisplayTitle {
+		leadingRows++
+	}
+	// When status bar is at top, it occupies row 0 like the title bar does,
+	// so leadingRows must account for it even though WhetherDisplayTitle is forced false.
+	if m.statusBar != nil && m.options.StatusBarPosition == StatusBarTop {
+		leadingRows++
+	}
+	if titleStartRow > 1 {
+		leadingRows += titleStartRow
+	}
+	leadingRows += 2 // menu title (1) + gap "" (1 visual row, see line 429)
 
-		m.menuListStartRow = leadingRows
+	m.menuListStartRow = leadingRows
 
-		menuDisplayLines := m.menuPageSize
-		if m.isDualColumn {
-			menuDisplayLines = int(math.Ceil(float64(m.menuPageSize) / 2))
-		}
-		m.menuBottomRow = m.menuListStartRow + menuDisplayLines
+	menuDisplayLines := m.menuPageSize
+	if m.isDualColumn {
+		menuDisplayLines = int(math.Ceil(float64(m.menuPageSize) / 2))
+	}
+	m.menuBottomRow = m.menuListStartRow + menuDisplayLines
 
-		if m.menuCurPage > 0 {
-			maxPage := int(math.Ceil(float64(len(m.menuList)) / float64(m.menuPageSize)))
-			if m.menuCurPage > maxPage {
-				m.menuCurPage = maxPage
-			}
+	if m.menuCurPage > 0 {
+		maxPage := int(math.Ceil(float64(len(m.menuList)) / float64(m.menuPageSize)))
+		if m.menuCurPage > maxPage {
+			m.menuCurPage = maxPage
 		}
-		return m, a.RerenderCmd(true)
 	}
-
-	return m, nil
 }
 
 // updateComponents dispatches a message to every registered component so they
@@ -499,15 +504,18 @@ func (m *Main) View(a *App) string {
 	if m.statusBar != nil && m.options.StatusBarPosition == StatusBarTop {
 		statusBarView := m.statusBar.View(a, m)
 		sections = append(sections, statusBarView)
+	} else if m.menuBarShown() {
+		sections = append(sections, m.menuBarView(a))
 	} else if m.options.WhetherDisplayTitle {
 		sections = append(sections, m.TitleView(a))
 	}
 
-	// ── 2. Tab bar (if multi-tab mode enabled) ──
-	if m.options.EnableTabs && m.tabs != nil {
+	// ── 2. Tab bar (if multi-tab mode enabled; the sidebar is overlaid in 6) ──
+	if m.options.EnableTabs && m.tabs != nil && !m.tabSidebarEnabled() {
 		// Update tabs widget size to match window width
 		m.tabs.SetSize(w, 0) // height auto-calculated by tabs widget
-		m.tabs.SetHovered(m.hoveredTabIdx)
+		m.tabs.SetHovered(m.tabHighlight())
+		m.syncTabBadges()
 		sections = append(sections, m.tabs.View())
 	}
 
@@ -599,6 +607,9 @@ func (m *Main) View(a *App) string {
 	} else if bodyHeight < targetHeight {
 		body = style.CurrentStyleSet().AppBackground.Height(targetHeight).Render(body)
 	}
+	if m.tabSidebarEnabled() {
+		body = m.overlayTabSidebar(body, targetHeight)
+	}
 
 	// Combine body + status bar, then apply the app background everywhere except
 	// the cover rectangle registered by its absolute-positioned renderer.
@@ -611,6 +622,29 @@ func (m *Main) View(a *App) string {
 	return renderAppBackground(content, w, ss.AppBackground, a.appBackgroundExclusion)
 }
 
+// overlayTabSidebar paints the tab sidebar over the left columns of body,
+// from below the top bar down to the last body row. The menu is already
+// shifted right by the sidebar width (see layout); components keep the full
+// window width and are covered where they reach into the sidebar.
+func (m *Main) overlayTabSidebar(body string, bodyHeight int) string {
+	top := m.tabSidebarTop()
+	m.tabs.SetSize(m.tabSidebarExpandedWidth(), max(0, bodyHeight-top))
+	m.tabs.SetHovered(m.tabHighlight())
+	m.syncTabBadges()
+	sidebar := strings.Split(m.tabs.View(), "\n")
+	width := m.tabs.SidebarWidth()
+
+	lines := strings.Split(body, "\n")
+	for i, row := range sidebar {
+		y := top + i
+		if y >= len(lines) {
+			break
+		}
+		lines[y] = row + ansi.TruncateLeft(lines[y], width, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
 // renderAppBackground fills the frame with the app background. Rewritten to
 // avoid running lipgloss's full wrap/align/grapheme pipeline over the whole
 // frame on every render — that was the dominant per-frame cost during mouse
@@ -862,6 +896,23 @@ func (m *Main) switchTab(newIndex int) {
 	prevIndex := m.activeTab
 
 	// 1. Save current tab's state
+	m.saveTabState()
+
+	// 2. Call OnActivate hook (if defined) — can veto the switch
+	if m.tabConfigs[newIndex].OnActivate != nil {
+		if !m.tabConfigs[newIndex].OnActivate(m, prevIndex) {
+			return // Hook vetoed the switch
+		}
+	}
+
+	// 3. Restore new tab's state
+	m.activeTab = newIndex
+	m.loadTabState(newIndex)
+	m.ClearTabBadge(newIndex)
+}
+
+// saveTabState snapshots the live navigation state into the active tab's slot.
+func (m *Main) saveTabState() {
 	m.tabStates[m.activeTab] = tabState{
 		menu:          m.menu,
 		menuTitle:     m.menuTitle,
@@ -870,17 +921,12 @@ func (m *Main) switchTab(newIndex int) {
 		menuCurPage:   m.menuCurPage,
 		menuStack:     m.menuStack.DeepCopy(),
 	}
+}
 
-	// 2. Call OnActivate hook (if defined) — can veto the switch
-	if m.options.TabConfigs[newIndex].OnActivate != nil {
-		if !m.options.TabConfigs[newIndex].OnActivate(m, prevIndex) {
-			return // Hook vetoed the switch
-		}
-	}
-
-	// 3. Restore new tab's state
-	m.activeTab = newIndex
-	state := m.tabStates[newIndex]
+// loadTabState restores the navigation state of tab index into the live
+// fields, marks it active in the Tabs widget and clears transient state.
+func (m *Main) loadTabState(index int) {
+	state := m.tabStates[index]
 	m.menu = state.menu
 	m.menuTitle = state.menuTitle
 	m.menuList = state.menuList
@@ -888,10 +934,8 @@ func (m *Main) switchTab(newIndex int) {
 	m.menuCurPage = state.menuCurPage
 	m.menuStack = state.menuStack
 
-	// 4. Update Tabs widget active index
-	m.tabs.SetActive(newIndex)
+	m.tabs.SetActive(index)
 
-	// 5. Clear transient state
 	m.inSearching = false
 	m.searchInput.Reset()
 	m.searchInput.Blur()
@@ -899,6 +943,204 @@ func (m *Main) switchTab(newIndex int) {
 	m.hoveredBreadcrumbIdx = -1
 }
 
+// appendTab registers cfg as a new tab with a fresh navigation state and
+// returns its index. The Tabs widget is created on first use.
+func (m *Main) appendTab(cfg TabConfig) int {
+	if cfg.MenuTitle == nil {
+		cfg.MenuTitle = &MenuItem{Title: cfg.Title}
+	}
+	if m.tabs == nil {
+		m.tabs = NewTabs(nil)
+		m.tabs.SetBorder(true)
+		m.tabs.Focus()
+		if m.options.TabLayout == TabLayoutSidebar {
+			m.tabs.SetOrientation(TabsVertical)
+			m.tabs.SetCollapsed(m.options.TabSidebar.Collapsed)
+			m.tabs.SetSize(m.tabSidebarExpandedWidth(), 0)
+		}
+	}
+	m.tabConfigs = append(m.tabConfigs, cfg)
+	m.tabStates = append(m.tabStates, tabState{
+		menu:          cfg.Menu,
+		menuTitle:     cfg.MenuTitle,
+		menuList:      cfg.Menu.MenuViews(),
+		selectedIndex: 0,
+		menuCurPage:   1,
+		menuStack:     &util.Stack{},
+	})
+	index := m.tabs.AddTab(cfg.Title, cfg.Closable)
+	m.tabs.SetIcon(index, cfg.Icon)
+	return index
+}
+
+// TabCount returns the number of tabs, or 0 when tabs are disabled.
+func (m *Main) TabCount() int {
+	return len(m.tabStates)
+}
+
+// TabIndex returns the index of the tab whose TabConfig.Key is key, or -1.
+func (m *Main) TabIndex(key string) int {
+	if key == "" {
+		return -1
+	}
+	return slices.IndexFunc(m.tabConfigs, func(cfg TabConfig) bool { return cfg.Key == key })
+}
+
+// ActiveTab returns the index of the active tab.
+func (m *Main) ActiveTab() int {
+	return m.activeTab
+}
+
+// ActivateTab switches to the tab at index, honoring its OnActivate veto.
+// Returns whether the tab is active afterwards.
+func (m *Main) ActivateTab(index int) bool {
+	m.switchTab(index)
+	return index >= 0 && index < len(m.tabStates) && m.activeTab == index
+}
+
+// AddTab appends a tab with its own isolated menu hierarchy and returns its
+// index, or -1 when EnableTabs is false or cfg has no Menu. The active tab is
+// unchanged unless this is the first tab; use ActivateTab to focus it.
+// A nil MenuTitle defaults to the tab title.
+func (m *Main) AddTab(cfg TabConfig) int {
+	if !m.options.EnableTabs || cfg.Menu == nil {
+		return -1
+	}
+	first := len(m.tabStates) == 0
+	index := m.appendTab(cfg)
+	if first {
+		m.activeTab = index
+		m.loadTabState(index)
+	}
+	m.relayoutSidebar()
+	return index
+}
+
+// CloseTab closes the tab at index after consulting its OnClose hook.
+// Returns false when the hook vetoed the close or the tab cannot be removed
+// (see RemoveTab).
+func (m *Main) CloseTab(index int) bool {
+	if index < 0 || index >= len(m.tabStates) || len(m.tabStates) <= 1 {
+		return false
+	}
+	if onClose := m.tabConfigs[index].OnClose; onClose != nil && !onClose(m, index) {
+		return false
+	}
+	return m.RemoveTab(index)
+}
+
+// RemoveTab removes the tab at index without consulting OnClose. The last
+// remaining tab cannot be removed. Removing the active tab activates its right
+// neighbour (or the new last tab); that tab's OnActivate is called with
+// prevTabIndex -1 and cannot veto, because the previous tab no longer exists.
+// Unlike a regular switch, the hook runs after the tab's state is restored.
+func (m *Main) RemoveTab(index int) bool {
+	if index < 0 || index >= len(m.tabStates) || len(m.tabStates) <= 1 {
+		return false
+	}
+	wasActive := index == m.activeTab
+
+	m.tabConfigs = append(m.tabConfigs[:index], m.tabConfigs[index+1:]...)
+	m.tabStates = append(m.tabStates[:index], m.tabStates[index+1:]...)
+	m.tabs.RemoveTab(index)
+	m.hoveredTabIdx = -1
+	m.draggingTab = -1
+	m.relayoutSidebar()
+
+	if !wasActive {
+		if index < m.activeTab {
+			m.activeTab--
+		}
+		return true
+	}
+
+	m.activeTab = m.tabs.Active()
+	m.loadTabState(m.activeTab)
+	if onActivate := m.tabConfigs[m.activeTab].OnActivate; onActivate != nil {
+		onActivate(m, -1)
+	}
+	return true
+}
+
+// MoveTab moves the tab at from to position to, shifting the tabs in between.
+// Each tab keeps its navigation state, and the active tab stays active.
+func (m *Main) MoveTab(from, to int) bool {
+	if from < 0 || from >= len(m.tabStates) || to < 0 || to >= len(m.tabStates) || from == to {
+		return false
+	}
+	m.tabConfigs = moveSliceItem(m.tabConfigs, from, to)
+	m.tabStates = moveSliceItem(m.tabStates, from, to)
+	m.tabs.MoveTab(from, to)
+	m.activeTab = movedIndex(m.activeTab, from, to)
+	m.hoveredTabIdx = -1
+	return true
+}
+
+// SetTabTitle renames the tab at index. The breadcrumb follows the new title.
+func (m *Main) SetTabTitle(index int, title string) {
+	if index < 0 || index >= len(m.tabConfigs) {
+		return
+	}
+	m.tabConfigs[index].Title = title
+	m.tabs.SetTitle(index, title)
+	m.relayoutSidebar() // a collapsed sidebar may show the title's first rune
+}
+
+// SetTabBadgeMsg sets the badge of the tab whose TabConfig.Key is Key, like
+// Main.SetTabBadge. It can be sent from a goroutine via program.Send (e.g. a
+// background download updating an inactive tab) to remain race-free in the
+// Update loop. Tabs are addressed by key because they may be added, moved or
+// removed before the message arrives; it is dropped when no tab has the key.
+type SetTabBadgeMsg struct {
+	Key   string
+	Badge TabBadge
+}
+
+// SetTabBadge sets the unread/activity badge of the tab at index. Pass the
+// zero TabBadge to clear it. The badge is cleared when the tab is activated.
+// Must be called from the UI goroutine (e.g. inside Update or a hook); other
+// goroutines send a SetTabBadgeMsg instead.
+func (m *Main) SetTabBadge(index int, badge TabBadge) {
+	if index < 0 || index >= len(m.tabConfigs) {
+		return
+	}
+	m.tabs.SetBadge(index, badge)
+}
+
+// ClearTabBadge clears the badge of the tab at index and calls its
+// OnClearBadge hook.
+func (m *Main) ClearTabBadge(index int) {
+	if index < 0 || index >= len(m.tabConfigs) {
+		return
+	}
+	m.tabs.SetBadge(index, TabBadge{})
+	if onClear := m.tabConfigs[index].OnClearBadge; onClear != nil {
+		onClear(m, index)
+	}
+}
+
+// TabBadge returns the badge currently shown on the tab at index, evaluating
+// TabConfig.Badge when set.
+func (m *Main) TabBadge(index int) TabBadge {
+	if index < 0 || index >= len(m.tabConfigs) {
+		return TabBadge{}
+	}
+	if badge := m.tabConfigs[index].Badge; badge != nil {
+		return badge()
+	}
+	return m.tabs.Badge(index)
+}
+
+// syncTabBadges pushes the dynamic TabConfig.Badge values into the Tabs
+// widget so rendering and hit-testing see the same widths.
+func (m *Main) syncTabBadges() {
+	for i, cfg := range m.tabConfigs {
+		if cfg.Badge != nil {
+			m.tabs.SetBadge(i, cfg.Badge())
+		}
+	}
+}
+
 // buildBreadcrumb constructs a breadcrumb trail from the current tab title
 // and menu stack hierarchy. Used when EnableTabs=true to show navigation context.
 func (m *Main) buildBreadcrumb() *MenuItem {
@@ -909,8 +1151,8 @@ func (m *Main) buildBreadcrumb() *MenuItem {
 	var parts []string
 
 	// Start with active tab title
-	if m.activeTab >= 0 && m.activeTab < len(m.options.TabConfigs) {
-		parts = append(parts, m.options.TabConfigs[m.activeTab].Title)
+	if m.activeTab >= 0 && m.activeTab < len(m.tabConfigs) {
+		parts = append(parts, m.tabConfigs[m.activeTab].Title)
 	}
 
 	// Add menu stack path (each menuStackItem's title)
@@ -940,14 +1182,60 @@ func (m *Main) buildBreadcrumb() *MenuItem {
 }
 
 // tabIndexAt returns the tab index at the given mouse coordinates, or -1 if not over any tab.
-// Calculates the horizontal layout of tabs (with borders and padding) and checks if the click
-// falls within a tab's rendered region.
-func (m *Main) tabIndexAt(x, y int, a *App) int {
-	if !m.options.EnableTabs || m.tabs == nil || len(m.tabStates) == 0 {
+// The horizontal layout comes from Tabs.TabAt, which measures the same
+// per-tab rendering used by the tab bar.
+func (m *Main) tabIndexAt(x, y int, _ *App) int {
+	if row, ok := m.tabSidebarRowAt(x, y); ok {
+		return m.tabs.TabAtRow(row)
+	}
+	if !m.tabBarContains(y) {
+		return -1
+	}
+	return m.tabs.TabAt(x)
+}
+
+// tabCloseButtonAt returns the index of the tab whose close glyph is at the
+// given mouse coordinates, or -1.
+func (m *Main) tabCloseButtonAt(x, y int) int {
+	if row, ok := m.tabSidebarRowAt(x, y); ok {
+		return m.tabs.CloseButtonAtRow(x, row)
+	}
+	if !m.tabBarContains(y) {
+		return -1
+	}
+	return m.tabs.CloseButtonAt(x)
+}
+
+// tabDragTarget returns the index the dragged tab should move to for the
+// pointer at (x, y), or -1 when it should stay where it is.
+func (m *Main) tabDragTarget(x, y int) int {
+	if row, ok := m.tabSidebarRowAt(x, y); ok {
+		// Sidebar rows are uniform, so the row under the pointer is the target.
+		if target := m.tabs.TabAtRow(row); target != m.draggingTab {
+			return target
+		}
 		return -1
 	}
+	if !m.tabBarContains(y) {
+		return -1
+	}
+	return m.tabs.dragTarget(m.draggingTab, x)
+}
+
+// tabBarContains reports whether screen row y falls within the tab bar.
+func (m *Main) tabBarContains(y int) bool {
+	if !m.options.EnableTabs || m.tabs == nil || len(m.tabStates) == 0 || m.tabSidebarEnabled() {
+		return false
+	}
+
+	// Tab bar with borders occupies 3 rows (top border + content + bottom border)
+	tabBarStartRow := m.tabBarTop()
+	tabBarHeight := 3
+	return y >= tabBarStartRow && y < tabBarStartRow+tabBarHeight
+}
 
-	// Calculate tab bar starting row in the view
+// tabBarTop returns the screen row of the tab bar's top border.
+func (m *Main) tabBarTop() int {
 	// Rendering order from View(): [Title bar (optional)] + [Tab bar] + [vertical gap] + [menu title] + ...
 	tabBarStartRow := 0
 	if m.options.WhetherDisplayTitle {
@@ -956,81 +1244,81 @@ func (m *Main) tabIndexAt(x, y int, a *App) int {
 	if m.statusBar != nil && m.options.StatusBarPosition == StatusBarTop {
 		tabBarStartRow++
 	}
+	return tabBarStartRow
+}
 
-	// Tab bar with borders occupies ~3 rows (top border + content + bottom border)
-	// Check if mouse Y is within tab bar region
-	tabBarHeight := 3 // conservative estimate for bordered tabs
-	if y < tabBarStartRow || y >= tabBarStartRow+tabBarHeight {
-		return -1
+// defaultTabSidebarWidth is the expanded sidebar width when
+// TabSidebarOptions.Width is unset.
+const defaultTabSidebarWidth = 20
+
+// tabSidebarEnabled reports whether the tabs render as a left sidebar.
+func (m *Main) tabSidebarEnabled() bool {
+	return m.options.EnableTabs && m.tabs != nil && m.options.TabLayout == TabLayoutSidebar
+}
+
+// tabSidebarExpandedWidth returns the configured expanded sidebar width.
+func (m *Main) tabSidebarExpandedWidth() int {
+	if m.options.TabSidebar.Width > 0 {
+		return m.options.TabSidebar.Width
 	}
+	return defaultTabSidebarWidth
+}
 
-	// Calculate horizontal position of each tab to determine which was clicked
-	// Match the rendering logic from tabs.go renderTabBar()
-	ss := style.CurrentStyleSet()
+// sidebarWidth returns the columns taken by the tab sidebar, or 0.
+func (m *Main) sidebarWidth() int {
+	if !m.tabSidebarEnabled() {
+		return 0
+	}
+	return m.tabs.SidebarWidth()
+}
 
-	activeTabBorder := lipgloss.Border{
-		Top:         "─",
-		Bottom:      " ",
-		Left:        "│",
-		Right:       "│",
-		TopLeft:     "╭",
-		TopRight:    "╮",
-		BottomLeft:  "┘",
-		BottomRight: "└",
-	}
-
-	tabBorder := lipgloss.Border{
-		Top:         "─",
-		Bottom:      "─",
-		Left:        "│",
-		Right:       "│",
-		TopLeft:     "╭",
-		TopRight:    "╮",
-		BottomLeft:  "┴",
-		BottomRight: "┴",
-	}
-
-	borderColor := ss.SelectedItem.GetForeground()
-	if !m.tabs.Focused() {
-		borderColor = ss.Border.GetForeground()
-	}
-
-	tab := lipgloss.NewStyle().
-		Border(tabBorder, true).
-		BorderForeground(borderColor).
-		Padding(0, 1)
-
-	activeTab := lipgloss.NewStyle().
-		Border(activeTabBorder, true).
-		BorderForeground(borderColor).
-		Padding(0, 1)
-
-	// Calculate cumulative width for each tab to determine click boundaries
-	currentX := 0
-	for i := 0; i < len(m.options.TabConfigs); i++ {
-		title := m.options.TabConfigs[i].Title
-
-		var tabContent string
-		var renderedTab string
-		if i == m.activeTab {
-			tabContent = ss.SelectedItem.Render(title)
-			renderedTab = activeTab.Render(tabContent)
-		} else {
-			tabContent = ss.MenuItem.Render(title)
-			renderedTab = tab.Render(tabContent)
-		}
+// tabSidebarTop returns the first screen row of the tab sidebar, which
+// starts below the title bar or top status bar.
+func (m *Main) tabSidebarTop() int {
+	if m.options.WhetherDisplayTitle || (m.statusBar != nil && m.options.StatusBarPosition == StatusBarTop) {
+		return 1
+	}
+	return 0
+}
 
-		tabWidth := lipgloss.Width(renderedTab)
+// tabSidebarRowAt maps screen coordinates to a sidebar row. ok is false when
+// the sidebar is disabled or (x, y) lies outside it.
+func (m *Main) tabSidebarRowAt(x, y int) (row int, ok bool) {
+	if !m.tabSidebarEnabled() || x < 0 || x >= m.sidebarWidth() {
+		return 0, false
+	}
+	row = y - m.tabSidebarTop()
+	if row < 0 || (m.tabs.height > 0 && row >= m.tabs.height) {
+		return 0, false
+	}
+	return row, true
+}
 
-		// Check if click falls within this tab's horizontal range
-		if x >= currentX && x < currentX+tabWidth {
-			return i
-		}
+// TabSidebarCollapsed reports whether the tab sidebar shows icons only.
+func (m *Main) TabSidebarCollapsed() bool {
+	return m.tabSidebarEnabled() && m.tabs.Collapsed()
+}
 
-		currentX += tabWidth
+// SetTabSidebarCollapsed collapses the tab sidebar to icons only, or expands
+// it again, and re-lays out the menu for the new sidebar width. No-op unless
+// Options.TabLayout is TabLayoutSidebar.
+func (m *Main) SetTabSidebarCollapsed(collapsed bool) {
+	if !m.tabSidebarEnabled() || m.tabs.Collapsed() == collapsed {
+		return
 	}
+	m.tabs.SetCollapsed(collapsed)
+	m.hoveredTabIdx = -1
+	m.relayoutSidebar()
+}
 
-	return -1
+// relayoutSidebar re-lays out the menu when the tab sidebar width changed
+// since the last layout, e.g. after adding a tab with a wider icon to a
+// collapsed sidebar.
+func (m *Main) relayoutSidebar() {
+	if m.sidebarWidth() == m.layoutSidebarW || m.app == nil || m.app.WindowWidth() <= 0 {
+		return
+	}
+	m.layout(m.app.WindowWidth(), m.app.WindowHeight())
 }
 
 // TitleView renders the app name as a decorative bar with dashes on both sides.
@@ -1340,6 +1628,7 @@ type menuItemViewCacheEntry struct {
 	selected      bool
 	hovered       bool
 	windowWidth   int
+	startColumn   int // item width depends on menuStartColumn (tab sidebar collapse)
 	maxIndexWidth int
 	dualColumn    bool
 	styleGen      uint64
@@ -1373,8 +1662,8 @@ func (m *Main) menuItemView(a *App, index int) (string, int) {
 		if e, ok := m.menuItemCache[index]; ok &&
 			e.title == item.Title && e.subtitle == item.Subtitle &&
 			e.selected == isSelected && e.hovered == isHovered &&
-			e.windowWidth == windowWidth && e.maxIndexWidth == maxIndexWidth &&
-			e.dualColumn == m.isDualColumn &&
+			e.windowWidth == windowWidth && e.startColumn == m.menuStartColumn &&
+			e.maxIndexWidth == maxIndexWidth && e.dualColumn == m.isDualColumn &&
 			e.styleGen == style.StyleGeneration() && e.scrollPhase == scrollPhase {
 			return e.view, e.width
 		}
@@ -1515,6 +1804,7 @@ func (m *Main) menuItemView(a *App, index int) (string, int) {
 			selected:      isSelected,
 			hovered:       isHovered,
 			windowWidth:   windowWidth,
+			startColumn:   m.menuStartColumn,
 			maxIndexWidth: maxIndexWidth,
 			dualColumn:    m.isDualColumn,
 			styleGen:      style.StyleGeneration(),
@@ -1828,10 +2118,12 @@ func (m *Main) searchInputView(app *App) string {
 			parts = append(parts, hintKey.Render("  "+h.Key)+hintDesc.Render(" "+h.Desc))
 		}
 		hint := layout.JoinHorizontal(layout.Top, parts...)
+		// Center within the menu area, right of the tab sidebar (if any).
 		return lipgloss.NewStyle().
 			Inherit(ss.AppBackground).
 			Width(windowWidth).
 			Align(lipgloss.Center).
+			PaddingLeft(m.sidebarWidth()).
 			PaddingTop(1).
 			Render(hint)
 	}
@@ -1872,9 +2164,31 @@ func (m *Main) keyMsgHandle(msg tea.KeyMsg, a *App) (Page, tea.Cmd) {
 		return m, tea.Batch(cmd)
 	}
 
+	if m.menuBarKeyHandle(msg.String(), a) {
+		return m, a.RerenderCmd(true)
+	}
+
+	if handled, newPage := m.focusKeyHandle(msg.String(), a); handled {
+		if newPage != nil {
+			return newPage, func() tea.Msg { return newPage.Msg() }
+		}
+		return m, a.RerenderCmd(true)
+	}
+
 	// Tab switching (when tabs enabled and not in search mode)
 	if m.options.EnableTabs && m.tabs != nil && len(m.tabStates) > 0 {
 		key := msg.String()
+		if m.tabSidebarEnabled() {
+			switch {
+			case key == "ctrl+down":
+				key = "ctrl+right"
+			case key == "ctrl+up":
+				key = "ctrl+left"
+			case key == m.options.TabSidebar.ToggleKey:
+				m.SetTabSidebarCollapsed(!m.tabs.Collapsed())
+				return m, a.RerenderCmd(true)
+			}
+		}
 		switch key {
 		case "ctrl+tab":
 			m.switchTab((m.activeTab + 1) % len(m.tabStates))
@@ -2010,13 +2324,19 @@ func (m *Main) mouseMsgHandle(msg tea.MouseMsg, a *App) (Page, tea.Cmd) {
 	// we must ignore MouseReleaseMsg to avoid false double-click detection.
 	switch msg.(type) {
 	case tea.MouseClickMsg:
+		m.focusZone = focusZoneMenu // clicking hands the keyboard back to the menu list
+		if m.menuBar != nil {
+			m.menuBar.focused = -1
+		}
 		return m.mouseClickHandle(mouse, a)
 	case tea.MouseMotionMsg:
 		return m.mouseMotionHandle(mouse, a)
 	case tea.MouseReleaseMsg:
-		// Ignore — hover and pointer state are driven by mouseMotionHandle.
+		// Hover and pointer state are driven by mouseMotionHandle.
 		// Clearing them here would flicker the pointer when clicking a menu
-		// item (mouse still over clickable area after release).
+		// item (mouse still over clickable area after release). Only an
+		// in-progress tab drag ends here.
+		m.draggingTab = -1
 		return m, a.Tick(time.Nanosecond)
 	case tea.MouseWheelMsg:
 		return m.mouseWheelHandle(mouse, a)
@@ -2034,11 +2354,30 @@ func (m *Main) mouseClickHandle(mouse tea.Mouse, a *App) (Page, tea.Cmd) {
 
 	switch mouse.Button {
 	case tea.MouseLeft:
+		if m.menuBarShown() {
+			if index := m.menuBar.titleAt(mouse.X, mouse.Y); index >= 0 {
+				m.menuBar.openDropdown(a, index, false)
+				return m, a.RerenderCmd(true)
+			}
+		}
+
 		// Check tab bar click (when multi-tab mode enabled)
 		if m.options.EnableTabs && m.tabs != nil {
+			if tabIdx := m.tabCloseButtonAt(mouse.X, mouse.Y); tabIdx >= 0 {
+				m.CloseTab(tabIdx)
+				return m, a.RerenderCmd(true)
+			}
 			if tabIdx := m.tabIndexAt(mouse.X, mouse.Y, a); tabIdx >= 0 {
-				if tabIdx != m.activeTab {
+				// Pressing on a header also starts a potential drag-to-reorder,
+				// unless OnActivate vetoed the switch to it.
+				switched := tabIdx != m.activeTab
+				if switched {
 					m.switchTab(tabIdx)
+				}
+				if m.activeTab == tabIdx {
+					m.draggingTab = tabIdx
+				}
+				if switched {
 					return m, a.RerenderCmd(true)
 				}
 				return m, a.Tick(time.Nanosecond)
@@ -2132,6 +2471,14 @@ func (m *Main) mouseClickHandle(mouse tea.Mouse, a *App) (Page, tea.Cmd) {
 		return m, a.RerenderCmd(true)
 
 	case tea.MouseMiddle:
+		// Middle-click on a closable tab header closes it, like a browser.
+		if tabIdx := m.tabIndexAt(mouse.X, mouse.Y, a); tabIdx >= 0 {
+			if m.tabs.Closable(tabIdx) {
+				m.CloseTab(tabIdx)
+				return m, a.RerenderCmd(true)
+			}
+			break
+		}
 		if !m.mouseInMenuArea(mouse.Y) {
 			break
 		}
@@ -2142,18 +2489,10 @@ func (m *Main) mouseClickHandle(mouse tea.Mouse, a *App) (Page, tea.Cmd) {
 		return m, a.RerenderCmd(true)
 
 	case tea.MouseRight:
-		idx := m.menuItemAt(mouse.X, mouse.Y)
-		if idx < 0 || idx >= len(m.menuList) {
-			idx = -1 // 空白区域
-		}
-		items := m.menu.ContextMenuItems(a, idx)
-		if len(items) == 0 {
+		contextMenu := m.pointerContextMenu(a, mouse.X, mouse.Y)
+		if contextMenu == nil {
 			break
 		}
-		if idx >= 0 {
-			m.selectedIndex = idx
-		}
-		contextMenu := newContextMenu(m.menu, idx, items, mouse.X, mouse.Y, m.options.ContextMenuOptions)
 		a.pushModal(contextMenu)
 		return m, a.RerenderCmd(true)
 
@@ -2168,6 +2507,17 @@ func (m *Main) mouseWheelHandle(mouse tea.Mouse, a *App) (Page, tea.Cmd) {
 		return m, a.Tick(time.Nanosecond)
 	}
 
+	// The wheel over the tab sidebar scrolls it without switching tabs.
+	if _, ok := m.tabSidebarRowAt(mouse.X, mouse.Y); ok {
+		switch mouse.Button {
+		case tea.MouseWheelUp:
+			m.tabs.ScrollBy(-1)
+		case tea.MouseWheelDown:
+			m.tabs.ScrollBy(1)
+		}
+		return m, a.RerenderCmd(true)
+	}
+
 	switch mouse.Button {
 	case tea.MouseWheelUp:
 		if !m.mouseInMenuArea(mouse.Y) {
@@ -2694,25 +3044,46 @@ func (m *Main) BackToMenu(count int) Page {
 // clickable ancestor segment is at that position. Only works for
 // DefaultStatusBar layout — returns false for other status bars.
 func (m *Main) breadcrumbSegmentAt(x, y int, a *App) (segIdx int, depthIdx int, ok bool) {
-	if m.menuStack.Len() <= 0 {
+	spans, row := m.breadcrumbSpans(a)
+	if y != row {
 		return -1, 0, false
 	}
+	for _, span := range spans {
+		if x >= span.start && x < span.end {
+			return span.segIdx, span.depthIdx, true
+		}
+	}
+	return -1, 0, false
+}
+
+// breadcrumbSpan is the screen extent of a clickable breadcrumb segment.
+type breadcrumbSpan struct {
+	segIdx, depthIdx int
+	start, end       int
+}
+
+// breadcrumbSpans returns the clickable breadcrumb segments, left to right,
+// and the status bar row they are drawn on.
+func (m *Main) breadcrumbSpans(a *App) ([]breadcrumbSpan, int) {
+	if m.menuStack.Len() <= 0 {
+		return nil, -1
+	}
 	if m.statusBar == nil {
-		return -1, 0, false
+		return nil, -1
 	}
 	if _, ok := m.statusBar.(*DefaultStatusBar); !ok {
-		return -1, 0, false
+		return nil, -1
 	}
 
 	// Status bar occupies a specific row based on position.
 	statusBarRow := m.statusBarRowY(a)
-	if statusBarRow < 0 || y != statusBarRow {
-		return -1, 0, false
+	if statusBarRow < 0 {
+		return nil, -1
 	}
 
 	segments := computeBreadcrumbSegments(m)
 	if len(segments) == 0 {
-		return -1, 0, false
+		return nil, -1
 	}
 
 	ss := style.CurrentStyleSet()
@@ -2720,6 +3091,7 @@ func (m *Main) breadcrumbSegmentAt(x, y int, a *App) (segIdx int, depthIdx int,
 	labelW := lipgloss.Width(pathLabel)
 	segStartX := labelW + 1
 
+	var spans []breadcrumbSpan
 	for i, seg := range segments {
 		if seg.IsEllipsis {
 			segStartX += seg.DisplayWidth + 3
@@ -2730,14 +3102,12 @@ func (m *Main) breadcrumbSegmentAt(x, y int, a *App) (segIdx int, depthIdx int,
 		}
 
 		segEndX := segStartX + seg.DisplayWidth
-		if x >= segStartX && x < segEndX {
-			return i, seg.DepthIndex, true
-		}
+		spans = append(spans, breadcrumbSpan{segIdx: i, depthIdx: seg.DepthIndex, start: segStartX, end: segEndX})
 
 		segStartX = segEndX + 3 // " / " = 3 chars
 	}
 
-	return -1, 0, false
+	return spans, statusBarRow
 }
 
 // isOverClickableElement returns true if the given screen position is over
@@ -2764,6 +3134,10 @@ func (m *Main) isOverClickableElement(x, y int, a *App) bool {
 		}
 	}
 
+	if m.menuBarShown() && m.menuBar.titleAt(x, y) >= 0 {
+		return true
+	}
+
 	// 4. Menu list area (single-click selects, double-click enters)
 	if !m.inSearching && m.mouseInMenuArea(y) {
 		idx := m.menuItemAt(x, y)
@@ -2779,6 +3153,18 @@ func (m *Main) isOverClickableElement(x, y int, a *App) bool {
 // terminal mouse pointer shape changes. It updates both the breadcrumb
 // hover rendering state and the global pointer cursor.
 func (m *Main) mouseMotionHandle(mouse tea.Mouse, a *App) (Page, tea.Cmd) {
+	// Dragging a tab header over another header reorders the tabs. The
+	// dragged tab follows the pointer, so draggingTab tracks its new index.
+	if m.draggingTab >= 0 {
+		if mouse.Button != tea.MouseLeft || m.tabs == nil {
+			m.draggingTab = -1
+		} else if target := m.tabDragTarget(mouse.X, mouse.Y); target >= 0 {
+			m.MoveTab(m.draggingTab, target)
+			m.draggingTab = target
+			return m, a.RerenderCmd(true)
+		}
+	}
+
 	oldBreadcrumbHover := m.hoveredBreadcrumbIdx
 	oldTabHover := m.hoveredTabIdx
 	oldPointerActive := m.hoverPointerActive
@@ -2832,6 +3218,13 @@ func (m *Main) mouseMotionHandle(mouse tea.Mouse, a *App) (Page, tea.Cmd) {
 		m.hoveredTabIdx = -1
 	}
 
+	// Update menu bar hover
+	oldMenuBarHover := -1
+	if m.menuBarShown() {
+		oldMenuBarHover = m.menuBar.hovered
+		m.menuBar.hovered = m.menuBar.titleAt(mouse.X, mouse.Y)
+	}
+
 	// Update menu item hover
 	oldMenuItemHover := m.hoveredMenuItemIdx
 	if !m.inSearching && m.mouseInMenuArea(mouse.Y) {
@@ -2853,7 +3246,8 @@ func (m *Main) mouseMotionHandle(mouse tea.Mouse, a *App) (Page, tea.Cmd) {
 
 	// Compute commands for state changes
 	var cmds []tea.Cmd
-	if m.hoveredBreadcrumbIdx != oldBreadcrumbHover || m.hoveredMenuItemIdx != oldMenuItemHover || m.hoveredBackButton != oldBackButtonHover || m.hoveredTabIdx != oldTabHover {
+	if m.hoveredBreadcrumbIdx != oldBreadcrumbHover || m.hoveredMenuItemIdx != oldMenuItemHover || m.hoveredBackButton != oldBackButtonHover || m.hoveredTabIdx != oldTabHover ||
+		(m.menuBar != nil && m.menuBar.hovered != oldMenuBarHover) {
 		// Hover rendering is merged into the frame-rate render cycle: a mouse
 		// sweep changes the hovered row every frame, and rendering the full
 		// view per change drives CPU to a single core. State updates are
@@ -2922,7 +3316,11 @@ func (m *Main) handleBreadcrumbClick(x, y int, a *App) Page {
 	if !ok {
 		return nil
 	}
+	return m.jumpToBreadcrumb(depthIdx)
+}
 
+// jumpToBreadcrumb navigates back to the menu at depth depthIdx of the path.
+func (m *Main) jumpToBreadcrumb(depthIdx int) Page {
 	// Compute full path length for pop count
 	fullPathLen := m.menuStack.Len()
 	if m.menuStack.Len() > 0 {
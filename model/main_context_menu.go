@@ -0,0 +1,306 @@
+package model
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/anhoder/foxful-cli/style"
+)
+
+// Context menus of Main's surfaces. Right-clicking opens the menu of the
+// surface under the pointer: a tab header, a breadcrumb segment, a status bar
+// component, a menu item or blank space.
+//
+// Keyboard access: shift+f10, the menu key or ContextMenuOptions.Key open the
+// context menu of the keyboard-focused element, anchored at it with its first
+// item focused. ContextMenuOptions.FocusZoneKey (F6 by default) moves the
+// keyboard focus from the menu list to the tab headers and the breadcrumb and
+// back; there left/right pick the tab or segment, enter activates it and esc
+// returns to the menu list.
+
+const (
+	contextMenuKey    = "shift+f10"
+	contextMenuKeyAlt = "menu"
+)
+
+// mainFocusZone is the part of Main receiving keyboard navigation.
+type mainFocusZone uint8
+
+const (
+	focusZoneMenu mainFocusZone = iota
+	focusZoneTabs
+	focusZoneBreadcrumb
+)
+
+// TabContextMenu supplies the context menu of tab headers
+// (Options.TabContextMenu), e.g. close, duplicate and move left/right.
+type TabContextMenu interface {
+	// TabContextMenuItems returns the items for the tab at index. Return nil
+	// to show no context menu.
+	TabContextMenuItems(app *App, index int) []ContextMenuItem
+	// TabContextMenuAction is called when an item is selected.
+	TabContextMenuAction(app *App, index int, item ContextMenuItem) (Page, tea.Cmd)
+}
+
+// BreadcrumbContextMenu supplies the context menu of breadcrumb segments
+// (Options.BreadcrumbContextMenu), e.g. copy path or jump. depth is the
+// segment's index in the menu path, 0 being the root menu.
+type BreadcrumbContextMenu interface {
+	BreadcrumbContextMenuItems(app *App, depth int) []ContextMenuItem
+	BreadcrumbContextMenuAction(app *App, depth int, item ContextMenuItem) (Page, tea.Cmd)
+}
+
+// EmptyAreaContextMenu supplies the context menu of blank space
+// (Options.EmptyAreaContextMenu), e.g. refresh or sort by, for menus whose
+// ContextMenuItems returns nothing for index -1.
+type EmptyAreaContextMenu interface {
+	EmptyAreaContextMenuItems(app *App) []ContextMenuItem
+	EmptyAreaContextMenuAction(app *App, item ContextMenuItem) (Page, tea.Cmd)
+}
+
+func (m *Main) isContextMenuKey(key string) bool {
+	return key == contextMenuKey || key == contextMenuKeyAlt ||
+		(m.options.ContextMenuOptions.Key != "" && key == m.options.ContextMenuOptions.Key)
+}
+
+// focusKeyHandle handles the context menu key and keyboard navigation outside
+// the menu list. It returns false for keys left to the menu list.
+func (m *Main) focusKeyHandle(key string, a *App) (bool, Page) {
+	switch {
+	case key == m.options.ContextMenuOptions.FocusZoneKey && key != "":
+		m.switchFocusZone(a)
+		return true, nil
+	case m.isContextMenuKey(key):
+		m.openKeyboardContextMenu(a)
+		return true, nil
+	}
+
+	switch m.focusZone {
+	case focusZoneTabs:
+		if len(m.tabStates) == 0 {
+			m.focusZone = focusZoneMenu
+			return false, nil
+		}
+		switch key {
+		case "left", "h", "up", "k":
+			m.focusedTab = (m.focusedTab - 1 + len(m.tabStates)) % len(m.tabStates)
+		case "right", "l", "down", "j":
+			m.focusedTab = (m.focusedTab + 1) % len(m.tabStates)
+		case "enter":
+			m.switchTab(m.focusedTab)
+			m.focusZone = focusZoneMenu
+		case "esc":
+			m.focusZone = focusZoneMenu
+		default:
+			return false, nil
+		}
+		return true, nil
+	case focusZoneBreadcrumb:
+		spans, _ := m.breadcrumbSpans(a)
+		pos := slices.IndexFunc(spans, func(s breadcrumbSpan) bool { return s.segIdx == m.focusedBreadcrumb })
+		if pos < 0 {
+			m.focusZone = focusZoneMenu
+			return false, nil
+		}
+		switch key {
+		case "left", "h":
+			m.focusedBreadcrumb = spans[max(pos-1, 0)].segIdx
+		case "right", "l":
+			m.focusedBreadcrumb = spans[min(pos+1, len(spans)-1)].segIdx
+		case "enter":
+			m.focusZone = focusZoneMenu
+			return true, m.jumpToBreadcrumb(spans[pos].depthIdx)
+		case "esc":
+			m.focusZone = focusZoneMenu
+		default:
+			return false, nil
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// switchFocusZone moves the keyboard focus to the next available zone: the
+// menu list, the tab headers, then the breadcrumb's last clickable segment.
+func (m *Main) switchFocusZone(a *App) {
+	if m.focusZone == focusZoneMenu && m.options.EnableTabs && m.tabs != nil && len(m.tabStates) > 0 {
+		m.focusZone = focusZoneTabs
+		m.focusedTab = m.activeTab
+		return
+	}
+	if m.focusZone != focusZoneBreadcrumb {
+		if spans, _ := m.breadcrumbSpans(a); len(spans) > 0 {
+			m.focusZone = focusZoneBreadcrumb
+			m.focusedBreadcrumb = spans[len(spans)-1].segIdx
+			return
+		}
+	}
+	m.focusZone = focusZoneMenu
+}
+
+// tabHighlight returns the tab drawn highlighted: the hovered one, else the
+// keyboard-focused one.
+func (m *Main) tabHighlight() int {
+	if m.hoveredTabIdx < 0 && m.focusZone == focusZoneTabs {
+		return m.focusedTab
+	}
+	return m.hoveredTabIdx
+}
+
+// breadcrumbHighlight is tabHighlight for breadcrumb segments.
+func (m *Main) breadcrumbHighlight() int {
+	if m.hoveredBreadcrumbIdx < 0 && m.focusZone == focusZoneBreadcrumb {
+		return m.focusedBreadcrumb
+	}
+	return m.hoveredBreadcrumbIdx
+}
+
+// openKeyboardContextMenu opens the context menu of the keyboard-focused
+// element with its first selectable item focused.
+func (m *Main) openKeyboardContextMenu(a *App) {
+	var cm *ContextMenu
+	switch m.focusZone {
+	case focusZoneTabs:
+		if m.focusedTab >= 0 && m.focusedTab < len(m.tabStates) {
+			x, y := m.tabContextMenuAnchor(m.focusedTab)
+			cm = m.tabContextMenu(a, m.focusedTab, x, y)
+		}
+	case focusZoneBreadcrumb:
+		spans, row := m.breadcrumbSpans(a)
+		if i := slices.IndexFunc(spans, func(s breadcrumbSpan) bool { return s.segIdx == m.focusedBreadcrumb }); i >= 0 {
+			cm = m.breadcrumbContextMenu(a, spans[i].depthIdx, spans[i].start, row)
+		}
+	default:
+		cm = m.menuItemContextMenu(a, m.selectedIndex)
+	}
+	if cm == nil {
+		return
+	}
+	cm.focused = cm.firstSelectableFrom(0, 1)
+	a.pushModal(cm)
+}
+
+// pointerContextMenu builds the context menu of the surface at (x, y), or
+// returns nil when it has none.
+func (m *Main) pointerContextMenu(a *App, x, y int) *ContextMenu {
+	if m.options.EnableTabs && m.tabs != nil {
+		if index := m.tabIndexAt(x, y, a); index >= 0 {
+			return m.tabContextMenu(a, index, x, y)
+		}
+	}
+	if statusBar, ok := m.statusBar.(*DefaultStatusBar); ok {
+		if bounds, ok := statusBar.componentAt(x, y, a, m); ok {
+			if component, ok := bounds.component.(ContextMenuStatusBarComponent); ok {
+				return m.statusBarContextMenu(a, component, x, y)
+			}
+		}
+	}
+	if _, depth, ok := m.breadcrumbSegmentAt(x, y, a); ok {
+		return m.breadcrumbContextMenu(a, depth, x, y)
+	}
+
+	index := m.menuItemAt(x, y)
+	if index < 0 || index >= len(m.menuList) {
+		index = -1 // 空白区域
+	}
+	items := m.menu.ContextMenuItems(a, index)
+	if len(items) == 0 {
+		if index < 0 {
+			return m.emptyAreaContextMenu(a, x, y)
+		}
+		return nil
+	}
+	if index >= 0 {
+		m.selectedIndex = index
+	}
+	return newContextMenu(m.menu, index, items, x, y, m.options.ContextMenuOptions)
+}
+
+// menuItemContextMenu builds the context menu of a menu list item, anchored
+// below the item's text.
+func (m *Main) menuItemContextMenu(a *App, index int) *ContextMenu {
+	if index < 0 || index >= len(m.menuList) {
+		return nil
+	}
+	items := m.menu.ContextMenuItems(a, index)
+	if len(items) == 0 {
+		return nil
+	}
+	x, _, _ := m.menuItemTextBounds(index)
+	return newContextMenu(m.menu, index, items, x, m.menuItemRow(index), m.options.ContextMenuOptions)
+}
+
+// menuItemRow returns the screen row of a menu list item on the current page.
+func (m *Main) menuItemRow(index int) int {
+	row := index - m.getPageStartIndex()
+	if m.isDualColumn {
+		row /= 2
+	}
+	return m.menuListStartRow + row
+}
+
+// tabContextMenuAnchor returns where the keyboard-opened context menu of a tab
+// header is anchored: below it, or beside it in the sidebar.
+func (m *Main) tabContextMenuAnchor(index int) (x, y int) {
+	if m.tabSidebarEnabled() {
+		// The menu opens one row below its anchor: level with the tab row.
+		return m.sidebarWidth(), m.tabSidebarTop() + index - m.tabs.offset - 1
+	}
+	// Anchored on the bar's bottom border, so the menu opens below it.
+	return m.tabs.tabSpans(style.CurrentStyleSet())[index].start, m.tabBarTop() + 2
+}
+
+// tabContextMenu builds the context menu of a tab header, anchored at (x, y).
+func (m *Main) tabContextMenu(a *App, index, x, y int) *ContextMenu {
+	hook := m.options.TabContextMenu
+	if hook == nil {
+		return nil
+	}
+	items := hook.TabContextMenuItems(a, index)
+	if len(items) == 0 {
+		return nil
+	}
+	return newContextMenuFunc(items, x, y, m.options.ContextMenuOptions, func(app *App, item ContextMenuItem) (Page, tea.Cmd) {
+		return hook.TabContextMenuAction(app, index, item)
+	})
+}
+
+// breadcrumbContextMenu builds the context menu of the breadcrumb segment at
+// depth, anchored at (x, y).
+func (m *Main) breadcrumbContextMenu(a *App, depth, x, y int) *ContextMenu {
+	hook := m.options.BreadcrumbContextMenu
+	if hook == nil {
+		return nil
+	}
+	items := hook.BreadcrumbContextMenuItems(a, depth)
+	if len(items) == 0 {
+		return nil
+	}
+	return newContextMenuFunc(items, x, y, m.options.ContextMenuOptions, func(app *App, item ContextMenuItem) (Page, tea.Cmd) {
+		return hook.BreadcrumbContextMenuAction(app, depth, item)
+	})
+}
+
+// statusBarContextMenu builds the context menu of a status bar component,
+// anchored at (x, y).
+func (m *Main) statusBarContextMenu(a *App, component ContextMenuStatusBarComponent, x, y int) *ContextMenu {
+	items := component.StatusBarContextMenuItems(a)
+	if len(items) == 0 {
+		return nil
+	}
+	return newContextMenuFunc(items, x, y, m.options.ContextMenuOptions, component.StatusBarContextMenuAction)
+}
+
+// emptyAreaContextMenu builds the context menu of blank space, anchored at
+// (x, y).
+func (m *Main) emptyAreaContextMenu(a *App, x, y int) *ContextMenu {
+	hook := m.options.EmptyAreaContextMenu
+	if hook == nil {
+		return nil
+	}
+	items := hook.EmptyAreaContextMenuItems(a)
+	if len(items) == 0 {
+		return nil
+	}
+	return newContextMenuFunc(items, x, y, m.options.ContextMenuOptions, hook.EmptyAreaContextMenuAction)
+}
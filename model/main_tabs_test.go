@@ -1,7 +1,13 @@
 package model
 
 import (
+	"strconv"
+	"strings"
 	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // mockMenu is a simple test menu implementation
@@ -348,3 +354,304 @@ func TestMainTabIndexAt(t *testing.T) {
 		t.Errorf("Expected -1 when EnableTabs=false, got %d", idx)
 	}
 }
+
+func newDynamicTabsMain(t *testing.T, configure ...func(*Options)) (*App, *Main) {
+	t.Helper()
+	ops := DefaultOptions()
+	ops.EnableTabs = true
+	ops.WhetherDisplayTitle = false
+	ops.TabConfigs = []TabConfig{
+		{Title: "Home", Menu: &mockMenu{key: "home", items: []MenuItem{{Title: "A"}, {Title: "B"}}}},
+		{Title: "Playlist", Menu: &mockMenu{key: "playlist", items: []MenuItem{{Title: "X"}, {Title: "Y"}}}, Closable: true},
+	}
+	for _, fn := range configure {
+		fn(ops)
+	}
+	app := NewApp(ops)
+	main := NewMain(app, ops)
+	app.main = main
+	app.windowWidth = 80
+	app.windowHeight = 24
+	return app, main
+}
+
+// TestMainDynamicTabsPreserveState verifies that adding, moving, renaming and
+// removing tabs keeps every tab's navigation state attached to its content.
+func TestMainDynamicTabsPreserveState(t *testing.T) {
+	_, main := newDynamicTabsMain(t)
+
+	idx := main.AddTab(TabConfig{Title: "Radio", Menu: &mockMenu{key: "radio", items: []MenuItem{{Title: "R1"}, {Title: "R2"}}}})
+	if idx != 2 || main.TabCount() != 3 {
+		t.Fatalf("AddTab() = %d, TabCount() = %d, want 2, 3", idx, main.TabCount())
+	}
+	if main.ActiveTab() != 0 {
+		t.Fatalf("AddTab changed active tab to %d", main.ActiveTab())
+	}
+	if main.tabStates[2].menuTitle == nil || main.tabStates[2].menuTitle.Title != "Radio" {
+		t.Fatal("nil MenuTitle should default to the tab title")
+	}
+
+	main.selectedIndex = 1
+	if !main.ActivateTab(2) {
+		t.Fatal("ActivateTab(2) failed")
+	}
+	main.selectedIndex = 1
+
+	// Move the active tab to the front: it stays active and keeps its state.
+	if !main.MoveTab(2, 0) {
+		t.Fatal("MoveTab(2, 0) failed")
+	}
+	if main.ActiveTab() != 0 || main.menu.GetMenuKey() != "radio" || main.tabs.Active() != 0 {
+		t.Fatalf("after move active=%d menu=%s", main.ActiveTab(), main.menu.GetMenuKey())
+	}
+	if got := main.tabs.Title(1); got != "Home" {
+		t.Fatalf("tab 1 title = %q, want Home", got)
+	}
+
+	main.SetTabTitle(0, "Live Radio")
+	if got := main.buildBreadcrumb().Title; got != "Live Radio > Radio" {
+		t.Fatalf("breadcrumb = %q after rename", got)
+	}
+
+	// Removing an inactive tab after the active one keeps the active index.
+	if !main.RemoveTab(2) {
+		t.Fatal("RemoveTab(2) failed")
+	}
+	main.switchTab(1)
+	if main.menu.GetMenuKey() != "home" || main.selectedIndex != 1 {
+		t.Fatalf("Home state lost: menu=%s selected=%d", main.menu.GetMenuKey(), main.selectedIndex)
+	}
+	main.switchTab(0)
+	if main.menu.GetMenuKey() != "radio" || main.selectedIndex != 1 {
+		t.Fatalf("Radio state lost: menu=%s selected=%d", main.menu.GetMenuKey(), main.selectedIndex)
+	}
+
+	// Removing the active tab activates its right neighbour.
+	if !main.RemoveTab(0) {
+		t.Fatal("RemoveTab(0) failed")
+	}
+	if main.ActiveTab() != 0 || main.menu.GetMenuKey() != "home" || main.selectedIndex != 1 {
+		t.Fatalf("after removing active tab: active=%d menu=%s selected=%d", main.ActiveTab(), main.menu.GetMenuKey(), main.selectedIndex)
+	}
+
+	// The last remaining tab cannot be removed.
+	if main.RemoveTab(0) {
+		t.Fatal("RemoveTab removed the last tab")
+	}
+}
+
+// TestMainCloseTabVeto verifies that OnClose can veto CloseTab but not RemoveTab.
+func TestMainCloseTabVeto(t *testing.T) {
+	_, main := newDynamicTabsMain(t)
+	allow := false
+	var closedIndex int
+	main.tabConfigs[1].OnClose = func(_ *Main, index int) bool {
+		closedIndex = index
+		return allow
+	}
+
+	if main.CloseTab(1) {
+		t.Fatal("CloseTab should be vetoed")
+	}
+	if main.TabCount() != 2 || closedIndex != 1 {
+		t.Fatalf("TabCount() = %d, OnClose index = %d", main.TabCount(), closedIndex)
+	}
+	allow = true
+	if !main.CloseTab(1) || main.TabCount() != 1 {
+		t.Fatalf("CloseTab should succeed once allowed, TabCount() = %d", main.TabCount())
+	}
+}
+
+// TestMainTabCloseGlyphAndMiddleClick verifies that the close glyph is
+// hit-tested from the rendered tab bar and that middle-click closes a tab.
+func TestMainTabCloseGlyphAndMiddleClick(t *testing.T) {
+	app, main := newDynamicTabsMain(t)
+
+	bar := ansi.Strip(main.tabs.View())
+	row := strings.Split(bar, "\n")[1]
+	glyphX := strings.Index(row, tabCloseGlyph)
+	if glyphX < 0 {
+		t.Fatalf("closable tab has no close glyph: %q", row)
+	}
+	glyphX = ansi.StringWidth(row[:glyphX])
+
+	if got := main.tabCloseButtonAt(glyphX, 1); got != 1 {
+		t.Fatalf("tabCloseButtonAt(%d, 1) = %d, want 1", glyphX, got)
+	}
+	if got := main.tabCloseButtonAt(1, 1); got != -1 {
+		t.Fatalf("non-closable tab reported a close button: %d", got)
+	}
+
+	// Middle-click on the non-closable Home tab does nothing.
+	main.mouseMsgHandle(tea.MouseClickMsg(tea.Mouse{X: 1, Y: 1, Button: tea.MouseMiddle}), app)
+	if main.TabCount() != 2 {
+		t.Fatal("middle-click closed a non-closable tab")
+	}
+
+	main.mouseMsgHandle(tea.MouseClickMsg(tea.Mouse{X: glyphX, Y: 1, Button: tea.MouseLeft}), app)
+	if main.TabCount() != 1 {
+		t.Fatalf("clicking the close glyph did not close the tab, TabCount() = %d", main.TabCount())
+	}
+
+	main.AddTab(TabConfig{Title: "Again", Menu: &mockMenu{key: "again"}, Closable: true})
+	x := main.tabs.tabSpans(style.CurrentStyleSet())[1].start + 1
+	main.mouseMsgHandle(tea.MouseClickMsg(tea.Mouse{X: x, Y: 1, Button: tea.MouseMiddle}), app)
+	if main.TabCount() != 1 {
+		t.Fatalf("middle-click did not close the closable tab, TabCount() = %d", main.TabCount())
+	}
+}
+
+// TestMainTabDragReorder verifies that dragging a tab header over another
+// reorders the tabs and ends on release.
+func TestMainTabDragReorder(t *testing.T) {
+	app, main := newDynamicTabsMain(t)
+	main.AddTab(TabConfig{Title: "Radio", Menu: &mockMenu{key: "radio"}})
+	spans := main.tabs.tabSpans(style.CurrentStyleSet())
+
+	main.mouseMsgHandle(tea.MouseClickMsg(tea.Mouse{X: spans[0].start + 1, Y: 1, Button: tea.MouseLeft}), app)
+	if main.draggingTab != 0 {
+		t.Fatalf("press on tab header did not start drag, draggingTab = %d", main.draggingTab)
+	}
+
+	main.mouseMsgHandle(tea.MouseMotionMsg(tea.Mouse{X: spans[2].end - 1, Y: 1, Button: tea.MouseLeft}), app)
+	if got := main.tabs.Title(2); got != "Home" {
+		t.Fatalf("after drag tab 2 = %q, want Home", got)
+	}
+	if main.ActiveTab() != 2 || main.menu.GetMenuKey() != "home" {
+		t.Fatalf("dragged tab should stay active: active=%d menu=%s", main.ActiveTab(), main.menu.GetMenuKey())
+	}
+
+	main.mouseMsgHandle(tea.MouseReleaseMsg(tea.Mouse{X: spans[2].end - 1, Y: 1, Button: tea.MouseLeft}), app)
+	if main.draggingTab != -1 {
+		t.Fatal("release did not end the drag")
+	}
+	// A vetoed switch starts no drag; RemoveTab's hook sees the new state.
+	var hookMenu string
+	main.tabConfigs[1].OnActivate = func(m *Main, prev int) bool {
+		hookMenu = m.menu.GetMenuKey()
+		return prev == -1
+	}
+	spans = main.tabs.tabSpans(style.CurrentStyleSet())
+	main.mouseMsgHandle(tea.MouseClickMsg(tea.Mouse{X: spans[1].start + 1, Y: 1, Button: tea.MouseLeft}), app)
+	if main.ActiveTab() != 2 || main.draggingTab != -1 {
+		t.Fatalf("vetoed switch: active=%d draggingTab=%d", main.ActiveTab(), main.draggingTab)
+	}
+	main.RemoveTab(2)
+	if main.ActiveTab() != 1 || hookMenu != main.tabStates[1].menu.GetMenuKey() {
+		t.Fatalf("OnActivate after RemoveTab saw menu %q, active=%d", hookMenu, main.ActiveTab())
+	}
+}
+
+// TestMainTabBadges verifies that static and dynamic badges render on
+// inactive tabs, show in the status bar, and clear when the tab is activated.
+func TestMainTabBadges(t *testing.T) {
+	app, main := newDynamicTabsMain(t)
+	unread := 3
+	cleared := -1
+	main.AddTab(TabConfig{
+		Title: "Inbox",
+		Menu:  &mockMenu{key: "inbox"},
+		Badge: func() TabBadge {
+			if unread == 0 {
+				return TabBadge{}
+			}
+			return TabBadge{Text: strconv.Itoa(unread), Level: NotificationWarning}
+		},
+		OnClearBadge: func(_ *Main, index int) {
+			cleared = index
+			unread = 0
+		},
+	})
+	main.SetTabBadge(1, TabBadge{Dot: true, Level: NotificationError})
+
+	view := ansi.Strip(main.View(app))
+	if !strings.Contains(view, "Playlist "+tabBadgeDot) || !strings.Contains(view, "Inbox 3") {
+		t.Fatalf("inactive tab badges missing from view:\n%s", view)
+	}
+
+	main.switchTab(1)
+	if !main.tabs.Badge(1).IsZero() {
+		t.Fatal("activating a tab should clear its static badge")
+	}
+	main.switchTab(2)
+	if cleared != 2 || !main.TabBadge(2).IsZero() {
+		t.Fatalf("OnClearBadge not called on activation, cleared = %d", cleared)
+	}
+
+	// A badge set on the active tab is shown in the status bar breadcrumb.
+	main.switchTab(1)
+	main.SetTabBadge(1, TabBadge{Text: "new"})
+	bar := ansi.Strip((&DefaultStatusBar{}).View(app, main))
+	if !strings.Contains(bar, "Playlist new") {
+		t.Fatalf("status bar missing active tab badge: %q", bar)
+	}
+	// Background senders use SetTabBadgeMsg, whichever page is shown.
+	app.page = &notificationMouseSpyPage{}
+	// It addresses tabs by key, so a move before delivery is harmless.
+	main.AddTab(TabConfig{Key: "downloads", Title: "Downloads", Menu: &mockMenu{key: "downloads"}})
+	main.MoveTab(main.TabIndex("downloads"), 0)
+	app.Update(SetTabBadgeMsg{Key: "downloads", Badge: TabBadge{Text: "9"}})
+	if main.TabIndex("downloads") != 0 || main.tabs.Badge(0).Text != "9" {
+		t.Fatalf("SetTabBadgeMsg not applied, badge = %+v", main.tabs.Badge(0))
+	}
+	main.RemoveTab(0)
+	app.Update(SetTabBadgeMsg{Key: "downloads", Badge: TabBadge{Text: "10"}})
+	for i := range main.TabCount() {
+		if main.tabs.Badge(i).Text == "10" {
+			t.Fatalf("a message for a removed tab landed on tab %d", i)
+		}
+	}
+}
+
+// TestMainTabSidebarLayout verifies that the sidebar layout shifts the menu
+// right, switches tabs on click and re-lays out the menu when collapsed.
+func TestMainTabSidebarLayout(t *testing.T) {
+	app, main := newDynamicTabsMain(t, func(ops *Options) {
+		ops.TabLayout = TabLayoutSidebar
+		ops.TabSidebar = TabSidebarOptions{Width: 18, ToggleKey: "ctrl+b"}
+	})
+
+	main.layout(80, 24)
+	expandedColumn := main.MenuStartColumn()
+	if expandedColumn < 18+5 {
+		t.Fatalf("menu start column %d should be past the 18-column sidebar", expandedColumn)
+	}
+
+	lines := strings.Split(ansi.Strip(main.View(app)), "\n")
+	top := main.tabSidebarTop()
+	if !strings.HasPrefix(lines[top], " Home") || !strings.HasPrefix(lines[top+1], " Playlist") {
+		t.Fatalf("sidebar rows missing: %q / %q", lines[top], lines[top+1])
+	}
+	if strings.Contains(strings.Join(lines, "\n"), "╭") {
+		t.Fatal("sidebar layout should not render the horizontal tab bar")
+	}
+
+	main.mouseMsgHandle(tea.MouseClickMsg(tea.Mouse{X: 3, Y: top + 1, Button: tea.MouseLeft}), app)
+	if main.ActiveTab() != 1 || main.menu.GetMenuKey() != "playlist" {
+		t.Fatalf("clicking the sidebar row did not switch tabs, active = %d", main.ActiveTab())
+	}
+	main.mouseMsgHandle(tea.MouseReleaseMsg(tea.Mouse{X: 3, Y: top + 1, Button: tea.MouseLeft}), app)
+
+	main.keyMsgHandle(newKeyMsg("ctrl+up"), app)
+	if main.ActiveTab() != 0 {
+		t.Fatalf("ctrl+up should switch to the previous tab, active = %d", main.ActiveTab())
+	}
+
+	main.keyMsgHandle(newKeyMsg("ctrl+b"), app)
+	if !main.TabSidebarCollapsed() || main.MenuStartColumn() >= expandedColumn {
+		t.Fatalf("collapse should shift the menu left: collapsed=%v column=%d", main.TabSidebarCollapsed(), main.MenuStartColumn())
+	}
+	if got := main.tabIndexAt(1, top+1, app); got != 1 {
+		t.Fatalf("collapsed sidebar tabIndexAt = %d, want 1", got)
+	}
+	// A wider icon widens the collapsed sidebar; the menu follows it.
+	collapsedColumn := main.MenuStartColumn()
+	index := main.AddTab(TabConfig{Title: "Radio", Icon: "📻", Menu: &mockMenu{key: "radio", items: []MenuItem{{Title: "FM"}}}})
+	if main.MenuStartColumn() != collapsedColumn+1 {
+		t.Fatalf("AddTab should re-lay out the menu: column %d, want %d", main.MenuStartColumn(), collapsedColumn+1)
+	}
+	main.RemoveTab(index)
+	if main.MenuStartColumn() != collapsedColumn {
+		t.Fatalf("RemoveTab should re-lay out the menu: column %d, want %d", main.MenuStartColumn(), collapsedColumn)
+	}
+}
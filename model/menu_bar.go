@@ -0,0 +1,249 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Application menu bar (Options.MenuBar), drawn in the title row with the app
+// name on the right. Clicking a title or pressing alt+<mnemonic> opens its
+// dropdown; F10 focuses the bar, where left/right pick a title and
+// enter/down open it. In an open dropdown left/right switch to the
+// neighbouring one. Selected items run the command registered under their ID
+// with App.RegisterCommand.
+//
+// Mnemonics take precedence over the app's own key bindings (the menu's key
+// handling, tab and sidebar keys): alt+letter opens the dropdown whenever a
+// title has that mnemonic. Only existing mnemonics are claimed, so other
+// alt+ keys still reach the app.
+
+const menuBarKey = "f10"
+
+// MenuBarMenu is a top-level entry of the application menu bar and the
+// dropdown it opens.
+type MenuBarMenu struct {
+	Title string
+	// Mnemonic is a letter of Title, underlined, that opens the dropdown with
+	// alt+letter. 0 = the first letter of Title.
+	Mnemonic rune
+	// Items of the dropdown. Selecting one runs the command registered under
+	// its ID; submenus (Children) are supported.
+	Items []ContextMenuItem
+}
+
+// CommandHandler runs a command, e.g. one selected from the menu bar. item is
+// the selected item; its Path holds the IDs from the dropdown down.
+type CommandHandler func(app *App, item ContextMenuItem) (Page, tea.Cmd)
+
+// RegisterCommand registers the handler run for menu bar items with ID id,
+// replacing any previous one. A nil handler unregisters it.
+func (a *App) RegisterCommand(id string, handler CommandHandler) {
+	if handler == nil {
+		delete(a.commands, id)
+		return
+	}
+	if a.commands == nil {
+		a.commands = make(map[string]CommandHandler)
+	}
+	a.commands[id] = handler
+}
+
+// runCommand runs the handler registered for item.ID, if any.
+func (a *App) runCommand(item ContextMenuItem) (Page, tea.Cmd) {
+	if handler, ok := a.commands[item.ID]; ok {
+		return handler(a, item)
+	}
+	return nil, nil
+}
+
+// menuBarSpan is the extent of a title on the bar row, as last rendered.
+type menuBarSpan struct {
+	start, end int
+}
+
+// menuBar is the state of the application menu bar.
+type menuBar struct {
+	menus   []MenuBarMenu
+	spans   []menuBarSpan
+	focused int  // title with the keyboard focus or an open dropdown, -1 = inactive
+	open    bool // focused's dropdown is open
+	hovered int  // title under the mouse, -1 = none
+}
+
+func newMenuBar(menus []MenuBarMenu) *menuBar {
+	if len(menus) == 0 {
+		return nil
+	}
+	return &menuBar{menus: menus, focused: -1, hovered: -1}
+}
+
+// menuBarShown reports whether the menu bar is drawn: it takes the title row,
+// which the status bar replaces when at the top.
+func (m *Main) menuBarShown() bool {
+	return m.menuBar != nil && m.options.WhetherDisplayTitle
+}
+
+// mnemonic returns the letter opening the menu, lower-cased.
+func (mb MenuBarMenu) mnemonic() rune {
+	if mb.Mnemonic != 0 {
+		return unicode.ToLower(mb.Mnemonic)
+	}
+	for _, r := range mb.Title {
+		return unicode.ToLower(r)
+	}
+	return 0
+}
+
+// mnemonicIndex returns the menu whose mnemonic key is key: alt+letter, or the
+// bare letter when withAlt is false. -1 if none.
+func (b *menuBar) mnemonicIndex(key string, withAlt bool) int {
+	if withAlt {
+		var ok bool
+		if key, ok = strings.CutPrefix(key, "alt+"); !ok {
+			return -1
+		}
+	}
+	runes := []rune(key)
+	if len(runes) != 1 {
+		return -1
+	}
+	for i, menu := range b.menus {
+		if menu.mnemonic() == unicode.ToLower(runes[0]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// titleAt returns the title at the given screen position, or -1.
+func (b *menuBar) titleAt(x, y int) int {
+	if y != 0 {
+		return -1
+	}
+	for i, span := range b.spans {
+		if x >= span.start && x < span.end {
+			return i
+		}
+	}
+	return -1
+}
+
+// openDropdown opens the dropdown of the menu at index below its title. A
+// dropdown opened from the keyboard focuses its first item.
+func (b *menuBar) openDropdown(app *App, index int, keyboard bool) {
+	b.focused, b.open, b.hovered = index, false, -1
+	if len(b.menus[index].Items) == 0 {
+		return
+	}
+	x := 0
+	if index < len(b.spans) {
+		x = b.spans[index].start
+	}
+	cm := newContextMenuFunc(b.menus[index].Items, x, 0, app.options.ContextMenuOptions, func(app *App, item ContextMenuItem) (Page, tea.Cmd) {
+		b.focused = -1
+		return app.runCommand(item)
+	})
+	cm.bar, cm.barNext = b, -1
+	if keyboard {
+		cm.focused = cm.firstSelectableFrom(0, 1)
+	}
+	b.open = true
+	app.pushModal(cm)
+}
+
+// dropdownClosed is called when a dropdown closes without a selection: it
+// opens dropdown next (-1 = none) instead. A dropdown closed from the keyboard
+// leaves the bar focused.
+func (b *menuBar) dropdownClosed(app *App, next int, keyboard bool) {
+	b.open = false
+	switch {
+	case next >= 0:
+		b.openDropdown(app, next, keyboard)
+	case !keyboard:
+		b.focused = -1
+	}
+}
+
+// step returns the title dir (-1/1) away from the focused one, wrapping.
+func (b *menuBar) step(dir int) int {
+	return (b.focused + dir + len(b.menus)) % len(b.menus)
+}
+
+// menuBarKeyHandle handles F10, the alt+letter mnemonics and the keys of a
+// focused bar. It runs before Main's other key handling, so mnemonics win
+// over app bindings of the same key. It returns false for keys left to the
+// rest of Main.
+func (m *Main) menuBarKeyHandle(key string, a *App) bool {
+	if !m.menuBarShown() {
+		return false
+	}
+	b := m.menuBar
+	if index := b.mnemonicIndex(key, true); index >= 0 {
+		b.openDropdown(a, index, true)
+		return true
+	}
+	if b.focused < 0 {
+		if key != menuBarKey {
+			return false
+		}
+		b.focused = 0
+		return true
+	}
+
+	switch key {
+	case "left", "h":
+		b.focused = b.step(-1)
+	case "right", "l":
+		b.focused = b.step(1)
+	case "enter", "space", "down", "j":
+		b.openDropdown(a, b.focused, true)
+	case "esc", menuBarKey:
+		b.focused = -1
+	default:
+		// A focused bar keeps the keyboard; bare mnemonics open dropdowns.
+		if index := b.mnemonicIndex(key, false); index >= 0 {
+			b.openDropdown(a, index, true)
+		}
+	}
+	return true
+}
+
+// menuBarView renders the bar: the titles on the left, the app name on the
+// right. It records the title spans for hit-testing.
+func (m *Main) menuBarView(a *App) string {
+	b := m.menuBar
+	ss := style.CurrentStyleSet()
+	base := ss.Title.Inherit(ss.AppBackground)
+	w := a.WindowWidth()
+
+	var bar strings.Builder
+	b.spans = b.spans[:0]
+	x := 0
+	for i, menu := range b.menus {
+		st := base
+		switch {
+		case i == b.hovered:
+			st = ss.Popup.ContextMenuItemHover
+		case i == b.focused:
+			st = ss.Popup.ContextMenuItemFocused
+		}
+		label := st.Render(" " + underlineMnemonic(menu.Title, menu.mnemonic()) + " ")
+		width := lipgloss.Width(label)
+		b.spans = append(b.spans, menuBarSpan{start: x, end: x + width})
+		bar.WriteString(label)
+		x += width
+	}
+
+	name := " " + m.options.AppName + " "
+	if gap := w - x - lipgloss.Width(name); gap > 0 && strings.TrimSpace(m.options.AppName) != "" {
+		bar.WriteString(base.Render(strings.Repeat(" ", gap) + name))
+	} else if w > x {
+		bar.WriteString(base.Render(strings.Repeat(" ", w-x)))
+	}
+	return ansi.Truncate(bar.String(), w, "")
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestMenuBarKeyboardMouseAndCommands(t *testing.T) {
+	options := DefaultOptions()
+	options.AppName = "Player"
+	options.MainMenu = &testMenu{items: []MenuItem{{Title: "Alpha"}}}
+	options.MenuBar = []MenuBarMenu{
+		{Title: "File", Items: []ContextMenuItem{{ID: "open", Label: "Open"}, {ID: "quit", Label: "Quit"}}},
+		{Title: "View", Items: []ContextMenuItem{{ID: "zoom", Label: "Zoom"}}},
+	}
+	app := NewApp(options)
+	app.windowWidth, app.windowHeight = 60, 20
+	main := NewMain(app, options)
+	app.main = main
+	app.page = &notificationMouseSpyPage{}
+	var ran []string
+	for _, id := range []string{"open", "zoom"} {
+		app.RegisterCommand(id, func(_ *App, item ContextMenuItem) (Page, tea.Cmd) {
+			ran = append(ran, item.ID)
+			return nil, nil
+		})
+	}
+
+	row := ansi.Strip(strings.Split(main.View(app), "\n")[0])
+	if !strings.HasPrefix(row, " File  View ") || !strings.HasSuffix(row, " Player ") {
+		t.Fatalf("menu bar row = %q", row)
+	}
+
+	// F10 focuses the bar; right + enter opens View, left switches to File.
+	for _, key := range []tea.Key{{Code: tea.KeyF10}, {Code: tea.KeyRight}, {Code: tea.KeyEnter}} {
+		main.Update(tea.KeyPressMsg(key), app)
+	}
+	if !app.HasPopup() || main.menuBar.focused != 1 {
+		t.Fatalf("enter should open the View dropdown, focused %d", main.menuBar.focused)
+	}
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyLeft}))
+	cm := app.modalStack[len(app.modalStack)-1].(*ContextMenu)
+	if len(app.modalStack) != 1 || main.menuBar.focused != 0 || cm.items[0].ID != "open" || cm.focused != 0 {
+		t.Fatalf("left should switch to the File dropdown, focused %d", main.menuBar.focused)
+	}
+	app.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	if app.HasPopup() || main.menuBar.focused != -1 || len(ran) != 1 || ran[0] != "open" {
+		t.Fatalf("selecting Open should run its command and leave the bar, ran %v", ran)
+	}
+
+	// alt+v opens View by its mnemonic; hovering File switches dropdowns.
+	main.Update(tea.KeyPressMsg(tea.Key{Code: 'v', Mod: tea.ModAlt}), app)
+	if !app.HasPopup() || main.menuBar.focused != 1 {
+		t.Fatal("alt+v should open the View dropdown")
+	}
+	app.Update(tea.MouseMotionMsg(tea.Mouse{X: 1, Y: 0}))
+	if cm := app.modalStack[len(app.modalStack)-1].(*ContextMenu); cm.items[0].ID != "open" || cm.focused != -1 {
+		t.Fatal("hovering File should switch to its dropdown without keyboard focus")
+	}
+	app.Update(tea.MouseClickMsg(tea.Mouse{X: 1, Y: 0, Button: tea.MouseLeft}))
+	if app.HasPopup() || main.menuBar.focused != -1 {
+		t.Fatal("clicking the open title should close its dropdown")
+	}
+}
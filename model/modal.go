@@ -12,12 +12,14 @@ import tea "charm.land/bubbletea/v2"
 //
 // To show custom modal content, use Popup (see NewPopup, NewMarkdownPopup)
 // rather than implementing Modal directly. Popup already supports arbitrary
-// content including markdown, scrollable text, forms, and action buttons.
+// content including markdown, scrollable text, action buttons and interactive
+// widgets such as forms (see PopupSpec.Body). Overlays that are not dialogs
+// implement the public CustomModal instead and are pushed with App.PushModal.
 //
-// Only Popup and ContextMenu implement this interface.
+// Only Popup, ContextMenu and the CustomModal adapter implement this interface.
 type Modal interface {
-	// update handles keyboard input
-	update(msg tea.Msg)
+	// update handles keyboard input and returns any command it produces.
+	update(msg tea.Msg) tea.Cmd
 
 	// handleMouse handles mouse events. Returns (handled, cmd).
 	// If handled=true, the modal consumed the event.
@@ -0,0 +1,312 @@
+package model
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/anhoder/foxful-cli/style"
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/fogleman/ease"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// ModalAnimation selects the open/close transition of popups, context menus
+// and custom modals.
+type ModalAnimation uint8
+
+const (
+	// ModalAnimationDefault uses ModalAnimationOptions.Animation; as the
+	// global setting it means no animation.
+	ModalAnimationDefault ModalAnimation = iota
+	// ModalAnimationNone shows and hides the modal instantly.
+	ModalAnimationNone
+	// ModalAnimationFade blends the content in from (and out to) the popup
+	// surface color.
+	ModalAnimationFade
+	// ModalAnimationScale grows the modal from its anchor point.
+	ModalAnimationScale
+	// ModalAnimationSlide reveals the modal from its anchor edge: downwards
+	// for top-anchored popups and context menus, upwards otherwise.
+	ModalAnimationSlide
+)
+
+// ModalAnimationOptions configures modal transitions globally. Transitions are
+// skipped with StartupOptions.ReducedMotion or in accessible mode.
+type ModalAnimationOptions struct {
+	Animation ModalAnimation // Default none; PopupSpec.Animation overrides it per popup.
+	Duration  time.Duration  // Length of each transition. Default 150ms.
+}
+
+const (
+	defaultModalAnimationDuration = 150 * time.Millisecond
+	modalAnimationFrame           = time.Second / 60
+)
+
+// modalAnimationTickMsg advances running modal transitions.
+type modalAnimationTickMsg struct{}
+
+// modalTransition tracks the transition of one modal. The last rendered layer
+// is kept so the close transition can still be drawn after the modal has left
+// the stack.
+type modalTransition struct {
+	kind    ModalAnimation
+	anchor  PopupAnchor
+	start   time.Time
+	closing bool
+
+	content string
+	x, y    int
+}
+
+// modalAnimationFor returns the transition used for m, or ModalAnimationNone.
+func (a *App) modalAnimationFor(m Modal) ModalAnimation {
+	if a.options == nil || a.options.StartupOptions.ReducedMotion || style.AccessibleMode() {
+		return ModalAnimationNone
+	}
+	kind := a.options.ModalAnimationOptions.Animation
+	if p, ok := m.(*Popup); ok && p.animation != ModalAnimationDefault {
+		kind = p.animation
+	}
+	if kind == ModalAnimationDefault {
+		return ModalAnimationNone
+	}
+	return kind
+}
+
+func (a *App) modalAnimationDuration() time.Duration {
+	if d := a.options.ModalAnimationOptions.Duration; d > 0 {
+		return d
+	}
+	return defaultModalAnimationDuration
+}
+
+// startModalOpen starts the open transition of a modal just pushed.
+func (a *App) startModalOpen(m Modal) {
+	kind := a.modalAnimationFor(m)
+	if kind == ModalAnimationNone {
+		return
+	}
+	anchor := AnchorCustom
+	if p, ok := m.(*Popup); ok {
+		anchor = p.anchor
+	} else if c, ok := m.(*customModal); ok {
+		anchor, _, _ = c.m.Placement()
+	}
+	if a.modalTransitions == nil {
+		a.modalTransitions = make(map[Modal]*modalTransition)
+	}
+	a.modalTransitions[m] = &modalTransition{kind: kind, anchor: anchor, start: time.Now()}
+}
+
+// startModalClose turns the transition of a modal leaving the stack into a
+// close transition of its last rendered layer.
+func (a *App) startModalClose(m Modal) {
+	tr, ok := a.modalTransitions[m]
+	if !ok {
+		return
+	}
+	delete(a.modalTransitions, m)
+	if tr.content == "" {
+		return // never rendered
+	}
+	tr.closing = true
+	tr.start = time.Now()
+	a.closingModals = append(a.closingModals, tr)
+}
+
+// modalOpening reports whether m is still running its open transition; mouse
+// hit-testing is disabled meanwhile.
+func (a *App) modalOpening(m Modal) bool {
+	tr, ok := a.modalTransitions[m]
+	return ok && a.transitionProgress(tr) < 1
+}
+
+// transitionProgress returns how far tr has run, in [0, 1].
+func (a *App) transitionProgress(tr *modalTransition) float64 {
+	return min(1, float64(time.Since(tr.start))/float64(a.modalAnimationDuration()))
+}
+
+// visibility returns how visible the modal is at progress t: eased in while
+// opening and eased out while closing.
+func (tr *modalTransition) visibility(t float64) float64 {
+	if tr.closing {
+		return 1 - ease.InCubic(t)
+	}
+	return ease.OutCubic(t)
+}
+
+// modalAnimating reports whether any transition is still running.
+func (a *App) modalAnimating() bool {
+	if len(a.closingModals) > 0 {
+		return true
+	}
+	for _, tr := range a.modalTransitions {
+		if a.transitionProgress(tr) < 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// modalAnimationCmd schedules the next animation frame while a transition is
+// running and no frame is pending.
+func (a *App) modalAnimationCmd() tea.Cmd {
+	if a.modalAnimationTicking || !a.modalAnimating() {
+		return nil
+	}
+	a.modalAnimationTicking = true
+	return tea.Tick(modalAnimationFrame, func(time.Time) tea.Msg { return modalAnimationTickMsg{} })
+}
+
+// handleModalAnimationTick drops finished close transitions; the frame itself
+// is drawn by the render following every Update.
+func (a *App) handleModalAnimationTick() {
+	a.modalAnimationTicking = false
+	kept := a.closingModals[:0]
+	for _, tr := range a.closingModals {
+		if a.transitionProgress(tr) < 1 {
+			kept = append(kept, tr)
+		}
+	}
+	a.closingModals = kept
+}
+
+// animateModalLayer records the rendered layer of m and applies its running
+// open transition, if any.
+func (a *App) animateModalLayer(m Modal, content string, x, y int, surface color.Color) (string, int, int) {
+	tr, ok := a.modalTransitions[m]
+	if !ok {
+		return content, x, y
+	}
+	tr.content, tr.x, tr.y = content, x, y
+	t := a.transitionProgress(tr)
+	if t >= 1 {
+		return content, x, y
+	}
+	return tr.apply(tr.visibility(t), surface)
+}
+
+// apply renders the stored layer at visibility v in [0, 1].
+func (tr *modalTransition) apply(v float64, surface color.Color) (string, int, int) {
+	switch tr.kind {
+	case ModalAnimationFade:
+		return fadeLayer(tr.content, surface, v), tr.x, tr.y
+	case ModalAnimationScale:
+		content, dx, dy := scaleLayer(tr.content, tr.anchor, v)
+		return content, tr.x + dx, tr.y + dy
+	case ModalAnimationSlide:
+		content, dy := slideLayer(tr.content, tr.anchor, v)
+		return content, tr.x, tr.y + dy
+	}
+	return tr.content, tr.x, tr.y
+}
+
+// fadeLayer blends every cell's colors toward surface; v = 0 leaves only the
+// surface.
+func fadeLayer(content string, surface color.Color, v float64) string {
+	if surface == nil {
+		return content
+	}
+	target, ok := colorful.MakeColor(surface)
+	if !ok {
+		return content
+	}
+	blend := func(c color.Color) color.Color {
+		if c == nil {
+			return c
+		}
+		base, ok := colorful.MakeColor(c)
+		if !ok {
+			return c
+		}
+		return base.BlendLab(target, 1-v).Clamped()
+	}
+	screen := popupStyledScreen(content)
+	for y := range screen.Lines {
+		for x := range screen.Lines[y] {
+			cell := screen.CellAt(x, y)
+			if cell == nil || cell.IsZero() {
+				continue
+			}
+			cell.Style.Fg = blend(cell.Style.Fg)
+			cell.Style.Bg = blend(cell.Style.Bg)
+		}
+	}
+	return screen.Render()
+}
+
+// scaleLayer samples the layer down to v of its size (nearest neighbour, so
+// the frame edges are kept) and returns the offset placing it at the anchor.
+func scaleLayer(content string, anchor PopupAnchor, v float64) (string, int, int) {
+	src := popupStyledScreen(content)
+	w, h := src.Width(), src.Height()
+	sw := max(1, int(math.Round(float64(w)*v)))
+	sh := max(1, int(math.Round(float64(h)*v)))
+	dst := uv.NewScreenBuffer(sw, sh)
+	dst.Method = ansi.GraphemeWidth
+	for y := range sh {
+		sy := scaleIndex(y, sh, h)
+		for x := range sw {
+			cell := src.CellAt(scaleIndex(x, sw, w), sy)
+			if cell == nil || cell.Width != 1 {
+				// Wide graphemes can't be split; keep their style on a blank.
+				blank := &uv.Cell{Content: " ", Width: 1}
+				if cell != nil {
+					blank.Style = cell.Style
+				}
+				cell = blank
+			}
+			dst.SetCell(x, y, cell)
+		}
+	}
+	fx, fy := anchorFraction(anchor)
+	dx := int(math.Round(float64(w-sw) * fx))
+	dy := int(math.Round(float64(h-sh) * fy))
+	return dst.Render(), dx, dy
+}
+
+// scaleIndex maps i in [0, n) onto [0, size), keeping both ends.
+func scaleIndex(i, n, size int) int {
+	if n <= 1 {
+		return 0
+	}
+	return i * (size - 1) / (n - 1)
+}
+
+// anchorFraction returns the point of the modal that stays fixed while it
+// scales, as fractions of its width and height.
+func anchorFraction(anchor PopupAnchor) (float64, float64) {
+	switch anchor {
+	case AnchorTopLeft, AnchorCustom:
+		return 0, 0
+	case AnchorTopCenter:
+		return .5, 0
+	case AnchorTopRight:
+		return 1, 0
+	case AnchorBottomLeft:
+		return 0, 1
+	case AnchorBottomCenter:
+		return .5, 1
+	case AnchorBottomRight:
+		return 1, 1
+	default:
+		return .5, .5
+	}
+}
+
+// slideLayer reveals v of the layer's rows from its anchor edge and returns
+// the row offset of the visible part.
+func slideLayer(content string, anchor PopupAnchor, v float64) (string, int) {
+	lines := strings.Split(content, "\n")
+	hidden := len(lines) - max(1, int(math.Round(float64(len(lines))*v)))
+	if _, fy := anchorFraction(anchor); fy == 0 {
+		// Emerges downwards from the top edge: the bottom rows show first.
+		return strings.Join(lines[hidden:], "\n"), 0
+	}
+	// Emerges upwards from the bottom edge: the top rows show first.
+	return strings.Join(lines[:len(lines)-hidden], "\n"), hidden
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestModalTransitionFrames(t *testing.T) {
+	box := "┌────────┐\n│ hello  │\n│        │\n└────────┘"
+
+	scaled, dx, dy := scaleLayer(box, AnchorCenter, .5)
+	lines := strings.Split(ansi.Strip(scaled), "\n")
+	if len(lines) != 2 || lines[0] != "┌───┐" || lines[1] != "└───┘" || dx != 3 || dy != 1 {
+		t.Fatalf("half scale from center = %q at (%d, %d)", lines, dx, dy)
+	}
+	if _, dx, dy := scaleLayer(box, AnchorBottomRight, .5); dx != 5 || dy != 2 {
+		t.Fatalf("bottom-right anchor offset = (%d, %d), want (5, 2)", dx, dy)
+	}
+
+	down, dy := slideLayer(box, AnchorCustom, .5)
+	if down != "│        │\n└────────┘" || dy != 0 {
+		t.Fatalf("top-edge slide = %q, %d", down, dy)
+	}
+	up, dy := slideLayer(box, AnchorCenter, .25)
+	if up != "┌────────┐" || dy != 3 {
+		t.Fatalf("bottom-edge slide = %q, %d", up, dy)
+	}
+}
+
+func TestModalAnimationLifecycle(t *testing.T) {
+	accessible := style.AccessibleMode()
+	style.SetAccessibleMode(false)
+	defer style.SetAccessibleMode(accessible)
+
+	ops := DefaultOptions()
+	ops.ModalAnimationOptions = ModalAnimationOptions{Animation: ModalAnimationFade, Duration: time.Hour}
+	app := NewApp(ops)
+	app.page = &notificationMouseSpyPage{}
+	app.windowWidth, app.windowHeight = 40, 12
+	base := strings.TrimSuffix(strings.Repeat(strings.Repeat(" ", 40)+"\n", 12), "\n")
+
+	popup, _ := NewPopup(PopupSpec{Content: "hi", Actions: []PopupAction{{ID: "ok", Label: "OK"}}})
+	app.ShowPopup(popup)
+	app.compositeModals(base)
+	if !app.modalOpening(popup) {
+		t.Fatal("popup should be opening")
+	}
+	_, cmd := app.Update(tea.MouseClickMsg(tea.Mouse{X: popup.actionBounds[0].x, Y: popup.actionBounds[0].y, Button: tea.MouseLeft}))
+	if !app.HasPopup() || cmd == nil || !app.modalAnimationTicking {
+		t.Fatal("clicks are ignored while opening and a frame is scheduled")
+	}
+
+	app.modalTransitions[popup].start = time.Now().Add(-2 * time.Hour)
+	app.handleModalAnimationTick()
+	app.DismissPopup()
+	if len(app.closingModals) != 1 || !app.modalAnimating() {
+		t.Fatal("dismissing should start the close transition")
+	}
+	if ansi.Strip(app.compositeModals(base)) == ansi.Strip(base) {
+		t.Fatal("the closing popup should still be drawn")
+	}
+	app.closingModals[0].start = time.Now().Add(-2 * time.Hour)
+	app.handleModalAnimationTick()
+	if len(app.closingModals) != 0 || app.modalAnimating() {
+		t.Fatal("finished close transitions should be dropped")
+	}
+
+	// Per-popup override and reduced motion.
+	instant, _ := NewPopup(PopupSpec{Content: "x", Animation: ModalAnimationNone})
+	app.ShowPopup(instant)
+	if app.modalOpening(instant) {
+		t.Fatal("PopupSpec.Animation should override the global animation")
+	}
+	app.options.ReducedMotion = true
+	reduced, _ := NewPopup(PopupSpec{Content: "y", Animation: ModalAnimationScale})
+	app.ShowPopup(reduced)
+	if app.modalOpening(reduced) {
+		t.Fatal("reduced motion should disable transitions")
+	}
+}
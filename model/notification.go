@@ -32,11 +32,19 @@ type NotificationActionResult struct {
 	ActionID       string
 }
 
+// NotificationProgress is the progress bar drawn beneath a notification's
+// message. Percent is in [0, 1] and ignored while Indeterminate, in which case
+// the bar animates on every Options.Ticker tick.
+type NotificationProgress struct {
+	Percent       float64
+	Indeterminate bool
+}
+
 // NotificationSpec defines the content and behavior of a notification.
 // Message may contain ANSI-styled text. Title and action labels are plain,
 // single-line text. Timeout of 0 uses the configured default for Info/Success,
-// except notifications with actions remain visible for user interaction.
-// Warning/Error notifications with a zero Timeout also remain visible.
+// except notifications with actions or a progress bar remain visible for user
+// interaction. Warning/Error notifications with a zero Timeout also remain visible.
 type NotificationSpec struct {
 	Level    NotificationLevel
 	Title    string
@@ -44,10 +52,23 @@ type NotificationSpec struct {
 	Timeout  time.Duration
 	Actions  []NotificationAction
 	OnAction func(NotificationActionResult)
+	Progress *NotificationProgress // nil = no progress bar
+
+	// DedupKey coalesces live notifications: showing a notification whose key
+	// matches a visible one replaces its content, bumps its "×N" counter and
+	// restarts its timeout instead of stacking another copy.
+	DedupKey string
+	// Group collapses live notifications sharing the key into one summary
+	// listing them, with a "Show all" action that expands the group.
+	Group string
 }
 
 func cloneNotificationSpec(spec NotificationSpec) NotificationSpec {
 	spec.Actions = append([]NotificationAction(nil), spec.Actions...)
+	if spec.Progress != nil {
+		progress := *spec.Progress
+		spec.Progress = &progress
+	}
 	return spec
 }
 
@@ -67,8 +88,16 @@ type Notification struct {
 	createdAt time.Time
 	expireAt  time.Time // zero means no auto-expire
 
+	count   int             // occurrences coalesced via DedupKey; shown as "×N" when > 1
+	members []*Notification // collapsed notifications, for a group summary only
+
 	hoveredAction int
 	actionBounds  []notificationRect
+
+	// Keyboard focus, managed by the App. focusedAction is the index of the
+	// focused action, or -1 when the notification itself is focused.
+	focused       bool
+	focusedAction int
 	actionArea    notificationRect
 
 	bounds    notificationRect
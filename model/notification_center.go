@@ -0,0 +1,519 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// defaultNotificationHistorySize is used when NotificationOptions.HistorySize is 0.
+const defaultNotificationHistorySize = 100
+
+// NotificationRecord is a notification as kept in the history after its toast
+// has expired or been dismissed.
+type NotificationRecord struct {
+	ID      NotificationID
+	Level   NotificationLevel
+	Title   string
+	Message string
+	Actions []NotificationAction
+	Time    time.Time // when the notification was shown
+	Read    bool      // seen in the notification center or dismissed by the user
+}
+
+// notificationRecord is a history entry; it keeps OnAction so actions stay
+// re-runnable from the notification center.
+type notificationRecord struct {
+	NotificationRecord
+	onAction func(NotificationActionResult)
+}
+
+// notificationHistory is a fixed-capacity ring buffer of notification records,
+// oldest first. The zero value with a nil buffer records nothing.
+type notificationHistory struct {
+	records []*notificationRecord
+	start   int
+	size    int
+}
+
+func newNotificationHistory(capacity int) notificationHistory {
+	return notificationHistory{records: make([]*notificationRecord, max(capacity, 0))}
+}
+
+// push appends r, overwriting the oldest record once the buffer is full.
+func (h *notificationHistory) push(r *notificationRecord) {
+	n := len(h.records)
+	if n == 0 {
+		return
+	}
+	if h.size < n {
+		h.records[(h.start+h.size)%n] = r
+		h.size++
+		return
+	}
+	h.records[h.start] = r
+	h.start = (h.start + 1) % n
+}
+
+// at returns the i-th oldest record.
+func (h *notificationHistory) at(i int) *notificationRecord {
+	return h.records[(h.start+i)%len(h.records)]
+}
+
+func (h *notificationHistory) find(id NotificationID) *notificationRecord {
+	for i := range h.size {
+		if r := h.at(i); r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+func (h *notificationHistory) clear() {
+	clear(h.records)
+	h.start, h.size = 0, 0
+}
+
+// recordNotification adds a freshly shown notification to the history.
+func (a *App) recordNotification(id NotificationID, spec NotificationSpec, shownAt time.Time) {
+	if a.notificationHistory.records == nil {
+		size := a.options.NotificationOptions.HistorySize
+		if size == 0 {
+			size = defaultNotificationHistorySize
+		}
+		a.notificationHistory = newNotificationHistory(size)
+	}
+	a.notificationHistory.push(&notificationRecord{
+		NotificationRecord: NotificationRecord{
+			ID:      id,
+			Level:   spec.Level,
+			Title:   spec.Title,
+			Message: spec.Message,
+			Actions: spec.Actions,
+			Time:    shownAt,
+		},
+		onAction: spec.OnAction,
+	})
+}
+
+// updateNotificationRecord mirrors UpdateNotification into the history.
+func (a *App) updateNotificationRecord(id NotificationID, spec NotificationSpec) {
+	if r := a.notificationHistory.find(id); r != nil {
+		r.Level, r.Title, r.Message = spec.Level, spec.Title, spec.Message
+		r.Actions, r.onAction = spec.Actions, spec.OnAction
+	}
+}
+
+// markNotificationRead marks a notification the user dismissed as read.
+func (a *App) markNotificationRead(id NotificationID) {
+	if r := a.notificationHistory.find(id); r != nil {
+		r.Read = true
+	}
+}
+
+// NotificationHistory returns the recorded notifications, oldest first. The
+// history keeps up to NotificationOptions.HistorySize entries. Must be called
+// from the UI goroutine.
+func (a *App) NotificationHistory() []NotificationRecord {
+	records := make([]NotificationRecord, a.notificationHistory.size)
+	for i := range records {
+		records[i] = a.notificationHistory.at(i).NotificationRecord
+		records[i].Actions = append([]NotificationAction(nil), records[i].Actions...)
+	}
+	return records
+}
+
+// UnreadNotificationCount returns how many recorded notifications have not
+// been seen in the notification center or dismissed by the user.
+func (a *App) UnreadNotificationCount() int {
+	n := 0
+	for i := range a.notificationHistory.size {
+		if !a.notificationHistory.at(i).Read {
+			n++
+		}
+	}
+	return n
+}
+
+// ClearNotificationHistory empties the notification history. Visible toasts
+// are not affected; use ClearAllNotifications for those.
+func (a *App) ClearNotificationHistory() {
+	a.notificationHistory.clear()
+}
+
+// notificationCenterFilterAll shows every level in the notification center.
+const notificationCenterFilterAll = -1
+
+// Action IDs of the notification center popup.
+const (
+	notificationCenterClear = "clear"
+	notificationCenterClose = "close"
+)
+
+// notificationCenterFilters are the level filters of the segment row.
+var notificationCenterFilters = []struct {
+	level int
+	label MessageID
+}{
+	{notificationCenterFilterAll, MsgAll},
+	{int(NotificationInfo), MsgLevelInfo},
+	{int(NotificationSuccess), MsgLevelSuccess},
+	{int(NotificationWarning), MsgLevelWarning},
+	{int(NotificationError), MsgLevelError},
+}
+
+// ShowNotificationCenter opens a popup listing past notifications, newest
+// first, and marks them all read. A segment row at the top filters by level
+// (left/right or click); the actions of listed notifications are buttons that
+// re-run them (up/down to pick, enter or click to run). The action row clears
+// the history or closes the center. Must be called from the UI goroutine.
+func (a *App) ShowNotificationCenter() {
+	for i := range a.notificationHistory.size {
+		a.notificationHistory.at(i).Read = true
+	}
+	body := newNotificationCenterBody(a)
+	popup, err := NewPopup(PopupSpec{
+		Title: T(MsgNotifications),
+		Body:  body,
+		Actions: []PopupAction{
+			{ID: notificationCenterClear, Label: T(MsgClearAll)},
+			{ID: notificationCenterClose, Label: T(MsgClose), IsCancel: true},
+		},
+		MaxWidth: 72,
+		OnResult: func(result PopupResult) {
+			if result.ActionID == notificationCenterClear {
+				a.ClearNotificationHistory()
+			}
+		},
+	})
+	if err != nil {
+		return
+	}
+	a.ShowPopup(popup)
+}
+
+// notificationCenterRun is a re-run button of the notification center: the
+// action-th action of record, placed at line (relative to the list) and
+// columns [x, x+width).
+type notificationCenterRun struct {
+	record   *notificationRecord
+	action   int
+	line     int
+	x, width int
+}
+
+// notificationCenterBody is the PopupBody of the notification center: the
+// filter segment row above the scrollable list of notifications.
+type notificationCenterBody struct {
+	app    *App
+	filter int
+	cursor int // index into runs
+	offset int // first visible list line
+	width  int
+	height int
+
+	// Rebuilt by layout. A line holding re-run buttons is empty in lines and
+	// rendered from runs.
+	lines    []string
+	runs     []notificationCenterRun
+	segments []int // end column of each filter segment
+}
+
+func newNotificationCenterBody(a *App) *notificationCenterBody {
+	return &notificationCenterBody{app: a, filter: notificationCenterFilterAll}
+}
+
+// records returns the listed records, newest first.
+func (b *notificationCenterBody) records() []*notificationRecord {
+	h := &b.app.notificationHistory
+	var records []*notificationRecord
+	for i := h.size - 1; i >= 0; i-- {
+		if r := h.at(i); b.filter == notificationCenterFilterAll || int(r.Level) == b.filter {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// layout renders the list lines and places the re-run buttons, wrapping them
+// to the body width.
+func (b *notificationCenterBody) layout() {
+	ss := b.app.StyleSet()
+	records := b.records()
+	b.lines, b.runs = b.lines[:0], b.runs[:0]
+	if len(records) == 0 {
+		b.lines = append(b.lines, T(MsgNoNotifications))
+	}
+	for n, r := range records {
+		if n > 0 {
+			b.lines = append(b.lines, "")
+		}
+		b.lines = append(b.lines, strings.Split(renderNotificationRecord(ss, r), "\n")...)
+		if r.onAction == nil || len(r.Actions) == 0 {
+			continue
+		}
+		b.lines = append(b.lines, "")
+		x := 2
+		for i := range r.Actions {
+			w := lipgloss.Width(notificationCenterButton(r, i, b.width))
+			if x > 2 && x+1+w > b.width {
+				b.lines = append(b.lines, "")
+				x = 2
+			}
+			if x > 2 {
+				x++
+			}
+			b.runs = append(b.runs, notificationCenterRun{record: r, action: i, line: len(b.lines) - 1, x: x, width: w})
+			x += w
+		}
+	}
+	b.cursor = clampInt(b.cursor, 0, max(0, len(b.runs)-1))
+	b.scrollTo(b.offset)
+}
+
+// rows returns how many list lines fit below the segment row.
+func (b *notificationCenterBody) rows() int {
+	return max(1, b.height-2)
+}
+
+func (b *notificationCenterBody) scrollTo(offset int) {
+	b.offset = clampInt(offset, 0, max(0, len(b.lines)-b.rows()))
+}
+
+// setFilter switches the level filter, keeping the list at the top.
+func (b *notificationCenterBody) setFilter(filter int) {
+	b.filter, b.cursor, b.offset = filter, 0, 0
+	b.layout()
+}
+
+// stepFilter moves the filter by delta segments, wrapping around.
+func (b *notificationCenterBody) stepFilter(delta int) {
+	n := len(notificationCenterFilters)
+	for i, f := range notificationCenterFilters {
+		if f.level == b.filter {
+			b.setFilter(notificationCenterFilters[(i+delta+n)%n].level)
+			return
+		}
+	}
+}
+
+// moveCursor moves the selected re-run button and scrolls it into view.
+// Without buttons it scrolls the list.
+func (b *notificationCenterBody) moveCursor(delta int) {
+	if len(b.runs) == 0 {
+		b.scrollTo(b.offset + delta)
+		return
+	}
+	b.cursor = clampInt(b.cursor+delta, 0, len(b.runs)-1)
+	if line := b.runs[b.cursor].line; line < b.offset {
+		b.scrollTo(line)
+	} else if line >= b.offset+b.rows() {
+		b.scrollTo(line - b.rows() + 1)
+	}
+}
+
+// run re-runs the action of the i-th button.
+func (b *notificationCenterBody) run(i int) {
+	if i < 0 || i >= len(b.runs) {
+		return
+	}
+	run := b.runs[i]
+	run.record.onAction(NotificationActionResult{NotificationID: run.record.ID, ActionID: run.record.Actions[run.action].ID})
+}
+
+func (b *notificationCenterBody) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "left", "shift+tab":
+			b.stepFilter(-1)
+		case "right", "tab":
+			b.stepFilter(1)
+		case "up":
+			b.moveCursor(-1)
+		case "down":
+			b.moveCursor(1)
+		case "pgup":
+			b.scrollTo(b.offset - b.rows())
+		case "pgdown", "pgdn":
+			b.scrollTo(b.offset + b.rows())
+		case "enter":
+			b.run(b.cursor)
+		}
+	case tea.MouseClickMsg:
+		if msg.Button != tea.MouseLeft {
+			return nil
+		}
+		if msg.Y == 0 {
+			for i, end := range b.segments {
+				if msg.X < end {
+					b.setFilter(notificationCenterFilters[i].level)
+					break
+				}
+			}
+			return nil
+		}
+		line := b.offset + msg.Y - 2
+		for i, run := range b.runs {
+			if run.line == line && msg.X >= run.x && msg.X < run.x+run.width {
+				b.cursor = i
+				b.run(i)
+				break
+			}
+		}
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			b.scrollTo(b.offset - 3)
+		case tea.MouseWheelDown:
+			b.scrollTo(b.offset + 3)
+		}
+	}
+	return nil
+}
+
+func (b *notificationCenterBody) View() string {
+	ss := b.app.StyleSet()
+	b.layout()
+
+	var row strings.Builder
+	b.segments = b.segments[:0]
+	x := 0
+	for _, f := range notificationCenterFilters {
+		label := " " + T(f.label) + " "
+		segmentStyle := ss.Muted
+		if f.level == b.filter {
+			segmentStyle = ss.SelectedItem
+		}
+		row.WriteString(segmentStyle.Render(label))
+		x += lipgloss.Width(label)
+		b.segments = append(b.segments, x)
+	}
+
+	end := min(len(b.lines), b.offset+b.rows())
+	lines := append([]string{row.String(), ""}, b.lines[b.offset:end]...)
+	for i, run := range b.runs {
+		if run.line < b.offset || run.line >= end {
+			continue
+		}
+		buttonStyle := ss.MenuItem
+		if i == b.cursor {
+			buttonStyle = ss.SelectedItem
+		}
+		line := &lines[run.line-b.offset+2]
+		*line += strings.Repeat(" ", run.x-lipgloss.Width(*line)) +
+			buttonStyle.Render(notificationCenterButton(run.record, run.action, b.width))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// notificationCenterButton returns the label of the re-run button for the
+// action-th action of r, truncated to fit width.
+func notificationCenterButton(r *notificationRecord, action, width int) string {
+	return "[" + ansi.Truncate(r.Actions[action].Label, max(1, width-4), "…") + "]"
+}
+
+func (b *notificationCenterBody) SetSize(width, height int) {
+	b.width, b.height = width, height
+	b.layout()
+}
+
+func (b *notificationCenterBody) Focus() {}
+
+// renderNotificationRecord renders r as a time-stamped title line in the
+// level color followed by the indented message.
+func renderNotificationRecord(ss style.StyleSet, r *notificationRecord) string {
+	header := ss.Muted.Render(r.Time.Format(time.TimeOnly)) + " " +
+		notificationLevelStyle(ss, r.Level).Render(notificationLevelIcon(ss.Notification, r.Level)+r.Title)
+	lines := []string{header}
+	for _, line := range strings.Split(r.Message, "\n") {
+		if line != "" {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// notificationLevelIcon returns the themed icon prefix of level.
+func notificationLevelIcon(styles style.NotificationStyleSet, level NotificationLevel) string {
+	switch level {
+	case NotificationSuccess:
+		return styles.SuccessIcon
+	case NotificationWarning:
+		return styles.WarningIcon
+	case NotificationError:
+		return styles.ErrorIcon
+	default:
+		return styles.InfoIcon
+	}
+}
+
+// notificationLevelStyle returns the bold themed foreground style of level.
+func notificationLevelStyle(ss style.StyleSet, level NotificationLevel) lipgloss.Style {
+	switch level {
+	case NotificationSuccess:
+		return ss.Success.Bold(true)
+	case NotificationWarning:
+		return ss.Warning.Bold(true)
+	case NotificationError:
+		return ss.Error.Bold(true)
+	default:
+		return ss.Info.Bold(true)
+	}
+}
+
+// notificationCenterGlyph prefixes the NotificationCenterStatusBarComponent.
+const notificationCenterGlyph = "✉"
+
+// NotificationCenterStatusBarComponent is a DefaultStatusBar segment showing
+// the number of unread notifications. It renders nothing until the first
+// notification, sits in the right zone and opens the notification center when
+// clicked. The zero value is ready to use.
+type NotificationCenterStatusBarComponent struct {
+	app   *App
+	width int
+}
+
+var (
+	_ InteractiveStatusBarComponent = (*NotificationCenterStatusBarComponent)(nil)
+	_ ZonedStatusBarComponent       = (*NotificationCenterStatusBarComponent)(nil)
+)
+
+// View implements StatusBarComponent.
+func (c *NotificationCenterStatusBarComponent) View(a *App, _ *Main) string {
+	c.app = a
+	view := ""
+	if a.notificationHistory.size > 0 {
+		view = notificationCenterGlyph
+		if unread := a.UnreadNotificationCount(); unread > 0 {
+			view += " " + strconv.Itoa(unread)
+		}
+	}
+	c.width = lipgloss.Width(view)
+	return view
+}
+
+// Layout implements ZonedStatusBarComponent.
+func (c *NotificationCenterStatusBarComponent) Layout() StatusBarSegmentLayout {
+	return StatusBarSegmentLayout{Zone: StatusBarZoneRight, Priority: 4}
+}
+
+// HandleMouse implements InteractiveStatusBarComponent: a left click opens
+// the notification center.
+func (c *NotificationCenterStatusBarComponent) HandleMouse(mouse tea.Mouse, x, y int) (bool, tea.Cmd) {
+	if mouse.Button != tea.MouseLeft || c.app == nil || !c.IsMouseOver(x, y) {
+		return false, nil
+	}
+	c.app.ShowNotificationCenter()
+	return true, c.app.RerenderCmd(true)
+}
+
+// IsMouseOver implements InteractiveStatusBarComponent.
+func (c *NotificationCenterStatusBarComponent) IsMouseOver(x, _ int) bool {
+	return x >= 0 && x < c.width
+}
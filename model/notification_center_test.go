@@ -0,0 +1,125 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestNotificationHistoryRingAndUnreadCount(t *testing.T) {
+	ops := DefaultOptions()
+	ops.NotificationOptions.HistorySize = 3
+	app := NewApp(ops)
+
+	for _, title := range []string{"one", "two", "three", "four"} {
+		app.handleShowNotification(NotificationSpec{Level: NotificationError, Title: title})
+	}
+	// Expired toasts stay in the history.
+	app.notifications = nil
+
+	history := app.NotificationHistory()
+	if len(history) != 3 || history[0].Title != "two" || history[2].Title != "four" {
+		t.Fatalf("history = %+v, want the 3 newest oldest first", history)
+	}
+	if got := app.UnreadNotificationCount(); got != 3 {
+		t.Fatalf("unread = %d, want 3", got)
+	}
+
+	component := &NotificationCenterStatusBarComponent{}
+	if got := component.View(app, nil); got != notificationCenterGlyph+" 3" {
+		t.Fatalf("component View = %q", got)
+	}
+	handled, _ := component.HandleMouse(tea.Mouse{Button: tea.MouseLeft}, 0, 0)
+	if !handled || len(app.modalStack) != 1 {
+		t.Fatal("clicking the component did not open the notification center")
+	}
+	if got := app.UnreadNotificationCount(); got != 0 {
+		t.Fatalf("unread after opening center = %d, want 0", got)
+	}
+	if got := component.View(app, nil); got != notificationCenterGlyph {
+		t.Fatalf("component View with nothing unread = %q", got)
+	}
+
+	ops2 := DefaultOptions()
+	ops2.NotificationOptions.HistorySize = -1
+	disabled := NewApp(ops2)
+	disabled.handleShowNotification(NotificationSpec{Title: "x"})
+	if len(disabled.NotificationHistory()) != 0 {
+		t.Fatal("negative HistorySize should disable the history")
+	}
+}
+
+func TestNotificationCenterFilterRerunAndClear(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	var ran []NotificationActionResult
+	app.handleShowNotification(NotificationSpec{Level: NotificationInfo, Title: "Saved", Message: "All good"})
+	app.handleShowNotification(NotificationSpec{
+		Level:    NotificationError,
+		Title:    "Upload failed",
+		Message:  "Network down",
+		Actions:  []NotificationAction{{ID: "retry", Label: "Retry"}},
+		OnAction: func(r NotificationActionResult) { ran = append(ran, r) },
+	})
+	app.notifications = nil
+
+	app.ShowNotificationCenter()
+	popup := app.modalStack[0].(*Popup)
+	if len(popup.actions) != 2 || popup.actions[0].ID != notificationCenterClear || popup.actions[1].ID != notificationCenterClose {
+		t.Fatalf("action row should hold clear and close only, got %+v", popup.actions)
+	}
+	body := popup.body.(*notificationCenterBody)
+	body.SetSize(60, 20)
+	view := ansi.Strip(body.View())
+	if !strings.Contains(view, "Saved") || !strings.Contains(view, "Network down") || !strings.Contains(view, T(MsgLevelWarning)) {
+		t.Fatalf("center view = %q", view)
+	}
+	if strings.Index(view, "Upload failed") > strings.Index(view, "Saved") {
+		t.Fatal("center should list newest notifications first")
+	}
+
+	// Filters are a segment row: errors only, by key and by click.
+	for range 4 {
+		popup.update(keyMsg("right"))
+	}
+	if view := ansi.Strip(body.View()); strings.Contains(view, "Saved") || !strings.Contains(view, "Upload failed") {
+		t.Fatalf("filtered view = %q", view)
+	}
+	body.Update(tea.MouseClickMsg(tea.Mouse{X: 1, Y: 0, Button: tea.MouseLeft}))
+	if body.filter != notificationCenterFilterAll {
+		t.Fatalf("clicking the first segment should show all, filter = %d", body.filter)
+	}
+
+	// Re-run buttons run the action and keep the center open.
+	popup.update(keyMsg("enter"))
+	run := body.runs[0]
+	body.Update(tea.MouseClickMsg(tea.Mouse{X: run.x, Y: run.line - body.offset + 2, Button: tea.MouseLeft}))
+	if len(ran) != 2 || ran[0].ActionID != "retry" || ran[0].NotificationID != 2 || popup.dismissed() {
+		t.Fatalf("re-run action results = %+v", ran)
+	}
+
+	popup.dismissAction(0, PopupDismissAction)
+	app.completeTopModal()
+	if len(app.NotificationHistory()) != 0 || len(app.modalStack) != 0 {
+		t.Fatal("clear all should empty the history and close the center")
+	}
+}
+
+func TestNotificationCenterScrollsWithoutButtons(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	for i := range 8 {
+		app.handleShowNotification(NotificationSpec{Title: "note", Message: strings.Repeat("x", i+1)})
+	}
+	body := newNotificationCenterBody(app)
+	body.SetSize(40, 6)
+	body.Update(keyMsg("down"))
+	body.Update(keyMsg("down"))
+	if body.offset != 2 {
+		t.Fatalf("down should scroll a list without buttons, offset = %d", body.offset)
+	}
+	body.Update(keyMsg("up"))
+	if body.offset != 1 {
+		t.Fatalf("up should scroll back, offset = %d", body.offset)
+	}
+}
@@ -0,0 +1,230 @@
+package model
+
+import (
+	"encoding/base64"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// DesktopNotificationProtocol selects the escape sequence used to forward a
+// notification to the OS notification system through the terminal.
+type DesktopNotificationProtocol uint8
+
+const (
+	// DesktopNotifyOSC9 uses OSC 9 (iTerm2, WezTerm, Ghostty). Only a single
+	// line of text is shown: "Title: Message".
+	DesktopNotifyOSC9 DesktopNotificationProtocol = iota
+	// DesktopNotifyOSC777 uses OSC 777 (rxvt-unicode, foot, Ghostty) with a
+	// separate title and body.
+	DesktopNotifyOSC777
+	// DesktopNotifyOSC99 uses kitty's OSC 99 with title, body, urgency and the
+	// notification's actions as buttons. Clicking the notification focuses the
+	// terminal; clicking a button runs the action through OnAction.
+	DesktopNotifyOSC99
+)
+
+// DesktopNotificationOptions configures the opt-in bridge mirroring
+// notifications to the desktop. Notifications are only forwarded while the
+// terminal window is unfocused, which requires a terminal that supports focus
+// reporting; updates of an existing notification are never forwarded.
+//
+// Only DesktopNotifyOSC99 carries NotificationSpec.Actions. A button click is
+// reported back by kitty and handled like a click on the in-app action: the
+// notification is dismissed and OnAction is called. Once the notification is
+// gone, the action still runs from its notification center record.
+type DesktopNotificationOptions struct {
+	Enabled  bool
+	Protocol DesktopNotificationProtocol
+	Levels   []NotificationLevel // Levels to forward. Nil = all levels.
+}
+
+// forwards reports whether notifications of level are forwarded.
+func (o DesktopNotificationOptions) forwards(level NotificationLevel) bool {
+	return o.Enabled && (o.Levels == nil || slices.Contains(o.Levels, level))
+}
+
+// desktopNotificationCmd returns a Cmd emitting the desktop notification for
+// spec, or nil when the bridge is disabled, the level is filtered out, the
+// terminal has focus or the notification was written to App.desktopOutput.
+func (a *App) desktopNotificationCmd(id NotificationID, spec NotificationSpec) tea.Cmd {
+	opts := a.options.NotificationOptions.Desktop
+	if !a.terminalBlurred || !opts.forwards(spec.Level) {
+		return nil
+	}
+	seq := desktopNotificationSequence(opts.Protocol, id, spec)
+	if seq == "" {
+		return nil
+	}
+	if a.desktopOutput != nil {
+		_, _ = io.WriteString(a.desktopOutput, seq)
+		return nil
+	}
+	return tea.Raw(seq)
+}
+
+// desktopNotificationSequence formats spec for protocol. Returns "" when the
+// notification has neither title nor message.
+func desktopNotificationSequence(protocol DesktopNotificationProtocol, id NotificationID, spec NotificationSpec) string {
+	title := sanitizeDesktopText(spec.Title, false)
+	body := sanitizeDesktopText(spec.Message, protocol == DesktopNotifyOSC99)
+	if title == "" && body == "" {
+		return ""
+	}
+
+	switch protocol {
+	case DesktopNotifyOSC777:
+		if title == "" {
+			title, body = body, ""
+		}
+		// Fields are ';'-separated without escaping; only the body, being
+		// last, may contain them.
+		return "\x1b]777;notify;" + strings.ReplaceAll(title, ";", ",") + ";" + body + "\x07"
+
+	case DesktopNotifyOSC99:
+		return kittyNotificationSequence(id, spec.Level, title, body, spec.Actions)
+
+	default:
+		text := title
+		if title != "" && body != "" {
+			text += ": "
+		}
+		return ansi.Notify(text + body)
+	}
+}
+
+// kittyNotificationSequence builds the chunks of an OSC 99 notification. All
+// payloads are base64 encoded (e=1) so arbitrary text is safe; d=0 marks
+// every chunk but the last. Actions become buttons and ask kitty to report
+// clicks (a=report), see handleKittyNotificationReport.
+func kittyNotificationSequence(id NotificationID, level NotificationLevel, title, body string, actions []NotificationAction) string {
+	type chunk struct{ kind, payload string }
+	var chunks []chunk
+	if title != "" {
+		chunks = append(chunks, chunk{"title", title})
+	}
+	if body != "" {
+		chunks = append(chunks, chunk{"body", body})
+	}
+	activation := "a=focus"
+	if len(actions) > 0 {
+		labels := make([]string, len(actions))
+		for i, action := range actions {
+			labels[i] = sanitizeDesktopText(action.Label, false)
+		}
+		// Buttons are separated by U+2028 (LINE SEPARATOR).
+		chunks = append(chunks, chunk{"buttons", strings.Join(labels, "\u2028")})
+		activation = "a=focus,report"
+	}
+
+	urgency := "1"
+	if level == NotificationError {
+		urgency = "2"
+	}
+	ident := "i=" + strconv.FormatUint(uint64(id), 10)
+
+	var b strings.Builder
+	for i, c := range chunks {
+		done := "0"
+		if i == len(chunks)-1 {
+			done = "1"
+		}
+		metadata := []string{ident, "d=" + done, "e=1", "p=" + c.kind}
+		if i == 0 {
+			metadata = append(metadata, activation, "u="+urgency)
+		}
+		b.WriteString(ansi.DesktopNotification(base64.StdEncoding.EncodeToString([]byte(c.payload)), metadata...))
+	}
+	return b.String()
+}
+
+// handleKittyNotificationReport runs the action of a button clicked in a
+// kitty desktop notification. kitty reports it as
+// "OSC 99 ; i=<id> ; <button> ST" with the 1-based button number, or 0/empty
+// when the notification itself was clicked. Reports that are not OSC 99
+// activations return handled == false.
+func (a *App) handleKittyNotificationReport(seq string) (handled bool, cmd tea.Cmd) {
+	id, button, ok := parseKittyNotificationReport(seq)
+	if !ok {
+		return false, nil
+	}
+	var (
+		actions  []NotificationAction
+		onAction func(NotificationActionResult)
+	)
+	n, _ := a.findLiveNotification(id)
+	if n != nil {
+		actions, onAction = n.spec.Actions, n.spec.OnAction
+	} else if r := a.notificationHistory.find(id); r != nil {
+		actions, onAction = r.Actions, r.onAction
+	}
+	if button < 1 || button > len(actions) {
+		return true, nil
+	}
+	result := NotificationActionResult{NotificationID: id, ActionID: actions[button-1].ID}
+	if n != nil {
+		if n.focused {
+			a.dismissFocusedNotification(n)
+		} else {
+			a.removeNotification(id)
+			a.markNotificationRead(id)
+		}
+		cmd = a.RerenderCmd(true)
+	}
+	if onAction != nil {
+		onAction(result)
+	}
+	return true, cmd
+}
+
+// parseKittyNotificationReport parses an OSC 99 activation report terminated
+// by ST or BEL. Other OSC 99 responses (e.g. p=close) are rejected.
+func parseKittyNotificationReport(seq string) (id NotificationID, button int, ok bool) {
+	rest, ok := strings.CutPrefix(seq, "\x1b]99;")
+	if !ok {
+		return 0, 0, false
+	}
+	rest = strings.TrimSuffix(strings.TrimSuffix(rest, "\x07"), "\x1b\\")
+	metadata, payload, _ := strings.Cut(rest, ";")
+	for _, kv := range strings.Split(metadata, ":") {
+		key, value, _ := strings.Cut(kv, "=")
+		switch key {
+		case "i":
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return 0, 0, false
+			}
+			id = NotificationID(n)
+		case "p":
+			return 0, 0, false
+		}
+	}
+	if payload != "" {
+		var err error
+		if button, err = strconv.Atoi(payload); err != nil {
+			return 0, 0, false
+		}
+	}
+	return id, button, id != 0
+}
+
+// sanitizeDesktopText strips ANSI styling and control characters, which
+// would terminate or corrupt the escape sequence. Newlines become spaces
+// unless keepNewlines is set (for base64 payloads).
+func sanitizeDesktopText(s string, keepNewlines bool) string {
+	s = ansi.Strip(s)
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' && keepNewlines:
+			return r
+		case r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0) || r == '\u2028':
+			return ' '
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
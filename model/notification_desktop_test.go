@@ -0,0 +1,119 @@
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+func TestDesktopNotificationsOnlyWhileBlurred(t *testing.T) {
+	var out bytes.Buffer
+	ops := DefaultOptions()
+	ops.NotificationOptions.Desktop = DesktopNotificationOptions{
+		Enabled: true,
+		Levels:  []NotificationLevel{NotificationError},
+	}
+	app := NewApp(ops)
+	app.desktopOutput = &out
+	app.page = &notificationMouseSpyPage{} // focus messages continue to the page
+
+	app.handleShowNotification(NotificationSpec{Level: NotificationError, Title: "Failed"})
+	if out.Len() != 0 {
+		t.Fatalf("focused terminal should not get desktop notifications, got %q", out.String())
+	}
+
+	app.Update(tea.BlurMsg{})
+	app.handleShowNotification(NotificationSpec{Level: NotificationInfo, Title: "Filtered"})
+	app.handleShowNotification(NotificationSpec{Level: NotificationError, Title: "Download failed", Message: "\x1b[31mtimeout\x1b[0m\n\x07"})
+	if got, want := out.String(), "\x1b]9;Download failed: timeout\x07"; got != want {
+		t.Fatalf("desktop output = %q, want %q", got, want)
+	}
+
+	out.Reset()
+	app.Update(tea.FocusMsg{})
+	app.handleShowNotification(NotificationSpec{Level: NotificationError, Title: "Again"})
+	if out.Len() != 0 {
+		t.Fatalf("refocused terminal should not get desktop notifications, got %q", out.String())
+	}
+}
+
+func TestDesktopNotificationSequences(t *testing.T) {
+	spec := NotificationSpec{
+		Level:   NotificationError,
+		Title:   "Sync; failed",
+		Message: "line one\nline two",
+		Actions: []NotificationAction{{ID: "retry", Label: "Retry"}, {ID: "log", Label: "Log"}},
+	}
+
+	if got, want := desktopNotificationSequence(DesktopNotifyOSC777, 1, spec),
+		"\x1b]777;notify;Sync, failed;line one line two\x07"; got != want {
+		t.Fatalf("OSC 777 = %q, want %q", got, want)
+	}
+
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	want := "\x1b]99;i=7:d=0:e=1:p=title:a=focus,report:u=2;" + b64("Sync; failed") + "\x07" +
+		"\x1b]99;i=7:d=0:e=1:p=body;" + b64("line one\nline two") + "\x07" +
+		"\x1b]99;i=7:d=1:e=1:p=buttons;" + b64("Retry\u2028Log") + "\x07"
+	if got := desktopNotificationSequence(DesktopNotifyOSC99, 7, spec); got != want {
+		t.Fatalf("OSC 99 = %q, want %q", got, want)
+	}
+
+	if got := desktopNotificationSequence(DesktopNotifyOSC9, 1, NotificationSpec{Message: "only body"}); !strings.HasSuffix(got, "9;only body\x07") {
+		t.Fatalf("OSC 9 without title = %q", got)
+	}
+	if got := desktopNotificationSequence(DesktopNotifyOSC9, 1, NotificationSpec{}); got != "" {
+		t.Fatalf("empty notification should produce no sequence, got %q", got)
+	}
+
+	app := NewApp(DefaultOptions())
+	if app.View().ReportFocus {
+		t.Fatal("focus reporting should stay off unless the desktop bridge is enabled")
+	}
+}
+
+func TestDesktopNotificationActionReports(t *testing.T) {
+	ops := DefaultOptions()
+	ops.NotificationOptions.Desktop = DesktopNotificationOptions{Enabled: true, Protocol: DesktopNotifyOSC99}
+	app := NewApp(ops)
+	app.page = &notificationMouseSpyPage{}
+
+	var results []NotificationActionResult
+	id, _ := app.showNotification(NotificationSpec{
+		Title:    "Sync failed",
+		Actions:  []NotificationAction{{ID: "retry", Label: "Retry"}, {ID: "log", Label: "Log"}},
+		OnAction: func(r NotificationActionResult) { results = append(results, r) },
+	})
+	report := func(payload, terminator string) {
+		app.Update(uv.UnknownOscEvent("\x1b]99;i=" + strconv.FormatUint(uint64(id), 10) + ";" + payload + terminator))
+	}
+
+	report("", "\x1b\\") // body click: focus only
+	report("3", "\x07")  // no such button
+	if len(results) != 0 || len(app.notifications) != 1 {
+		t.Fatalf("clicks without an action should do nothing, got %v", results)
+	}
+
+	report("2", "\x1b\\")
+	if want := []NotificationActionResult{{NotificationID: id, ActionID: "log"}}; !slices.Equal(results, want) {
+		t.Fatalf("results = %v, want %v", results, want)
+	}
+	if len(app.notifications) != 0 || app.UnreadNotificationCount() != 0 {
+		t.Fatal("a desktop button click should dismiss the notification and mark it read")
+	}
+
+	// Once the toast is gone the action still runs from the history record.
+	report("1", "\x07")
+	if len(results) != 2 || results[1].ActionID != "retry" {
+		t.Fatalf("results = %v, want a retry from the history record", results)
+	}
+
+	if handled, _ := app.handleKittyNotificationReport("\x1b]99;i=" + strconv.FormatUint(uint64(id), 10) + ":p=close;\x1b\\"); handled {
+		t.Fatal("close reports are not activations")
+	}
+}
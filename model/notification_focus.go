@@ -0,0 +1,157 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Keyboard focus for the notification stack. NotificationOptions.FocusKey moves
+// focus from the page or modal into the stack; while focused, up/down move
+// between notifications in screen order, left/right (or tab/shift+tab) between
+// the actions of the focused one, enter runs the focused action, delete (or
+// backspace) dismisses the notification and esc hands focus back. Other keys
+// are swallowed so they don't leak to the page underneath, except ctrl+c.
+
+// notificationFocusHold delays the expiry of a focused notification so it
+// doesn't vanish while the user is reading or choosing an action.
+const notificationFocusHold = time.Second
+
+// NotificationsFocused reports whether the notification stack has keyboard focus.
+func (a *App) NotificationsFocused() bool {
+	return a.focusedNotification() != nil
+}
+
+// FocusNotifications moves keyboard focus to the notification closest to the
+// anchor. Returns false when no notification is visible. Must be called from
+// the UI goroutine.
+func (a *App) FocusNotifications() bool {
+	if len(a.notifications) == 0 {
+		return false
+	}
+	// Notifications stack away from their anchor, so the newest is closest.
+	a.setNotificationFocus(a.notifications[len(a.notifications)-1], -1)
+	return true
+}
+
+// BlurNotifications returns keyboard focus to the page or modal.
+func (a *App) BlurNotifications() {
+	if n := a.focusedNotification(); n != nil {
+		n.focused = false
+	}
+	a.focusedNotificationID = 0
+}
+
+// focusedNotification returns the focused notification, dropping the focus
+// when that notification has gone away (expired, dismissed or cleared).
+func (a *App) focusedNotification() *Notification {
+	if a.focusedNotificationID == 0 {
+		return nil
+	}
+	for _, n := range a.notifications {
+		if n.id == a.focusedNotificationID {
+			return n
+		}
+	}
+	a.focusedNotificationID = 0
+	return nil
+}
+
+func (a *App) setNotificationFocus(n *Notification, action int) {
+	if previous := a.focusedNotification(); previous != nil {
+		previous.focused = false
+	}
+	n.focused = true
+	n.focusedAction = action
+	a.focusedNotificationID = n.id
+}
+
+// notificationsInScreenOrder returns the notifications from top to bottom as
+// last rendered. Notifications not placed yet follow them in stacking order.
+func (a *App) notificationsInScreenOrder() []*Notification {
+	ordered := slices.Clone(a.notifications)
+	slices.Reverse(ordered) // newest first, like the rendering order
+	slices.SortStableFunc(ordered, func(x, y *Notification) int {
+		switch {
+		case x.boundsSet && y.boundsSet:
+			return x.bounds.y - y.bounds.y
+		case x.boundsSet:
+			return -1
+		case y.boundsSet:
+			return 1
+		}
+		return 0
+	})
+	return ordered
+}
+
+// handleNotificationFocusKey handles a key press while the notification stack
+// has focus, or the focus key itself. handled is false for keys that should
+// continue to the page or modal.
+func (a *App) handleNotificationFocusKey(key string) (handled bool, cmd tea.Cmd) {
+	focused := a.focusedNotification()
+	if focused == nil {
+		focusKey := a.options.NotificationOptions.FocusKey
+		if focusKey == "" || key != focusKey || !a.FocusNotifications() {
+			return false, nil
+		}
+		return true, a.RerenderCmd(true)
+	}
+
+	switch key {
+	case "ctrl+c":
+		a.BlurNotifications()
+		return false, nil
+	case "esc", a.options.NotificationOptions.FocusKey:
+		a.BlurNotifications()
+	case "up", "down":
+		ordered := a.notificationsInScreenOrder()
+		i := slices.Index(ordered, focused)
+		if key == "up" {
+			i = (i - 1 + len(ordered)) % len(ordered)
+		} else {
+			i = (i + 1) % len(ordered)
+		}
+		a.setNotificationFocus(ordered[i], -1)
+	case "left", "right", "tab", "shift+tab":
+		// Cycle through the actions and the notification itself (-1).
+		n := len(focused.spec.Actions) + 1
+		step := 1
+		if key == "left" || key == "shift+tab" {
+			step = n - 1
+		}
+		focused.focusedAction = (clampInt(focused.focusedAction, -1, n-2)+1+step)%n - 1
+	case "enter":
+		action := focused.focusedAction
+		if action < 0 || action >= len(focused.spec.Actions) {
+			return true, nil
+		}
+		onAction := focused.spec.OnAction
+		result := NotificationActionResult{NotificationID: focused.id, ActionID: focused.spec.Actions[action].ID}
+		a.dismissFocusedNotification(focused)
+		if onAction != nil {
+			onAction(result)
+		}
+	case "delete", "backspace":
+		a.dismissFocusedNotification(focused)
+	default:
+		return true, nil
+	}
+	return true, a.RerenderCmd(true)
+}
+
+// dismissFocusedNotification removes the focused notification and moves the
+// focus to its neighbour below (or above), or back to the page when it was
+// the last one.
+func (a *App) dismissFocusedNotification(focused *Notification) {
+	ordered := a.notificationsInScreenOrder()
+	i := slices.Index(ordered, focused)
+	a.removeNotification(focused.id)
+	a.markNotificationRead(focused.id)
+	a.focusedNotificationID = 0
+	ordered = slices.Delete(ordered, i, i+1)
+	if len(ordered) > 0 {
+		a.setNotificationFocus(ordered[min(i, len(ordered)-1)], -1)
+	}
+}
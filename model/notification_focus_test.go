@@ -0,0 +1,109 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func pressKey(app *App, key tea.Key) {
+	app.Update(tea.KeyPressMsg(key))
+}
+
+func TestNotificationKeyboardFocusRunsAndDismisses(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	var ran []NotificationActionResult
+	app.handleShowNotification(NotificationSpec{Level: NotificationInfo, Title: "Older"})
+	app.handleShowNotification(NotificationSpec{
+		Level:    NotificationError,
+		Title:    "Download failed",
+		Actions:  []NotificationAction{{ID: "retry", Label: "Retry"}, {ID: "log", Label: "Log"}},
+		OnAction: func(r NotificationActionResult) { ran = append(ran, r) },
+	})
+
+	pressKey(app, tea.Key{Code: 'n', Mod: tea.ModAlt})
+	if !app.NotificationsFocused() || app.focusedNotificationID != 2 {
+		t.Fatalf("focus key should focus the newest notification, got %d", app.focusedNotificationID)
+	}
+
+	pressKey(app, tea.Key{Code: tea.KeyRight})
+	pressKey(app, tea.Key{Code: tea.KeyRight})
+	pressKey(app, tea.Key{Code: tea.KeyLeft})
+	if got := app.notifications[1].focusedAction; got != 0 {
+		t.Fatalf("focused action = %d, want 0 (Retry)", got)
+	}
+	pressKey(app, tea.Key{Code: tea.KeyEnter})
+	if len(ran) != 1 || ran[0].ActionID != "retry" || ran[0].NotificationID != 2 {
+		t.Fatalf("action results = %+v", ran)
+	}
+	if len(app.notifications) != 1 || app.focusedNotificationID != 1 {
+		t.Fatalf("after enter: %d notifications, focus %d; want the remaining one focused",
+			len(app.notifications), app.focusedNotificationID)
+	}
+
+	// Unrelated keys are swallowed while focused; esc gives focus back.
+	pressKey(app, tea.Key{Code: 'q', Text: "q"})
+	if app.quiting {
+		t.Fatal("q should not reach the quit handler while notifications have focus")
+	}
+	pressKey(app, tea.Key{Code: tea.KeyEscape})
+	if app.NotificationsFocused() {
+		t.Fatal("esc should return focus to the page")
+	}
+
+	app.FocusNotifications()
+	pressKey(app, tea.Key{Code: tea.KeyDelete})
+	if len(app.notifications) != 0 || app.NotificationsFocused() {
+		t.Fatal("delete should dismiss the last notification and drop focus")
+	}
+}
+
+func TestNotificationFocusRendersWithoutColor(t *testing.T) {
+	style.SetAccessibleMode(true)
+	defer style.SetAccessibleMode(false)
+
+	_, n, plain := renderNotificationForTest(NotificationSpec{
+		Title:   "Failed",
+		Actions: []NotificationAction{{ID: "retry", Label: "Retry"}},
+	})
+	n.focused = true
+	n.focusedAction = 0
+	app := NewApp(DefaultOptions())
+	focused := app.renderNotification(n, style.CurrentStyleSet().Notification, 40, 5, 20)
+
+	thick := lipgloss.ThickBorder().TopLeft
+	if strings.Contains(plain.content, thick) || !strings.Contains(focused.content, thick) {
+		t.Fatal("focused notification should use a thick border")
+	}
+	if plain.content == focused.content || !strings.Contains(ansi.Strip(focused.content), "Retry") {
+		t.Fatal("focused action should render differently")
+	}
+}
+
+func TestNotificationsInScreenOrderPutsUnrenderedLast(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	for _, title := range []string{"a", "b", "c", "d"} {
+		app.handleShowNotification(NotificationSpec{Title: title})
+	}
+	// Newest first: d, c, b, a. c is not rendered; b renders above d.
+	byTitle := map[string]*Notification{}
+	for _, n := range app.notifications {
+		n.clearBounds()
+		byTitle[n.spec.Title] = n
+	}
+	byTitle["d"].setBounds(0, 8, 10, 3, nil, notificationRect{})
+	byTitle["b"].setBounds(0, 2, 10, 3, nil, notificationRect{})
+	byTitle["a"].setBounds(0, 5, 10, 3, nil, notificationRect{})
+
+	var got []string
+	for _, n := range app.notificationsInScreenOrder() {
+		got = append(got, n.spec.Title)
+	}
+	if strings.Join(got, "") != "badc" {
+		t.Fatalf("screen order = %v, want rendered top to bottom, then unrendered", got)
+	}
+}
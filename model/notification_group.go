@@ -0,0 +1,238 @@
+package model
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/style"
+)
+
+// Deduplication, grouping and rate limiting keep a noisy component from
+// filling the screen: a repeated DedupKey bumps the "×N" counter of the live
+// notification, a second live notification of a Group collapses both into a
+// summary, and notifications over NotificationOptions.RateLimit are only
+// counted in the "+N more" overflow indicator (and kept in the history).
+
+// defaultNotificationRateWindow is used when NotificationOptions.RateWindow is 0.
+const defaultNotificationRateWindow = time.Second
+
+// notificationGroupExpandAction is the action ID of a group summary's "Show all".
+const notificationGroupExpandAction = "expand"
+
+// findLiveNotification returns the live notification with id and, when it is
+// collapsed into a group summary, that summary.
+func (a *App) findLiveNotification(id NotificationID) (n, summary *Notification) {
+	for _, top := range a.notifications {
+		if top.id == id {
+			return top, nil
+		}
+		for _, member := range top.members {
+			if member.id == id {
+				return member, top
+			}
+		}
+	}
+	return nil, nil
+}
+
+// findDedupNotification returns the live notification with key, if any.
+func (a *App) findDedupNotification(key string) (n, summary *Notification) {
+	for _, top := range a.notifications {
+		if top.members == nil && top.spec.DedupKey == key {
+			return top, nil
+		}
+		for _, member := range top.members {
+			if member.spec.DedupKey == key {
+				return member, top
+			}
+		}
+	}
+	return nil, nil
+}
+
+// coalesceNotification folds a repeat of n into it: the content is replaced,
+// the counter bumped and the timeout restarted.
+func (a *App) coalesceNotification(n, summary *Notification, spec NotificationSpec) tea.Cmd {
+	n.count++
+	n.spec = spec
+	n.resetInteraction()
+	a.updateNotificationRecord(n.id, spec)
+	if summary != nil {
+		a.refreshGroupSummary(summary)
+	}
+	return tea.Batch(a.RerenderCmd(true), a.scheduleNotificationExpiry(n, a.initialNotificationTimeout(spec)))
+}
+
+// notificationRateWait returns how long a new notification has to wait at now
+// for room in the rate window, or 0 when it may be shown right away.
+func (a *App) notificationRateWait(now time.Time) time.Duration {
+	opts := a.options.NotificationOptions
+	if opts.RateLimit <= 0 {
+		return 0
+	}
+	window := opts.RateWindow
+	if window <= 0 {
+		window = defaultNotificationRateWindow
+	}
+	a.notificationTimes = slices.DeleteFunc(a.notificationTimes, func(t time.Time) bool {
+		return now.Sub(t) >= window
+	})
+	if len(a.notificationTimes) < opts.RateLimit {
+		return 0
+	}
+	// Times are in order; room opens once all but RateLimit-1 have left.
+	return window - now.Sub(a.notificationTimes[len(a.notificationTimes)-opts.RateLimit])
+}
+
+// notificationRateLimited reports whether showing a notification at now would
+// exceed NotificationOptions.RateLimit, and counts it otherwise.
+func (a *App) notificationRateLimited(now time.Time) bool {
+	if a.notificationRateWait(now) > 0 {
+		return true
+	}
+	if a.options.NotificationOptions.RateLimit > 0 {
+		a.notificationTimes = append(a.notificationTimes, now)
+	}
+	return false
+}
+
+// resetOverflowWhenEmpty clears the overflow indicator once no notification
+// is left to show it next to.
+func (a *App) resetOverflowWhenEmpty() {
+	if len(a.notifications) == 0 {
+		a.notificationOverflow = 0
+	}
+}
+
+// insertNotification adds n to the stack, collapsing it into a group summary
+// when another notification of its group is live and the group isn't expanded.
+func (a *App) insertNotification(n *Notification) {
+	group := n.spec.Group
+	if group == "" {
+		a.notifications = append(a.notifications, n)
+		return
+	}
+	i := slices.IndexFunc(a.notifications, func(top *Notification) bool {
+		return top.spec.Group == group || (top.members != nil && top.members[0].spec.Group == group)
+	})
+	switch {
+	case i < 0:
+		delete(a.expandedGroups, group) // the expanded group has gone away
+		a.notifications = append(a.notifications, n)
+	case a.notifications[i].members != nil:
+		summary := a.notifications[i]
+		summary.members = append(summary.members, n)
+		a.refreshGroupSummary(summary)
+	case a.expandedGroups[group]:
+		a.notifications = append(a.notifications, n)
+	default:
+		a.nextNotificationID++
+		summary := &Notification{
+			id:            a.nextNotificationID,
+			createdAt:     time.Now(),
+			count:         1,
+			members:       []*Notification{a.notifications[i], n},
+			hoveredAction: -1,
+			focusedAction: -1,
+		}
+		a.refreshGroupSummary(summary)
+		a.notifications[i] = summary
+	}
+}
+
+// refreshGroupSummary rebuilds the summary content from its members: one line
+// per member, newest first, with the highest member level.
+func (a *App) refreshGroupSummary(summary *Notification) {
+	members := summary.members
+	level := NotificationInfo
+	lines := make([]string, 0, len(members))
+	for i := len(members) - 1; i >= 0; i-- {
+		m := members[i]
+		level = max(level, m.spec.Level)
+		text := m.spec.Title
+		if text == "" {
+			text, _, _ = strings.Cut(m.spec.Message, "\n")
+		}
+		if m.count > 1 {
+			text += " ×" + strconv.Itoa(m.count)
+		}
+		lines = append(lines, "• "+text)
+	}
+	summary.spec = NotificationSpec{
+		Level:   level,
+		Title:   Tf(MsgNotificationGroup, members[0].spec.Group, len(members)),
+		Message: strings.Join(lines, "\n"),
+		Actions: []NotificationAction{{ID: notificationGroupExpandAction, Label: T(MsgShowAll)}},
+		OnAction: func(NotificationActionResult) {
+			a.expandGroup(summary)
+		},
+	}
+	summary.resetInteraction()
+}
+
+// expandGroup replaces a summary with its members and keeps later
+// notifications of the group separate while any of them is live. The summary
+// has usually been dismissed already by the action that triggered this.
+func (a *App) expandGroup(summary *Notification) {
+	a.removeNotification(summary.id)
+	if a.expandedGroups == nil {
+		a.expandedGroups = make(map[string]bool)
+	}
+	a.expandedGroups[summary.members[0].spec.Group] = true
+	for _, m := range summary.members {
+		m.resetInteraction()
+	}
+	a.notifications = append(a.notifications, summary.members...)
+}
+
+// removeGroupMember drops member from summary. A summary left with a single
+// member is replaced by it.
+func (a *App) removeGroupMember(summary, member *Notification) {
+	summary.members = slices.DeleteFunc(summary.members, func(m *Notification) bool { return m == member })
+	if len(summary.members) > 1 {
+		a.refreshGroupSummary(summary)
+		return
+	}
+	if i := slices.Index(a.notifications, summary); i >= 0 {
+		a.notifications[i] = summary.members[0]
+		summary.members[0].resetInteraction()
+	}
+}
+
+// renderNotificationOverflow renders the "+N more" indicator for the
+// rate-limited notifications plus hidden ones that didn't fit on screen.
+func renderNotificationOverflow(styles style.NotificationStyleSet, count int) string {
+	return styles.Action.Render(Tf(MsgMoreNotifications, count))
+}
+
+// handleOverflowClick opens the notification center when the overflow
+// indicator is clicked.
+func (a *App) handleOverflowClick(mouse tea.Mouse) bool {
+	if !a.overflowBoundsSet || !a.overflowBounds.contains(mouse.X, mouse.Y) {
+		return false
+	}
+	a.notificationOverflow = 0
+	a.ShowNotificationCenter()
+	return true
+}
+
+// placeNotificationOverflow positions the overflow indicator after the last
+// rendered notification and returns its layer content and position.
+func (a *App) placeNotificationOverflow(styles style.NotificationStyleSet, count, stackHeight int) (string, int, int) {
+	a.overflowBoundsSet = false
+	if count <= 0 {
+		return "", 0, 0
+	}
+	content := renderNotificationOverflow(styles, count)
+	w, h := a.WindowWidth(), a.WindowHeight()
+	cw := lipgloss.Width(content)
+	opts := a.options.NotificationOptions
+	x, y := a.computeNotificationPosition(opts.Anchor, w, h, cw, 1, stackHeight, opts.Gap)
+	a.overflowBounds = notificationRect{x: x, y: y, w: cw, h: 1}
+	a.overflowBoundsSet = true
+	return content, x, y
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestNotificationDedupCoalescesWithCounter(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	app.windowWidth, app.windowHeight = 100, 40
+
+	for i := 0; i < 3; i++ {
+		app.handleShowNotification(NotificationSpec{Level: NotificationError, Title: "Network error", DedupKey: "net", Timeout: time.Minute})
+	}
+	if len(app.notifications) != 1 || app.notifications[0].count != 3 {
+		t.Fatalf("got %d notifications (count %d), want one with count 3", len(app.notifications), app.notifications[0].count)
+	}
+	n := app.notifications[0]
+	if time.Until(n.expireAt) < 59*time.Second {
+		t.Fatal("a repeat should restart the timeout")
+	}
+	if got := ansi.Strip(app.compositeNotifications(blankScreen(100, 40))); !strings.Contains(got, "Network error ×3") {
+		t.Fatalf("rendered stack lacks the counter:\n%s", got)
+	}
+	if len(app.NotificationHistory()) != 1 {
+		t.Fatal("repeats should not add history entries")
+	}
+
+	app.handleShowNotification(NotificationSpec{Title: "Other", DedupKey: "other"})
+	if len(app.notifications) != 2 {
+		t.Fatal("a different key should stack separately")
+	}
+}
+
+func TestNotificationGroupCollapsesAndExpands(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	for _, title := range []string{"a.mp3", "b.mp3", "c.mp3"} {
+		app.handleShowNotification(NotificationSpec{Level: NotificationWarning, Title: title, Group: "Downloads", Timeout: time.Minute})
+	}
+	if len(app.notifications) != 1 || len(app.notifications[0].members) != 3 {
+		t.Fatalf("want one summary with 3 members, got %d notifications", len(app.notifications))
+	}
+	summary := app.notifications[0]
+	if summary.spec.Title != "Downloads (3)" || summary.spec.Level != NotificationWarning ||
+		!strings.HasPrefix(summary.spec.Message, "• c.mp3\n• b.mp3") {
+		t.Fatalf("summary spec = %+v", summary.spec)
+	}
+
+	// A member expiring updates the summary; the last two remain collapsed.
+	first := summary.members[0]
+	first.expireAt = time.Now().Add(-time.Second)
+	app.handleExpire(first.id)
+	if len(summary.members) != 2 || summary.spec.Title != "Downloads (2)" {
+		t.Fatalf("after member expiry: %d members, title %q", len(summary.members), summary.spec.Title)
+	}
+
+	// "Show all" expands the members and keeps new ones separate.
+	app.FocusNotifications()
+	pressKey(app, tea.Key{Code: tea.KeyRight})
+	pressKey(app, tea.Key{Code: tea.KeyEnter})
+	if len(app.notifications) != 2 || app.notifications[0].members != nil {
+		t.Fatalf("expanded stack = %d notifications", len(app.notifications))
+	}
+	app.handleShowNotification(NotificationSpec{Title: "d.mp3", Group: "Downloads"})
+	if len(app.notifications) != 3 {
+		t.Fatal("notifications of an expanded group should stack separately")
+	}
+
+	// Dismissing members down to one leaves no summary behind.
+	grouped := NewApp(DefaultOptions())
+	grouped.handleShowNotification(NotificationSpec{Title: "x", Group: "g", Timeout: time.Minute})
+	grouped.handleShowNotification(NotificationSpec{Title: "y", Group: "g", Timeout: time.Minute})
+	grouped.removeNotification(grouped.notifications[0].members[1].id)
+	if len(grouped.notifications) != 1 || grouped.notifications[0].spec.Title != "x" {
+		t.Fatalf("single remaining member should replace the summary, got %+v", grouped.notifications[0].spec)
+	}
+}
+
+func TestNotificationRateLimitOverflowIndicator(t *testing.T) {
+	ops := DefaultOptions()
+	ops.NotificationOptions.RateLimit = 2
+	ops.NotificationOptions.RateWindow = time.Hour
+	app := NewApp(ops)
+	app.windowWidth, app.windowHeight = 100, 40
+
+	for i := 0; i < 7; i++ {
+		app.handleShowNotification(NotificationSpec{Level: NotificationError, Title: "Failure"})
+	}
+	if len(app.notifications) != 2 || app.notificationOverflow != 5 {
+		t.Fatalf("shown %d, overflow %d; want 2 and 5", len(app.notifications), app.notificationOverflow)
+	}
+	if len(app.NotificationHistory()) != 7 {
+		t.Fatal("rate-limited notifications should still be recorded in the history")
+	}
+	if got := ansi.Strip(app.compositeNotifications(blankScreen(100, 40))); !strings.Contains(got, "+5 more") {
+		t.Fatalf("overflow indicator missing:\n%s", got)
+	}
+
+	b := app.overflowBounds
+	app.Update(tea.MouseClickMsg{X: b.x, Y: b.y, Button: tea.MouseLeft})
+	if app.notificationOverflow != 0 || len(app.modalStack) != 1 {
+		t.Fatal("clicking the indicator should open the notification center")
+	}
+
+	app.ClearNotificationHistory()
+	app.removeNotification(app.notifications[0].id)
+	app.notificationOverflow = 3
+	app.removeNotification(app.notifications[0].id)
+	if app.notificationOverflow != 0 {
+		t.Fatal("overflow should reset once the stack is empty")
+	}
+}
+
+// blankScreen returns a w×h base layer for compositing notifications onto.
+func blankScreen(w, h int) string {
+	return strings.TrimSuffix(strings.Repeat(strings.Repeat(" ", w)+"\n", h), "\n")
+}
@@ -0,0 +1,181 @@
+package model
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/anhoder/foxful-cli/util"
+)
+
+// notificationProgressStep is how long (in ms of Ticker time) the filled
+// segment of an indeterminate bar takes to move one cell.
+const notificationProgressStep = 100
+
+// renderNotificationProgress renders the progress row of a notification: the
+// bar followed by the percentage, or a full-width bouncing bar while
+// indeterminate. Cells are painted on the notification surface.
+func (a *App) renderNotificationProgress(progress NotificationProgress, styles style.NotificationStyleSet, width int) string {
+	start, end := GetProgressColor()
+	row := lipgloss.NewStyle().Width(width).Background(styles.Surface)
+	if progress.Indeterminate {
+		phase := 0
+		if a.options.Ticker != nil {
+			phase = int(a.options.Ticker.PassedTime().Milliseconds() / notificationProgressStep)
+		}
+		ramp := util.MakeRamp(start, end, float64(width))
+		return row.Render(indeterminateProgress(&a.options.ProgressOptions, width, phase, ramp, styles.Surface))
+	}
+
+	percent := math.Max(0, math.Min(1, progress.Percent))
+	label := strconv.Itoa(int(math.Round(percent*100))) + "%"
+	barWidth := max(1, width-5) // " 100%"
+	ramp := util.MakeRamp(start, end, float64(barWidth))
+	full := int(math.Round(percent * float64(barWidth)))
+	bar := renderProgress(&a.options.ProgressOptions, barWidth, full, ramp, styles.Surface)
+	return row.Render(bar + styles.Message.Render(" "+label))
+}
+
+// ProgressNotification is a handle to a notification showing the progress of
+// a long-running operation, created by App.NotifyProgress. All methods are safe
+// to call from any goroutine; rapid updates are coalesced so only the latest
+// state is rendered.
+type ProgressNotification struct {
+	app *App
+
+	mu       sync.Mutex
+	spec     NotificationSpec
+	id       NotificationID // assigned once shown
+	finished bool
+
+	pending atomic.Bool // a progressNotificationMsg is on its way to Update
+}
+
+// progressNotificationMsg asks Update to show or refresh a progress notification.
+type progressNotificationMsg struct {
+	handle *ProgressNotification
+}
+
+// NotifyProgress shows a progress notification and returns its handle. A nil
+// spec.Progress starts with an indeterminate bar. The notification stays
+// visible until Done (which lets it expire like a Success notification), Fail
+// (which turns it into a persistent Error) or a manual dismiss.
+//
+// Unlike Notify, a progress notification over NotificationOptions.RateLimit
+// is not dropped: it waits until the rate window has room and then shows its
+// latest state. ID returns 0 until then.
+//
+// Safe to call from goroutines, including during Init().
+func (a *App) NotifyProgress(spec NotificationSpec) *ProgressNotification {
+	spec = cloneNotificationSpec(spec)
+	if spec.Progress == nil {
+		spec.Progress = &NotificationProgress{Indeterminate: true}
+	}
+	h := &ProgressNotification{app: a, spec: spec}
+	h.sync()
+	return h
+}
+
+// ID returns the notification ID, or 0 until the notification has been shown.
+func (h *ProgressNotification) ID() NotificationID {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.id
+}
+
+// Set updates the progress to percent (in [0, 1]) and the message. A negative
+// percent switches to an indeterminate bar. Ignored after Done or Fail.
+func (h *ProgressNotification) Set(percent float64, message string) {
+	h.mu.Lock()
+	if h.finished {
+		h.mu.Unlock()
+		return
+	}
+	progress := NotificationProgress{Percent: percent}
+	if percent < 0 {
+		progress = NotificationProgress{Indeterminate: true}
+	}
+	h.spec.Progress = &progress
+	h.spec.Message = message
+	h.mu.Unlock()
+	h.sync()
+}
+
+// Done completes the bar and turns the notification into a Success
+// notification that expires after the default timeout.
+func (h *ProgressNotification) Done() {
+	h.finish(func(spec *NotificationSpec) {
+		spec.Level = NotificationSuccess
+		spec.Progress = &NotificationProgress{Percent: 1}
+		spec.Timeout = h.app.defaultNotificationTimeout()
+	})
+}
+
+// Fail replaces the bar with err and turns the notification into a
+// persistent Error notification.
+func (h *ProgressNotification) Fail(err error) {
+	h.finish(func(spec *NotificationSpec) {
+		spec.Level = NotificationError
+		spec.Progress = nil
+		spec.Timeout = 0
+		if err != nil {
+			spec.Message = err.Error()
+		}
+	})
+}
+
+func (h *ProgressNotification) finish(apply func(spec *NotificationSpec)) {
+	h.mu.Lock()
+	if h.finished {
+		h.mu.Unlock()
+		return
+	}
+	h.finished = true
+	apply(&h.spec)
+	h.mu.Unlock()
+	h.sync()
+}
+
+// sync schedules a progressNotificationMsg unless one is already pending; the
+// message carries no state, Update reads the latest spec when it arrives.
+func (h *ProgressNotification) sync() {
+	if h.app.program == nil || !h.pending.CompareAndSwap(false, true) {
+		return
+	}
+	go h.app.program.Send(progressNotificationMsg{handle: h})
+}
+
+// handleProgressNotification shows the notification of h on its first message
+// and updates it with the latest spec afterwards. While the rate limit holds
+// the first showing back, the message is retried once the window has room;
+// pending stays set meanwhile, so updates are picked up by the retry.
+func (a *App) handleProgressNotification(h *ProgressNotification) tea.Cmd {
+	if h.ID() == 0 {
+		if wait := a.notificationRateWait(time.Now()); wait > 0 {
+			return tea.Tick(wait, func(time.Time) tea.Msg { return progressNotificationMsg{handle: h} })
+		}
+	}
+	h.pending.Store(false)
+	h.mu.Lock()
+	spec := cloneNotificationSpec(h.spec)
+	id := h.id
+	h.mu.Unlock()
+
+	if id == 0 {
+		shownID, cmd := a.showNotification(spec)
+		h.mu.Lock()
+		h.id = shownID
+		h.mu.Unlock()
+		return cmd
+	}
+	cmd := a.updateNotificationContent(id, spec)
+	if cmd != nil {
+		return tea.Batch(a.RerenderCmd(true), cmd)
+	}
+	return a.RerenderCmd(true)
+}
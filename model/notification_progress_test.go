@@ -0,0 +1,106 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anhoder/foxful-cli/util"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestNotificationProgressRendersBar(t *testing.T) {
+	_, _, rendered := renderNotificationForTest(NotificationSpec{
+		Title:    "Downloading",
+		Message:  "song.flac",
+		Progress: &NotificationProgress{Percent: 0.42},
+		Actions:  []NotificationAction{{ID: "cancel", Label: "Cancel"}},
+	})
+	lines := strings.Split(ansi.Strip(rendered.content), "\n")
+	// border, title, message, bar, spacer, actions, border
+	if len(lines) != 7 {
+		t.Fatalf("rendered %d lines, want 7:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	if !strings.Contains(lines[3], "#") || !strings.Contains(lines[3], ".") || !strings.Contains(lines[3], "42%") {
+		t.Fatalf("progress row = %q", lines[3])
+	}
+	if got := rendered.actionBounds[0].y; got != 5 {
+		t.Fatalf("action row y = %d, want 5 (below the bar and spacer)", got)
+	}
+
+	ramp := util.MakeRamp("#000000", "#ffffff", 12)
+	frames := []string{}
+	for _, phase := range []int{0, 3, 11} {
+		frames = append(frames, ansi.Strip(indeterminateProgress(&DefaultOptions().ProgressOptions, 12, phase, ramp, nil)))
+	}
+	// The 3-cell segment bounces back after reaching the end at phase 9.
+	if frames[0] != "###........." || frames[1] != "...###......" || frames[2] != ".......###.." {
+		t.Fatalf("indeterminate frames = %q", frames)
+	}
+}
+
+func TestProgressNotificationHandleLifecycle(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	h := app.NotifyProgress(NotificationSpec{Title: "Export"})
+	app.Update(progressNotificationMsg{handle: h})
+	if h.ID() == 0 || len(app.notifications) != 1 {
+		t.Fatal("first message should show the notification")
+	}
+	n := app.notifications[0]
+	if n.spec.Progress == nil || !n.spec.Progress.Indeterminate || !n.expireAt.IsZero() {
+		t.Fatalf("initial spec = %+v, want a persistent indeterminate bar", n.spec)
+	}
+
+	h.Set(0.5, "half way")
+	app.Update(progressNotificationMsg{handle: h})
+	if p := n.spec.Progress; p == nil || p.Indeterminate || p.Percent != 0.5 || n.spec.Message != "half way" {
+		t.Fatalf("after Set: %+v", n.spec)
+	}
+
+	h.Fail(errors.New("disk full"))
+	h.Done() // ignored once finished
+	h.Set(0.9, "late")
+	app.Update(progressNotificationMsg{handle: h})
+	if n.spec.Level != NotificationError || n.spec.Progress != nil || n.spec.Message != "disk full" || !n.expireAt.IsZero() {
+		t.Fatalf("after Fail: %+v", n.spec)
+	}
+
+	done := app.NotifyProgress(NotificationSpec{Title: "Sync"})
+	app.Update(progressNotificationMsg{handle: done})
+	done.Done()
+	app.Update(progressNotificationMsg{handle: done})
+	last := app.notifications[1]
+	if last.spec.Level != NotificationSuccess || last.spec.Progress.Percent != 1 || last.expireAt.IsZero() {
+		t.Fatalf("after Done: %+v expireAt=%v", last.spec, last.expireAt)
+	}
+}
+
+func TestProgressNotificationWaitsForRateLimit(t *testing.T) {
+	ops := DefaultOptions()
+	ops.NotificationOptions.RateLimit = 1
+	ops.NotificationOptions.RateWindow = 50 * time.Millisecond
+	app := NewApp(ops)
+	app.handleShowNotification(NotificationSpec{Title: "First"})
+	if id, _ := app.showNotification(NotificationSpec{Title: "Dropped"}); id != 0 {
+		t.Fatalf("rate-limited notification returned ID %d, want 0", id)
+	}
+
+	h := app.NotifyProgress(NotificationSpec{Title: "Export"})
+	_, cmd := app.Update(progressNotificationMsg{handle: h})
+	if h.ID() != 0 || len(app.notifications) != 1 || cmd == nil {
+		t.Fatal("progress notification should wait for the rate window")
+	}
+	h.Set(0.25, "quarter")
+	retry, ok := cmd().(progressNotificationMsg)
+	if !ok {
+		t.Fatal("waiting should retry the progress message")
+	}
+	app.Update(retry)
+	if h.ID() == 0 || len(app.notifications) != 2 {
+		t.Fatal("progress notification should show once the window has room")
+	}
+	if n := app.notifications[1]; n.spec.Progress.Percent != 0.25 || n.spec.Message != "quarter" {
+		t.Fatalf("shown spec = %+v, want the latest state", n.spec)
+	}
+}
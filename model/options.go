@@ -11,10 +11,46 @@ import (
 // TabConfig defines a single tab in multi-tab main menu mode.
 // Each tab has an isolated menu hierarchy, scroll position, and navigation stack.
 type TabConfig struct {
+	Key        string // Optional stable identifier, e.g. for SetTabBadgeMsg; indices change as tabs move.
 	Title      string
+	Icon       string // Optional icon before the title, e.g. "♫". The only thing shown by a collapsed sidebar.
 	Menu       Menu
 	MenuTitle  *MenuItem
 	OnActivate func(m *Main, prevTabIndex int) bool // Called when tab becomes active. Return false to veto the switch.
+
+	// Closable renders a close glyph after the title. Clicking the glyph or
+	// middle-clicking the tab header closes the tab via Main.CloseTab.
+	Closable bool
+	// OnClose is called before the tab is closed by the user or CloseTab.
+	// Return false to veto the close. Main.RemoveTab does not consult it.
+	OnClose func(m *Main, index int) bool
+
+	// Badge, when set, is evaluated on every render to produce the tab's
+	// unread/activity badge and takes precedence over Main.SetTabBadge.
+	// It runs on the UI goroutine; back it with synchronized state when a
+	// background goroutine drives the count.
+	Badge func() TabBadge
+	// OnClearBadge is called when the tab becomes active so a Badge func can
+	// reset its count. Badges set via Main.SetTabBadge are cleared automatically.
+	OnClearBadge func(m *Main, index int)
+}
+
+// TabLayout selects where Main renders its tabs when EnableTabs is true.
+type TabLayout int
+
+const (
+	// TabLayoutTop renders the tabs as a bordered bar above the menu (default).
+	TabLayoutTop TabLayout = iota
+	// TabLayoutSidebar renders the tabs as a vertical sidebar on the left and
+	// shifts the menu right by the sidebar width.
+	TabLayoutSidebar
+)
+
+// TabSidebarOptions configures TabLayoutSidebar.
+type TabSidebarOptions struct {
+	Width     int    // Expanded width including the separator column. Default 20.
+	Collapsed bool   // Start collapsed to icons only.
+	ToggleKey string // Key that toggles collapsed mode, e.g. "ctrl+b". Empty = disabled.
 }
 
 // ContextMenuOptions configures the size limits of right-click context menus.
@@ -22,6 +58,12 @@ type TabConfig struct {
 type ContextMenuOptions struct {
 	MaxWidth  int
 	MaxHeight int
+	// Key opens the context menu of the keyboard-focused element, in addition
+	// to shift+f10 and the menu key. Empty = only those.
+	Key string
+	// FocusZoneKey moves the keyboard focus from the menu list to the tab
+	// headers and the breadcrumb and back. Default "f6"; empty disables.
+	FocusZoneKey string
 }
 
 type Options struct {
@@ -29,6 +71,24 @@ type Options struct {
 	ProgressOptions
 	NotificationOptions
 
+	// ModalAnimationOptions configures open/close transitions of popups,
+	// context menus and custom modals. Disabled by default.
+	ModalAnimationOptions ModalAnimationOptions
+
+	// PopupWindowModeKey enters popup window mode, where the arrow keys move
+	// the popup and shift+arrows resize it. Default "ctrl+w"; empty disables.
+	// Popups with a Body leave the default to the body (ctrl+w deletes a
+	// word); any other key is handled before the body.
+	PopupWindowModeKey string
+	// PopupMaximizeKey toggles maximizing the top popup. Default "alt+m";
+	// empty disables.
+	PopupMaximizeKey string
+
+	// MenuBar shows an application menu bar with dropdowns in the title row
+	// (needs WhetherDisplayTitle). Nil = the plain title bar. Its alt+letter
+	// mnemonics take precedence over the app's bindings of the same keys.
+	MenuBar []MenuBarMenu
+
 	ContextMenuOptions  ContextMenuOptions
 	AppName             string
 	WhetherDisplayTitle bool
@@ -56,15 +116,28 @@ type Options struct {
 	StatusBar         StatusBar         // Custom status bar, nil = no status bar
 	StatusBarPosition StatusBarPosition // Position of status bar: StatusBarBottom (default) or StatusBarTop
 
+	// TabContextMenu and BreadcrumbContextMenu supply the context menus of tab
+	// headers and breadcrumb segments. EmptyAreaContextMenu supplies the one
+	// of blank space when the current menu has none for index -1. Nil = none.
+	TabContextMenu        TabContextMenu
+	BreadcrumbContextMenu BreadcrumbContextMenu
+	EmptyAreaContextMenu  EmptyAreaContextMenu
+
 	// EnableTabs activates multi-tab navigation in the Main page. When true,
 	// TabConfigs defines the available tabs; when false (default), MainMenu and
 	// MainMenuTitle are used. Tab switching keys: Ctrl+Tab, Ctrl+Shift+Tab,
-	// Ctrl+Left, Ctrl+Right. Each tab maintains isolated menu stack and scroll position.
+	// Ctrl+Left, Ctrl+Right (plus Ctrl+Up, Ctrl+Down with TabLayoutSidebar).
+	// Each tab maintains isolated menu stack and scroll position.
 	EnableTabs bool
-	// TabConfigs defines the tabs when EnableTabs is true. Each tab has an
-	// isolated menu stack and scroll position. Tabs are static (no runtime
-	// add/remove). Keep count ≤8 for usability.
+	// TabConfigs defines the initial tabs when EnableTabs is true. Each tab has
+	// an isolated menu stack and scroll position. Tabs can be added, removed,
+	// reordered and renamed at runtime via Main.AddTab, Main.RemoveTab,
+	// Main.MoveTab and Main.SetTabTitle; this slice is copied and never
+	// modified by those calls.
 	TabConfigs []TabConfig
+	// TabLayout places the tabs above the menu (default) or in a sidebar.
+	TabLayout  TabLayout
+	TabSidebar TabSidebarOptions
 
 	GlobalKeyHandlers map[string]GlobalKeyHandler
 	KBControllers     []KeyboardController
@@ -100,6 +173,14 @@ type NotificationOptions struct {
 	MaxWidth       int           // Whole-notification max width. 0 = min(termWidth/3, 60).
 	MaxLines       int           // Max message body lines before truncation. Default 5.
 	Gap            int           // Vertical gap between stacked notifications. Default 1.
+	HistorySize    int           // Notifications kept for the notification center. Default 100; negative disables.
+	FocusKey       string        // Key moving keyboard focus into the notification stack. Default "alt+n"; empty disables.
+	RateLimit      int           // Max new notifications shown per RateWindow; the rest go to "+N more". 0 = unlimited.
+	RateWindow     time.Duration // Window of RateLimit. Default 1s.
+
+	// Desktop mirrors notifications to the OS while the terminal is unfocused.
+	// Disabled by default.
+	Desktop DesktopNotificationOptions
 }
 
 func DefaultOptions() *Options {
@@ -130,11 +211,16 @@ func DefaultOptions() *Options {
 			MaxWidth:       0,
 			MaxLines:       5,
 			Gap:            1,
+			HistorySize:    100,
+			FocusKey:       "alt+n",
 		},
 		ContextMenuOptions: ContextMenuOptions{
-			MaxWidth:  0,
-			MaxHeight: 0,
+			MaxWidth:     0,
+			MaxHeight:    0,
+			FocusZoneKey: "f6",
 		},
+		PopupWindowModeKey:  popupWindowModeKey,
+		PopupMaximizeKey:    popupMaximizeKey,
 		WhetherDisplayTitle: true,
 		StatusBarPosition:   StatusBarBottom,
 		DualColumn:          true,
@@ -68,6 +68,41 @@ type PopupResult struct {
 	Key string
 }
 
+// PopupBody is an interactive widget embedded in a popup's content area, such
+// as Form, Table, Tree or FilePicker. The popup focuses it when created,
+// forwards keys (other than close keys), mouse events inside the content area
+// with content-relative coordinates and other messages to Update, and calls
+// SetSize whenever the content area changes size.
+//
+// Keys go to the body, so the popup's actions are activated with alt+enter or
+// the mouse. A body with a `Submitted() bool` method (like Form) activates the
+// focused action when it reports a submission.
+type PopupBody interface {
+	Update(msg tea.Msg) tea.Cmd
+	View() string
+	SetSize(width, height int)
+	Focus()
+}
+
+// popupSubmitter is implemented by bodies that can be submitted from the
+// keyboard, e.g. Form.
+type popupSubmitter interface {
+	Submitted() bool
+}
+
+// popupKeyCapturer is implemented by bodies that sometimes need a close key
+// themselves, e.g. esc closing a Form's select dropdown.
+type popupKeyCapturer interface {
+	capturesKey(key string) bool
+}
+
+const (
+	// Default whole-popup size for popups with a Body and no MaxWidth /
+	// MaxHeight, since widgets need a size to lay themselves out.
+	popupBodyDefaultWidth  = 64
+	popupBodyDefaultHeight = 20
+)
+
 // PopupAnchor controls where on the screen a popup appears.
 type PopupAnchor int
 
@@ -111,6 +146,19 @@ type PopupSpec struct {
 	// DisableOutsideClick keeps the popup open when the user clicks outside it.
 	// Default false (an outside left-click dismisses the popup).
 	DisableOutsideClick bool
+
+	// Animation overrides ModalAnimationOptions.Animation for this popup.
+	Animation ModalAnimation
+
+	// GeometryKey opts into remembering the popup's position, size and
+	// maximized state: the next popup shown with the same key reopens where
+	// this one was closed (see App.PopupGeometries).
+	GeometryKey string
+
+	// Body replaces Content with an interactive widget (see PopupBody). Keep a
+	// reference to read its state in OnResult, e.g. Form.Values(). The popup
+	// defaults to a 64x20 size when MaxWidth / MaxHeight are 0.
+	Body PopupBody
 }
 
 // Popup is an active modal dialog. Its configuration is immutable after
@@ -127,12 +175,37 @@ type Popup struct {
 	onResult  func(PopupResult)
 
 	disableResize bool // when true, hide indicator and ignore resize mouse events
+	animation     ModalAnimation
+	geometryKey   string
+
+	// Window management (see popup_window.go): maximized popups fill the
+	// terminal and keep the geometry to restore; windowMode routes the arrow
+	// keys to moving and resizing.
+	maximized      bool
+	restore        popupRestore
+	windowMode     bool
+	lastTitleClick time.Time
+	windowModeKey  string // empty disables
+	maximizeKey    string // empty disables
 
 	// closeKeys is the set of key names that dismiss the popup as a cancel.
 	closeKeys map[string]struct{}
 	// disableOutsideClick keeps the popup open on outside clicks when true.
 	disableOutsideClick bool
 
+	// body is the embedded interactive widget, if any; bodyW/bodyH is the
+	// content size it was last given through SetSize.
+	body  PopupBody
+	bodyW int
+	bodyH int
+
+	// actionGuard, when set, may veto a non-cancel action (e.g. invalid
+	// input) and keep the popup open. resultCmd turns the result into a
+	// command returned from complete; used by the dialog helpers to deliver
+	// typed results as messages.
+	actionGuard func(actionID string) bool
+	resultCmd   func(PopupResult) tea.Cmd
+
 	// markdownMeta stores markdown rendering metadata for popups created via
 	// NewMarkdownPopup. When non-nil, the popup can re-render its content when
 	// the terminal background changes (light/dark mode switch).
@@ -178,6 +251,9 @@ type Popup struct {
 	// methods are promoted onto Popup.
 	textSelection
 
+	// search is the find-in-content state (see popup_search.go).
+	search popupSearch
+
 	// pointerShape tracks the currently-set OSC 22 pointer shape ("" = default),
 	// so hover changes only emit an escape when the shape actually changes.
 	pointerShape string
@@ -253,6 +329,10 @@ func NewPopup(spec PopupSpec) (*Popup, error) {
 		return nil, fmt.Errorf("popup may have at most one cancel action")
 	}
 
+	if spec.Body != nil && spec.Content != "" {
+		return nil, fmt.Errorf("popup body and content are mutually exclusive")
+	}
+
 	closeKeys := spec.CloseKeys
 	if closeKeys == nil {
 		closeKeys = []string{"esc"}
@@ -265,7 +345,7 @@ func NewPopup(spec PopupSpec) (*Popup, error) {
 		closeKeySet[key] = struct{}{}
 	}
 
-	return &Popup{
+	p := &Popup{
 		title:               spec.Title,
 		content:             spec.Content,
 		actions:             actions,
@@ -276,10 +356,32 @@ func NewPopup(spec PopupSpec) (*Popup, error) {
 		offsetY:             spec.OffsetY,
 		onResult:            spec.OnResult,
 		disableResize:       spec.DisableResize,
+		animation:           spec.Animation,
+		geometryKey:         spec.GeometryKey,
 		closeKeys:           closeKeySet,
 		disableOutsideClick: spec.DisableOutsideClick,
 		hoveredAction:       -1,
-	}, nil
+		windowModeKey:       popupWindowModeKey,
+		maximizeKey:         popupMaximizeKey,
+	}
+	if spec.Body != nil {
+		p.body = spec.Body
+		if p.maxWidth == 0 {
+			p.maxWidth = popupBodyDefaultWidth
+		}
+		if p.maxHeight == 0 {
+			p.maxHeight = popupBodyDefaultHeight
+		}
+		// The default action is the first one that isn't the cancel action.
+		for i, action := range actions {
+			if !action.IsCancel {
+				p.focusedAction = i
+				break
+			}
+		}
+		p.body.Focus()
+	}
+	return p, nil
 }
 
 // SetTermSize stores the terminal dimensions for boundary detection during drag.
@@ -298,10 +400,23 @@ func isPopupPlainText(value string) bool {
 	return true
 }
 
-func (p *Popup) update(msg tea.Msg) {
+func (p *Popup) update(msg tea.Msg) tea.Cmd {
 	keyMsg, ok := msg.(tea.KeyMsg)
 	if !ok {
-		return
+		return nil
+	}
+	if p.search.typing {
+		_, cmd := p.updateSearch(keyMsg)
+		return cmd
+	}
+	if p.updateWindowMode(keyMsg.String()) {
+		return nil
+	}
+	if p.body != nil {
+		return p.updateBody(keyMsg)
+	}
+	if handled, cmd := p.updateSearch(keyMsg); handled {
+		return cmd
 	}
 
 	if p.isContentScrollable() {
@@ -309,27 +424,27 @@ func (p *Popup) update(msg tea.Msg) {
 		case "up", "k":
 			p.clearSelection()
 			p.scrollOffset = max(p.scrollOffset-1, 0)
-			return
+			return nil
 		case "down", "j":
 			p.clearSelection()
 			p.scrollOffset = min(p.scrollOffset+1, p.maxScrollOffset())
-			return
+			return nil
 		case "pgup":
 			p.clearSelection()
 			p.scrollOffset = max(p.scrollOffset-max(p.visibleContentLines/2, 1), 0)
-			return
+			return nil
 		case "pgdn":
 			p.clearSelection()
 			p.scrollOffset = min(p.scrollOffset+max(p.visibleContentLines/2, 1), p.maxScrollOffset())
-			return
+			return nil
 		case "home":
 			p.clearSelection()
 			p.scrollOffset = 0
-			return
+			return nil
 		case "end":
 			p.clearSelection()
 			p.scrollOffset = p.maxScrollOffset()
-			return
+			return nil
 		}
 	}
 
@@ -339,14 +454,14 @@ func (p *Popup) update(msg tea.Msg) {
 	if _, isClose := p.closeKeys[key]; isClose {
 		if key == "esc" && p.hasSelection {
 			p.clearSelection()
-			return
+			return nil
 		}
 		cause := PopupDismissKey
 		if key == "esc" {
 			cause = PopupDismissEscape
 		}
 		p.dismissCancelKey(cause, key)
-		return
+		return nil
 	}
 
 	switch key {
@@ -363,6 +478,89 @@ func (p *Popup) update(msg tea.Msg) {
 			p.focusedAction = (p.focusedAction - 1 + len(p.actions)) % len(p.actions)
 		}
 	}
+	return nil
+}
+
+// updateBody routes a key to the embedded body. Close keys still dismiss the
+// popup and alt+enter activates the focused action; a body reporting a
+// submission activates it too.
+func (p *Popup) updateBody(msg tea.KeyMsg) tea.Cmd {
+	key := msg.String()
+	if c, ok := p.body.(popupKeyCapturer); ok && c.capturesKey(key) {
+		return p.body.Update(msg)
+	}
+	if _, isClose := p.closeKeys[key]; isClose {
+		cause := PopupDismissKey
+		if key == "esc" {
+			cause = PopupDismissEscape
+		}
+		p.dismissCancelKey(cause, key)
+		return nil
+	}
+	if key == "alt+enter" {
+		p.dismissAction(p.focusedAction, PopupDismissAction)
+		return nil
+	}
+	cmd := p.body.Update(msg)
+	p.dismissIfSubmitted()
+	return cmd
+}
+
+// dismissIfSubmitted activates the focused action once the body reports a
+// submission.
+func (p *Popup) dismissIfSubmitted() {
+	if s, ok := p.body.(popupSubmitter); ok && s.Submitted() {
+		p.dismissAction(p.focusedAction, PopupDismissAction)
+	}
+}
+
+// bodySize returns the content area size available to the body.
+func (p *Popup) bodySize(actionsOverhead int) (int, int) {
+	return p.maxContentWidth(), max(1, p.maxHeight-popupFrameVerticalOverhead-actionsOverhead)
+}
+
+// renderBodyLines sizes and renders the body into exactly the content area's
+// rows. Explicit backgrounds (e.g. a selected table row) are kept; the rest
+// is painted with the popup surface.
+func (p *Popup) renderBodyLines(styles style.PopupStyleSet, actionsOverhead int) []string {
+	w, h := p.bodySize(actionsOverhead)
+	if w != p.bodyW || h != p.bodyH {
+		p.bodyW, p.bodyH = w, h
+		p.body.SetSize(w, h)
+	}
+	view := fillMissingBackground(styles.Content.Render(p.body.View()), styles.Surface)
+	lines := strings.Split(view, "\n")
+	if len(lines) > h {
+		lines = lines[:h]
+	}
+	for len(lines) < h {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// pointInBody reports whether a popup-relative point lies in the body area.
+func (p *Popup) pointInBody(relX, relY int) bool {
+	return p.body != nil &&
+		relX >= p.bodyRelX && relX < p.bodyRelX+p.bodyW &&
+		relY >= p.bodyRelY && relY < p.bodyRelY+p.bodyH
+}
+
+// bodyMouseMsg translates msg into body-relative coordinates.
+func (p *Popup) bodyMouseMsg(msg tea.MouseMsg) tea.Msg {
+	m := msg.Mouse()
+	m.X -= p.bounds.x + p.bodyRelX
+	m.Y -= p.bounds.y + p.bodyRelY
+	switch msg.(type) {
+	case tea.MouseClickMsg:
+		return tea.MouseClickMsg(m)
+	case tea.MouseReleaseMsg:
+		return tea.MouseReleaseMsg(m)
+	case tea.MouseWheelMsg:
+		return tea.MouseWheelMsg(m)
+	default:
+		return tea.MouseMotionMsg(m)
+	}
 }
 
 func (p *Popup) dismissed() bool {
@@ -383,7 +581,11 @@ func (p *Popup) dismissActionKey(index int, cause PopupDismissCause, key string)
 	if p.result != nil || index < 0 || index >= len(p.actions) {
 		return
 	}
-	p.result = &PopupResult{ActionID: p.actions[index].ID, Cause: cause, Key: key}
+	action := p.actions[index]
+	if p.actionGuard != nil && !action.IsCancel && !p.actionGuard(action.ID) {
+		return
+	}
+	p.result = &PopupResult{ActionID: action.ID, Cause: cause, Key: key}
 }
 
 func (p *Popup) dismissCancel(cause PopupDismissCause) {
@@ -442,13 +644,46 @@ func (p *Popup) rerenderMarkdown() bool {
 	return true
 }
 
+// setContent replaces the plain content of an open popup, keeping its size
+// constraints. Used for popups whose content updates live.
+func (p *Popup) setContent(content string) {
+	p.content = content
+	p.contentLines = nil // force rebuild in renderContent
+	p.scrollOffset = clampInt(p.scrollOffset, 0, max(0, strings.Count(content, "\n")))
+	p.clearSelection()
+}
+
+// setActions replaces the actions of an open popup, keeping the focus on the
+// same action ID when it is still offered.
+func (p *Popup) setActions(actions []PopupAction) {
+	focused := ""
+	if p.focusedAction < len(p.actions) {
+		focused = p.actions[p.focusedAction].ID
+	}
+	p.actions = actions
+	p.focusedAction = max(0, min(p.focusedAction, len(actions)-1))
+	for i, action := range actions {
+		if action.ID == focused {
+			p.focusedAction = i
+		}
+	}
+	p.hoveredAction = -1
+}
+
 // complete implements Modal.complete for Popup.
-// Invokes the onResult callback if present, then returns (nil, nil).
+// Invokes the onResult callback if present, then returns (nil, nil) or the
+// result command of a dialog helper.
 func (p *Popup) complete(app *App) (Page, tea.Cmd) {
 	result := p.consumeResult()
-	if result != nil && p.onResult != nil {
+	if result == nil {
+		return nil, nil
+	}
+	if p.onResult != nil {
 		p.onResult(*result)
 	}
+	if p.resultCmd != nil {
+		return nil, p.resultCmd(*result)
+	}
 	return nil, nil
 }
 
@@ -482,6 +717,7 @@ type popupRender struct {
 }
 
 func (p *Popup) render(styles style.PopupStyleSet) popupRender {
+	p.fitMaximized()
 	maxContentWidth := p.maxContentWidth()
 
 	actions := p.renderActions(styles, maxContentWidth)
@@ -493,22 +729,34 @@ func (p *Popup) render(styles style.PopupStyleSet) popupRender {
 	if p.contentLines == nil && p.content != "" {
 		normalized := normalizePopupSurface(styles.Content.Render(p.content), styles.Surface)
 		p.contentLines = strings.Split(normalized, "\n")
+		p.refreshSearch(false)
 	}
-	contentLines := p.contentLines
-	p.totalContentLines = len(contentLines)
 
 	// The action block, when present, is preceded by one blank spacer row.
 	actionsOverhead := actions.height
 	if actions.height > 0 {
 		actionsOverhead++
 	}
+
+	// The search bar, when open, takes one row below the content.
+	searchRows := 0
+	if p.search.open {
+		searchRows = 1
+	}
+
+	contentLines := p.contentLines
+	if p.body != nil {
+		contentLines = p.renderBodyLines(styles, actionsOverhead)
+	}
+	p.totalContentLines = len(contentLines)
 	visibleHeight := len(contentLines)
 	if len(contentLines) > 0 && p.maxHeight > 0 {
-		visibleHeight = max(1, p.maxHeight-popupFrameVerticalOverhead-actionsOverhead)
+		visibleHeight = max(1, p.maxHeight-popupFrameVerticalOverhead-actionsOverhead-searchRows)
 		visibleHeight = min(visibleHeight, len(contentLines))
 	}
 	p.visibleContentLines = visibleHeight
 	p.scrollOffset = min(p.scrollOffset, p.maxScrollOffset())
+	p.revealSearchMatch()
 
 	scrolling := len(contentLines) > visibleHeight
 
@@ -516,6 +764,9 @@ func (p *Popup) render(styles style.PopupStyleSet) popupRender {
 	if visibleHeight < len(contentLines) {
 		visibleLines = contentLines[p.scrollOffset : p.scrollOffset+visibleHeight]
 	}
+	if len(p.search.matches) > 0 {
+		visibleLines = p.applySearchHighlight(visibleLines)
+	}
 	if p.hasSelection {
 		visibleLines = p.applySelectionHighlight(visibleLines)
 	}
@@ -589,6 +840,9 @@ func (p *Popup) render(styles style.PopupStyleSet) popupRender {
 		}
 		blocks = append(blocks, bodyStr)
 	}
+	if searchRows > 0 {
+		blocks = append(blocks, p.renderSearchBar(styles, innerWidth))
+	}
 	// One blank line separates the content from the action buttons.
 	spacerHeight := 0
 	if bodyStr != "" && actions.content != "" {
@@ -622,7 +876,9 @@ func (p *Popup) render(styles style.PopupStyleSet) popupRender {
 
 	framed := styles.Frame.Render(inner)
 
-	if p.title != "" {
+	if p.windowMode {
+		framed = embedTitleInTopBorder(framed, T(MsgPopupWindowMode), styles)
+	} else if p.title != "" {
 		framed = embedTitleInTopBorder(framed, p.title, styles)
 	}
 
@@ -631,7 +887,7 @@ func (p *Popup) render(styles style.PopupStyleSet) popupRender {
 		framed = addResizeIndicator(framed, styles.Surface)
 	}
 
-	actionY := popupFrameInsetY + bodyHeight + spacerHeight
+	actionY := popupFrameInsetY + bodyHeight + searchRows + spacerHeight
 	actionX := popupFrameInsetX + (innerWidth-actions.width)/2
 	actionBounds := make([]popupRect, len(actions.bounds))
 	for i, bound := range actions.bounds {
@@ -996,8 +1252,7 @@ func (p *Popup) handleMouse(msg tea.MouseMsg) (bool, tea.Cmd) {
 		// Calculate deltas from resize start position
 		deltaX := mouse.X - p.resizeStartMouseX
 		deltaY := mouse.Y - p.resizeStartMouseY
-		minW := popupFrameHorizontalOverhead + 10 // enough for buttons
-		minH := popupFrameVerticalOverhead + 3    // title + 1 line + buttons
+		minW, minH := popupMinWidth, popupMinHeight
 
 		// Apply resize based on handle type
 		switch p.resizeHandle {
@@ -1043,6 +1298,10 @@ func (p *Popup) handleMouse(msg tea.MouseMsg) (bool, tea.Cmd) {
 		return false, hoverCmd
 	}
 
+	if relX, relY := mouse.X-p.bounds.x, mouse.Y-p.bounds.y; p.pointInBody(relX, relY) {
+		return true, tea.Batch(hoverCmd, p.body.Update(p.bodyMouseMsg(msg)))
+	}
+
 	if isClick && mouse.Button == tea.MouseLeft {
 		return p.handleLeftClick(mouse, hoverCmd)
 	}
@@ -1168,6 +1427,13 @@ func (p *Popup) handleLeftClick(mouse tea.Mouse, hoverCmd tea.Cmd) (bool, tea.Cm
 	}
 	if mouse.Y == p.bounds.y {
 		p.clearSelection()
+		if p.titleDoubleClicked(time.Now()) {
+			p.toggleMaximize()
+			return true, hoverCmd
+		}
+		if p.maximized {
+			return true, hoverCmd
+		}
 		p.dragging = true
 		p.dragMouseX = mouse.X
 		p.dragMouseY = mouse.Y
@@ -1346,7 +1612,7 @@ func (p *Popup) desiredDragPointer() string {
 
 // resizeHandleAt reports which resize handle (corner or edge) the mouse is over.
 func (p *Popup) resizeHandleAt(mouse tea.Mouse) ResizeHandle {
-	if !p.boundsSet || p.disableResize {
+	if !p.boundsSet || p.disableResize || p.maximized {
 		return ResizeNone
 	}
 
@@ -1449,6 +1715,12 @@ func (p *Popup) applySelectionHighlight(visibleLines []string) []string {
 // highlightColumns reverse-videos the display columns [left, right) of a single
 // styled line, preserving all other cell styling.
 func highlightColumns(line string, left, right int) string {
+	return highlightColumnsAttrs(line, left, right, uv.AttrReverse)
+}
+
+// highlightColumnsAttrs adds attrs to the display columns [left, right) of a
+// single styled line.
+func highlightColumnsAttrs(line string, left, right int, attrs uint8) string {
 	screen := popupStyledScreen(line)
 	if len(screen.Lines) == 0 {
 		return line
@@ -1460,7 +1732,7 @@ func highlightColumns(line string, left, right int) string {
 		if cell == nil {
 			continue
 		}
-		cell.Style.Attrs |= uv.AttrReverse
+		cell.Style.Attrs |= attrs
 	}
 	return screen.Render()
 }
@@ -1493,9 +1765,22 @@ func (p *Popup) maxScrollOffset() int {
 }
 
 func (p *Popup) computePosition(termW, termH, popupW, popupH int) (int, int) {
-	ox, oy := p.anchorOrigin(termW, termH, popupW, popupH)
-	x := ox + p.offsetX
-	y := oy + p.offsetY
+	if p.maximized {
+		return popupMaximizeMargin, popupMaximizeMargin
+	}
+	return placeAnchored(p.anchor, p.offsetX, p.offsetY, termW, termH, popupW, popupH)
+}
+
+func (p *Popup) anchorOrigin(termW, termH, popupW, popupH int) (int, int) {
+	return anchorOrigin(p.anchor, termW, termH, popupW, popupH)
+}
+
+// placeAnchored positions a w×h overlay at anchor plus the offset, clamped to
+// the screen.
+func placeAnchored(anchor PopupAnchor, offsetX, offsetY, termW, termH, w, h int) (int, int) {
+	ox, oy := anchorOrigin(anchor, termW, termH, w, h)
+	x := ox + offsetX
+	y := oy + offsetY
 
 	if x < 0 {
 		x = 0
@@ -1503,32 +1788,32 @@ func (p *Popup) computePosition(termW, termH, popupW, popupH int) (int, int) {
 	if y < 0 {
 		y = 0
 	}
-	if x+popupW > termW {
-		x = termW - popupW
+	if x+w > termW {
+		x = termW - w
 	}
-	if y+popupH > termH {
-		y = termH - popupH
+	if y+h > termH {
+		y = termH - h
 	}
 	return x, y
 }
 
-func (p *Popup) anchorOrigin(termW, termH, popupW, popupH int) (int, int) {
-	switch p.anchor {
+func anchorOrigin(anchor PopupAnchor, termW, termH, w, h int) (int, int) {
+	switch anchor {
 	case AnchorTopLeft:
 		return 0, 0
 	case AnchorTopCenter:
-		return (termW - popupW) / 2, 0
+		return (termW - w) / 2, 0
 	case AnchorTopRight:
-		return termW - popupW, 0
+		return termW - w, 0
 	case AnchorBottomLeft:
-		return 0, termH - popupH
+		return 0, termH - h
 	case AnchorBottomCenter:
-		return (termW - popupW) / 2, termH - popupH
+		return (termW - w) / 2, termH - h
 	case AnchorBottomRight:
-		return termW - popupW, termH - popupH
+		return termW - w, termH - h
 	case AnchorCustom:
 		return 0, 0
 	default:
-		return (termW - popupW) / 2, (termH - popupH) / 3
+		return (termW - w) / 2, (termH - h) / 3
 	}
 }
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/anhoder/foxful-cli/style"
+)
+
+// spyPopupBody records what the popup forwards to its body.
+type spyPopupBody struct {
+	focused bool
+	sizes   [][2]int
+	msgs    []tea.Msg
+}
+
+func (b *spyPopupBody) Update(msg tea.Msg) tea.Cmd { b.msgs = append(b.msgs, msg); return nil }
+func (b *spyPopupBody) View() string               { return "body" }
+func (b *spyPopupBody) SetSize(w, h int)           { b.sizes = append(b.sizes, [2]int{w, h}) }
+func (b *spyPopupBody) Focus()                     { b.focused = true }
+
+func TestPopupBodySizingAndInputRouting(t *testing.T) {
+	body := &spyPopupBody{}
+	popup, err := NewPopup(PopupSpec{
+		Title:   "Pick",
+		Body:    body,
+		Actions: []PopupAction{{ID: "cancel", Label: "Cancel", IsCancel: true}, {ID: "ok", Label: "OK"}},
+	})
+	if err != nil {
+		t.Fatalf("NewPopup() error = %v", err)
+	}
+	if !body.focused || popup.focusedAction != 1 {
+		t.Fatalf("body focused=%v, focused action=%d; want focused body and the OK action", body.focused, popup.focusedAction)
+	}
+
+	styles := style.NewStyleSet(style.DefaultDarkTheme()).Popup
+	rendered := popup.render(styles)
+	popup.setBounds(10, 5, popup.maxWidth, popup.maxHeight, rendered.actionBounds)
+	// 64x20 default minus the frame, the action row and its spacer.
+	if len(body.sizes) != 1 || body.sizes[0] != [2]int{60, 16} {
+		t.Fatalf("SetSize calls = %v, want [[60 16]]", body.sizes)
+	}
+
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+	popup.handleMouse(tea.MouseClickMsg(tea.Mouse{X: 10 + popupFrameInsetX + 3, Y: 5 + popupFrameInsetY + 2, Button: tea.MouseLeft}))
+	if len(body.msgs) != 2 {
+		t.Fatalf("body received %d messages, want 2", len(body.msgs))
+	}
+	if click, ok := body.msgs[1].(tea.MouseClickMsg); !ok || click.X != 3 || click.Y != 2 {
+		t.Fatalf("mouse msg = %#v, want a click at content-relative (3, 2)", body.msgs[1])
+	}
+
+	// Resizing the popup resizes the body on the next render.
+	popup.maxWidth, popup.maxHeight = 40, 12
+	popup.render(styles)
+	if last := body.sizes[len(body.sizes)-1]; last != [2]int{36, 8} {
+		t.Fatalf("SetSize after resize = %v, want [36 8]", last)
+	}
+
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter, Mod: tea.ModAlt}))
+	if result := popup.consumeResult(); result == nil || result.ActionID != "ok" {
+		t.Fatalf("alt+enter result = %+v, want the ok action", result)
+	}
+}
+
+func TestPopupFormBodySubmitsDefaultAction(t *testing.T) {
+	form := NewForm([]FormField{{Key: "user", Label: "User", Required: true}})
+	var values map[string]string
+	popup, err := NewPopup(PopupSpec{
+		Title:   "Login",
+		Body:    form,
+		Actions: []PopupAction{{ID: "login", Label: "Login"}, {ID: "cancel", Label: "Cancel", IsCancel: true}},
+		OnResult: func(r PopupResult) {
+			if r.ActionID == "login" {
+				values = form.Values()
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewPopup() error = %v", err)
+	}
+
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	if popup.dismissed() {
+		t.Fatal("an invalid form should not submit")
+	}
+	popup.update(tea.KeyPressMsg(tea.Key{Code: 'a', Text: "a"}))
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	if !popup.dismissed() {
+		t.Fatal("submitting the form should activate the default action")
+	}
+	popup.complete(nil)
+	if values["user"] != "a" {
+		t.Fatalf("OnResult read values %v, want user=a", values)
+	}
+
+	if _, err := NewPopup(PopupSpec{Content: "text", Body: form}); err == nil {
+		t.Fatal("Content and Body together should be rejected")
+	}
+}
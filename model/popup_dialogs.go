@@ -0,0 +1,471 @@
+package model
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/sahilm/fuzzy"
+)
+
+// Dialog helpers built on NewPopup. Each delivers a typed result to its
+// OnResult callback or, when OnResult is nil, as a message returned from
+// Update (match it by type and ID). Escape, outside clicks and close keys
+// produce a result with Cancelled set.
+
+const (
+	dialogActionOK     = "ok"
+	dialogActionCancel = "cancel"
+
+	dialogDefaultWidth       = 50 // whole popup width of the input and choice dialogs
+	dialogFrameOverhead      = 4  // popup frame plus the action row and its spacer
+	choiceDialogDefaultLines = 10 // visible options when ChoicePopupSpec.MaxHeight is 0
+)
+
+// ConfirmResult is the result of a confirm dialog.
+type ConfirmResult struct {
+	ID        string
+	Confirmed bool
+	Cancelled bool // dismissed without choosing Yes or No
+}
+
+// ConfirmPopupSpec defines a yes/no dialog.
+type ConfirmPopupSpec struct {
+	ID       string // copied to the result, to tell dialogs apart
+	Title    string
+	Message  string
+	YesLabel string // empty = T(MsgYes)
+	NoLabel  string // empty = T(MsgNo)
+	MaxWidth int    // 0 = unlimited
+	OnResult func(ConfirmResult)
+}
+
+// NewConfirmPopup creates a Yes/No dialog. No is the cancel action, so Escape
+// selects it; the result still reports Cancelled to tell the two apart.
+func NewConfirmPopup(spec ConfirmPopupSpec) (*Popup, error) {
+	yes, no := spec.YesLabel, spec.NoLabel
+	if yes == "" {
+		yes = T(MsgYes)
+	}
+	if no == "" {
+		no = T(MsgNo)
+	}
+	p, err := NewPopup(PopupSpec{
+		Title:    spec.Title,
+		Content:  spec.Message,
+		MaxWidth: spec.MaxWidth,
+		Actions: []PopupAction{
+			{ID: dialogActionOK, Label: yes},
+			{ID: dialogActionCancel, Label: no, IsCancel: true},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	setDialogResult(p, spec.OnResult, func(r PopupResult) ConfirmResult {
+		return ConfirmResult{
+			ID:        spec.ID,
+			Confirmed: r.ActionID == dialogActionOK,
+			Cancelled: r.Cause != PopupDismissAction,
+		}
+	})
+	return p, nil
+}
+
+// InputResult is the result of an input dialog. Value is empty when cancelled.
+type InputResult struct {
+	ID        string
+	Value     string
+	Cancelled bool
+}
+
+// InputPopupSpec defines a single-line text prompt.
+type InputPopupSpec struct {
+	ID          string // copied to the result, to tell dialogs apart
+	Title       string
+	Prompt      string // optional line above the input
+	Placeholder string
+	Value       string // initial value
+	Password    bool   // mask the input
+	CharLimit   int    // 0 = 256
+	// Validate is run on confirmation; an error is shown under the input and
+	// keeps the dialog open.
+	Validate func(string) error
+	MaxWidth int // whole popup width; 0 = 50
+	OnResult func(InputResult)
+}
+
+// NewInputPopup creates a text prompt with Confirm and Cancel actions. Enter
+// confirms.
+func NewInputPopup(spec InputPopupSpec) (*Popup, error) {
+	body := newInputDialogBody(spec)
+	height := 2 // input + error row
+	if spec.Prompt != "" {
+		height++
+	}
+	p, err := NewPopup(PopupSpec{
+		Title:     spec.Title,
+		Body:      body,
+		MaxWidth:  dialogWidth(spec.MaxWidth),
+		MaxHeight: height + dialogFrameOverhead,
+		Actions:   dialogActions(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.actionGuard = func(string) bool { return body.check() }
+	setDialogResult(p, spec.OnResult, func(r PopupResult) InputResult {
+		if r.ActionID != dialogActionOK {
+			return InputResult{ID: spec.ID, Cancelled: true}
+		}
+		return InputResult{ID: spec.ID, Value: body.input.Value()}
+	})
+	return p, nil
+}
+
+// ChoiceResult is the result of a choice dialog: the chosen option indices in
+// option order and their values. Both are empty when cancelled.
+type ChoiceResult struct {
+	ID        string
+	Indices   []int
+	Values    []string
+	Cancelled bool
+}
+
+// ChoicePopupSpec defines a list to choose from. Typing fuzzy-filters the
+// options; in Multi mode Tab toggles the highlighted option.
+type ChoicePopupSpec struct {
+	ID        string // copied to the result, to tell dialogs apart
+	Title     string
+	Options   []string
+	Multi     bool
+	Selected  []int // initially selected options (Multi only)
+	MaxWidth  int   // whole popup width; 0 = 50
+	MaxHeight int   // whole popup height; 0 = room for 10 options
+	OnResult  func(ChoiceResult)
+}
+
+// NewChoicePopup creates a single or multi-select list with a filter input.
+// Enter chooses the highlighted option, or in Multi mode the toggled options
+// (the highlighted one when none is toggled).
+func NewChoicePopup(spec ChoicePopupSpec) (*Popup, error) {
+	body := newChoiceDialogBody(spec)
+	maxHeight := spec.MaxHeight
+	if maxHeight == 0 {
+		maxHeight = 1 + max(1, min(len(spec.Options), choiceDialogDefaultLines)) + dialogFrameOverhead
+	}
+	p, err := NewPopup(PopupSpec{
+		Title:     spec.Title,
+		Body:      body,
+		MaxWidth:  dialogWidth(spec.MaxWidth),
+		MaxHeight: maxHeight,
+		Actions:   dialogActions(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.actionGuard = func(string) bool { return len(body.chosen()) > 0 }
+	setDialogResult(p, spec.OnResult, func(r PopupResult) ChoiceResult {
+		if r.ActionID != dialogActionOK {
+			return ChoiceResult{ID: spec.ID, Cancelled: true}
+		}
+		indices := body.chosen()
+		values := make([]string, len(indices))
+		for i, idx := range indices {
+			values[i] = spec.Options[idx]
+		}
+		return ChoiceResult{ID: spec.ID, Indices: indices, Values: values}
+	})
+	return p, nil
+}
+
+func dialogActions() []PopupAction {
+	return []PopupAction{
+		{ID: dialogActionOK, Label: T(MsgConfirm)},
+		{ID: dialogActionCancel, Label: T(MsgCancel), IsCancel: true},
+	}
+}
+
+func dialogWidth(width int) int {
+	if width == 0 {
+		return dialogDefaultWidth
+	}
+	return width
+}
+
+// setDialogResult converts the popup result with convert and delivers it to
+// onResult, or as a message when onResult is nil.
+func setDialogResult[R any](p *Popup, onResult func(R), convert func(PopupResult) R) {
+	if onResult != nil {
+		p.onResult = func(r PopupResult) { onResult(convert(r)) }
+		return
+	}
+	p.resultCmd = func(r PopupResult) tea.Cmd {
+		result := convert(r)
+		return func() tea.Msg { return result }
+	}
+}
+
+// dialogInputStyles styles a dialog's text input like Form's focused field.
+func dialogInputStyles(styles style.StyleSet) textinput.Styles {
+	tiStyles := textinput.DefaultStyles(style.HasDarkBackground())
+	tiStyles.Focused.Prompt = styles.Prompt
+	tiStyles.Focused.Text = styles.Normal
+	tiStyles.Focused.Placeholder = styles.Muted
+	return tiStyles
+}
+
+// inputDialogBody is the PopupBody of NewInputPopup.
+type inputDialogBody struct {
+	prompt    string
+	input     textinput.Model
+	validate  func(string) error
+	err       error
+	submitted bool
+}
+
+func newInputDialogBody(spec InputPopupSpec) *inputDialogBody {
+	ti := textinput.New()
+	ti.Placeholder = spec.Placeholder
+	ti.CharLimit = spec.CharLimit
+	if ti.CharLimit == 0 {
+		ti.CharLimit = 256
+	}
+	if spec.Password {
+		ti.EchoMode = textinput.EchoPassword
+		ti.EchoCharacter = '•'
+	}
+	ti.SetValue(spec.Value)
+	return &inputDialogBody{prompt: spec.Prompt, input: ti, validate: spec.Validate}
+}
+
+// check validates the current value, recording the error to display.
+func (b *inputDialogBody) check() bool {
+	b.err = nil
+	if b.validate != nil {
+		b.err = b.validate(b.input.Value())
+	}
+	return b.err == nil
+}
+
+func (b *inputDialogBody) Update(msg tea.Msg) tea.Cmd {
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "enter" {
+		b.submitted = b.check()
+		return nil
+	}
+	var cmd tea.Cmd
+	b.input, cmd = b.input.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok && b.err != nil {
+		b.check() // re-validate while the error is shown
+	}
+	return cmd
+}
+
+func (b *inputDialogBody) View() string {
+	styles := style.CurrentStyleSet()
+	b.input.SetStyles(dialogInputStyles(styles))
+	lines := make([]string, 0, 3)
+	if b.prompt != "" {
+		lines = append(lines, b.prompt)
+	}
+	lines = append(lines, b.input.View())
+	if b.err != nil {
+		lines = append(lines, styles.Error.Render(b.err.Error()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (b *inputDialogBody) SetSize(width, height int) {
+	b.input.SetWidth(max(1, width-lipgloss.Width(b.input.Prompt)-1))
+}
+
+func (b *inputDialogBody) Focus() {
+	b.input.Focus()
+}
+
+func (b *inputDialogBody) Submitted() bool {
+	return b.submitted
+}
+
+// choiceDialogBody is the PopupBody of NewChoicePopup: a filter input above
+// the list of matching options.
+type choiceDialogBody struct {
+	options  []string
+	multi    bool
+	filter   textinput.Model
+	matches  []int // option indices in display order
+	cursor   int   // index into matches
+	offset   int
+	selected map[int]bool
+	height   int
+
+	submitted bool
+}
+
+func newChoiceDialogBody(spec ChoicePopupSpec) *choiceDialogBody {
+	ti := textinput.New()
+	ti.Prompt = "/ "
+	b := &choiceDialogBody{
+		options:  spec.Options,
+		multi:    spec.Multi,
+		filter:   ti,
+		selected: make(map[int]bool),
+	}
+	if spec.Multi {
+		for _, idx := range spec.Selected {
+			if idx >= 0 && idx < len(spec.Options) {
+				b.selected[idx] = true
+			}
+		}
+	}
+	b.refilter()
+	return b
+}
+
+// refilter recomputes the matching options, best fuzzy match first.
+func (b *choiceDialogBody) refilter() {
+	b.matches = b.matches[:0]
+	if pattern := b.filter.Value(); pattern != "" {
+		for _, m := range fuzzy.Find(pattern, b.options) {
+			b.matches = append(b.matches, m.Index)
+		}
+	} else {
+		for i := range b.options {
+			b.matches = append(b.matches, i)
+		}
+	}
+	b.cursor, b.offset = 0, 0
+}
+
+// chosen returns the option indices the dialog would confirm, in option order.
+func (b *choiceDialogBody) chosen() []int {
+	var indices []int
+	if b.multi {
+		for i := range b.options {
+			if b.selected[i] {
+				indices = append(indices, i)
+			}
+		}
+	}
+	if len(indices) == 0 && b.cursor < len(b.matches) {
+		indices = []int{b.matches[b.cursor]}
+	}
+	return indices
+}
+
+func (b *choiceDialogBody) rows() int {
+	return max(1, b.height-1) // the filter input takes the first row
+}
+
+func (b *choiceDialogBody) moveCursor(delta int) {
+	if len(b.matches) == 0 {
+		return
+	}
+	b.cursor = clampInt(b.cursor+delta, 0, len(b.matches)-1)
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	} else if b.cursor >= b.offset+b.rows() {
+		b.offset = b.cursor - b.rows() + 1
+	}
+}
+
+func (b *choiceDialogBody) toggle() {
+	if b.multi && b.cursor < len(b.matches) {
+		idx := b.matches[b.cursor]
+		b.selected[idx] = !b.selected[idx]
+	}
+}
+
+func (b *choiceDialogBody) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "ctrl+p":
+			b.moveCursor(-1)
+			return nil
+		case "down", "ctrl+n":
+			b.moveCursor(1)
+			return nil
+		case "pgup":
+			b.moveCursor(-b.rows())
+			return nil
+		case "pgdown", "pgdn":
+			b.moveCursor(b.rows())
+			return nil
+		case "tab":
+			b.toggle()
+			b.moveCursor(1)
+			return nil
+		case "enter":
+			b.submitted = len(b.chosen()) > 0
+			return nil
+		}
+		before := b.filter.Value()
+		var cmd tea.Cmd
+		b.filter, cmd = b.filter.Update(msg)
+		if b.filter.Value() != before {
+			b.refilter()
+		}
+		return cmd
+	case tea.MouseClickMsg:
+		if msg.Button == tea.MouseLeft && msg.Y >= 1 {
+			if i := b.offset + msg.Y - 1; i < len(b.matches) {
+				b.cursor = i
+				b.toggle()
+			}
+		}
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			b.moveCursor(-1)
+		case tea.MouseWheelDown:
+			b.moveCursor(1)
+		}
+	}
+	var cmd tea.Cmd
+	b.filter, cmd = b.filter.Update(msg)
+	return cmd
+}
+
+func (b *choiceDialogBody) View() string {
+	styles := style.CurrentStyleSet()
+	b.filter.SetStyles(dialogInputStyles(styles))
+	lines := []string{b.filter.View()}
+	if len(b.matches) == 0 {
+		lines = append(lines, styles.Muted.Render(T(MsgNoData)))
+	}
+	end := min(len(b.matches), b.offset+b.rows())
+	for i := b.offset; i < end; i++ {
+		idx := b.matches[i]
+		text := b.options[idx]
+		if b.multi {
+			mark := "[ ] "
+			if b.selected[idx] {
+				mark = "[x] "
+			}
+			text = mark + text
+		}
+		itemStyle := styles.MenuItem
+		if i == b.cursor {
+			itemStyle = styles.SelectedItem
+		}
+		lines = append(lines, itemStyle.Render(text))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (b *choiceDialogBody) SetSize(width, height int) {
+	b.height = height
+	b.filter.SetWidth(max(1, width-lipgloss.Width(b.filter.Prompt)-1))
+	b.moveCursor(0)
+}
+
+func (b *choiceDialogBody) Focus() {
+	b.filter.Focus()
+}
+
+func (b *choiceDialogBody) Submitted() bool {
+	return b.submitted
+}
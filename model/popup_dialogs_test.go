@@ -0,0 +1,131 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func typeKeys(p *Popup, text string) {
+	for _, r := range text {
+		p.update(tea.KeyPressMsg(tea.Key{Code: r, Text: string(r)}))
+	}
+}
+
+func TestConfirmPopupResults(t *testing.T) {
+	var got []ConfirmResult
+	newConfirm := func() *Popup {
+		p, err := NewConfirmPopup(ConfirmPopupSpec{ID: "del", Title: "Delete", Message: "Sure?", OnResult: func(r ConfirmResult) { got = append(got, r) }})
+		if err != nil {
+			t.Fatalf("NewConfirmPopup() error = %v", err)
+		}
+		return p
+	}
+
+	p := newConfirm()
+	if p.actions[0].Label != T(MsgYes) || p.actions[1].Label != T(MsgNo) {
+		t.Fatalf("actions = %+v", p.actions)
+	}
+	p.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	p.complete(nil)
+
+	p = newConfirm()
+	p.dismissAction(1, PopupDismissAction)
+	p.complete(nil)
+
+	p = newConfirm()
+	p.dismissOutside()
+	p.complete(nil)
+
+	want := []ConfirmResult{{ID: "del", Confirmed: true}, {ID: "del"}, {ID: "del", Cancelled: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("results = %+v, want %+v", got, want)
+	}
+}
+
+func TestInputPopupValidatesAndMasks(t *testing.T) {
+	p, err := NewInputPopup(InputPopupSpec{
+		ID:       "pw",
+		Title:    "Password",
+		Password: true,
+		Validate: func(s string) error {
+			if len(s) < 3 {
+				return errors.New("too short")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewInputPopup() error = %v", err)
+	}
+	styles := style.NewStyleSet(style.DefaultDarkTheme()).Popup
+
+	typeKeys(p, "ab")
+	p.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	p.dismissAction(0, PopupDismissAction) // clicking Confirm is validated too
+	if p.dismissed() {
+		t.Fatal("invalid input should keep the dialog open")
+	}
+	view := ansi.Strip(p.render(styles).content)
+	if !strings.Contains(view, "too short") || strings.Contains(view, "ab") {
+		t.Fatalf("want the error and a masked value:\n%s", view)
+	}
+
+	typeKeys(p, "c")
+	p.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	_, cmd := p.complete(nil)
+	if cmd == nil {
+		t.Fatal("without OnResult the result should be delivered as a message")
+	}
+	if msg := cmd(); msg != (InputResult{ID: "pw", Value: "abc"}) {
+		t.Fatalf("message = %#v", msg)
+	}
+
+	p, _ = NewInputPopup(InputPopupSpec{Value: "kept"})
+	p.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))
+	if _, cmd := p.complete(nil); cmd() != (InputResult{Cancelled: true}) {
+		t.Fatal("escape should deliver a cancelled result without the value")
+	}
+}
+
+func TestChoicePopupFiltersAndMultiSelects(t *testing.T) {
+	var got ChoiceResult
+	p, err := NewChoicePopup(ChoicePopupSpec{
+		Title:    "Genres",
+		Options:  []string{"Rock", "Jazz", "Pop", "Punk rock"},
+		Multi:    true,
+		Selected: []int{1},
+		OnResult: func(r ChoiceResult) { got = r },
+	})
+	if err != nil {
+		t.Fatalf("NewChoicePopup() error = %v", err)
+	}
+	styles := style.NewStyleSet(style.DefaultDarkTheme()).Popup
+	p.render(styles)
+
+	typeKeys(p, "rk")
+	view := ansi.Strip(p.render(styles).content)
+	if !strings.Contains(view, "Rock") || !strings.Contains(view, "Punk rock") || strings.Contains(view, "Pop") {
+		t.Fatalf("filtered list:\n%s", view)
+	}
+	p.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyTab})) // toggles the best match, "Rock"
+	p.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	p.complete(nil)
+	if !reflect.DeepEqual(got.Indices, []int{0, 1}) || !reflect.DeepEqual(got.Values, []string{"Rock", "Jazz"}) {
+		t.Fatalf("result = %+v", got)
+	}
+
+	single, _ := NewChoicePopup(ChoicePopupSpec{Options: []string{"a", "b", "c"}, OnResult: func(r ChoiceResult) { got = r }})
+	single.render(styles)
+	single.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+	single.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	single.complete(nil)
+	if !reflect.DeepEqual(got.Values, []string{"b"}) || got.Cancelled {
+		t.Fatalf("single result = %+v", got)
+	}
+}
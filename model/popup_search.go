@@ -0,0 +1,254 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/style"
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Find-in-content for text popups: / or ctrl+f opens a search bar at the
+// bottom of the popup. While typing, enter confirms the query, alt+c toggles
+// case sensitivity and alt+r regex mode; afterwards n/N jump between matches
+// and / edits the query again. Esc closes the search.
+
+const (
+	popupSearchKey      = "/"
+	popupSearchAltKey   = "ctrl+f"
+	popupSearchCaseKey  = "alt+c"
+	popupSearchRegexKey = "alt+r"
+)
+
+// popupSearchMatch is one match in full-content space: the display columns
+// [left, right) of line.
+type popupSearchMatch struct {
+	line, left, right int
+}
+
+// popupSearch is the search state of a Popup.
+type popupSearch struct {
+	open          bool // the search bar is shown
+	typing        bool // the search bar has keyboard focus
+	input         textinput.Model
+	caseSensitive bool
+	regex         bool
+
+	matches []popupSearchMatch
+	current int   // index into matches
+	err     error // invalid regex
+	reveal  bool  // scroll the current match into view on the next render
+}
+
+// searchable reports whether the popup's content can be searched.
+func (p *Popup) searchable() bool {
+	return p.body == nil && len(p.contentLines) > 0
+}
+
+// openSearch shows the search bar and focuses it.
+func (p *Popup) openSearch() tea.Cmd {
+	if !p.search.open {
+		p.search.input = textinput.New()
+		p.search.input.Prompt = popupSearchKey
+		p.search.input.CharLimit = 256
+		p.search.open = true
+	}
+	p.search.typing = true
+	return p.search.input.Focus()
+}
+
+// closeSearch hides the search bar and drops the matches.
+func (p *Popup) closeSearch() {
+	p.search = popupSearch{caseSensitive: p.search.caseSensitive, regex: p.search.regex}
+}
+
+// updateSearch handles the search keys. It returns false for keys it doesn't
+// consume.
+func (p *Popup) updateSearch(msg tea.KeyMsg) (bool, tea.Cmd) {
+	key := msg.String()
+	if !p.search.open {
+		if (key == popupSearchKey || key == popupSearchAltKey) && p.searchable() {
+			p.clearSelection()
+			return true, p.openSearch()
+		}
+		return false, nil
+	}
+	if !p.search.typing {
+		switch key {
+		case "n":
+			p.stepSearch(1)
+		case "N":
+			p.stepSearch(-1)
+		case popupSearchKey, popupSearchAltKey:
+			return true, p.openSearch()
+		case "esc":
+			p.closeSearch()
+		default:
+			return false, nil
+		}
+		return true, nil
+	}
+
+	switch key {
+	case "esc":
+		p.closeSearch()
+		return true, nil
+	case "enter":
+		p.search.typing = false
+		p.search.input.Blur()
+		if p.search.input.Value() == "" {
+			p.closeSearch()
+		}
+		return true, nil
+	case popupSearchCaseKey:
+		p.search.caseSensitive = !p.search.caseSensitive
+	case popupSearchRegexKey:
+		p.search.regex = !p.search.regex
+	default:
+		var cmd tea.Cmd
+		before := p.search.input.Value()
+		p.search.input, cmd = p.search.input.Update(msg)
+		if p.search.input.Value() == before {
+			return true, cmd
+		}
+		p.refreshSearch(true)
+		return true, cmd
+	}
+	p.refreshSearch(true)
+	return true, nil
+}
+
+// refreshSearch recomputes the matches against the plain text of
+// contentLines. With jump set, the current match becomes the first one at or
+// below the top visible line; otherwise the current index is kept in range.
+func (p *Popup) refreshSearch(jump bool) {
+	s := &p.search
+	s.matches, s.err = nil, nil
+	query := s.input.Value()
+	if !s.open || query == "" {
+		s.current = 0
+		return
+	}
+	pattern := query
+	if !s.regex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if !s.caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		s.err = err
+		s.current = 0
+		return
+	}
+	for i, line := range p.contentLines {
+		plain := ansi.Strip(line)
+		for _, loc := range re.FindAllStringIndex(plain, -1) {
+			if loc[0] == loc[1] {
+				continue // empty matches can't be highlighted
+			}
+			left := ansi.StringWidth(plain[:loc[0]])
+			s.matches = append(s.matches, popupSearchMatch{line: i, left: left, right: left + ansi.StringWidth(plain[loc[0]:loc[1]])})
+		}
+	}
+	if !jump {
+		s.current = clampInt(s.current, 0, max(len(s.matches)-1, 0))
+		return
+	}
+	s.current = 0
+	for i, m := range s.matches {
+		if m.line >= p.scrollOffset {
+			s.current = i
+			break
+		}
+	}
+	s.reveal = len(s.matches) > 0
+}
+
+// stepSearch moves to the next (dir 1) or previous (dir -1) match, wrapping
+// around.
+func (p *Popup) stepSearch(dir int) {
+	n := len(p.search.matches)
+	if n == 0 {
+		return
+	}
+	p.clearSelection()
+	p.search.current = (p.search.current + dir + n) % n
+	p.search.reveal = true
+}
+
+// revealSearchMatch scrolls the current match into view once the visible
+// height of this render is known.
+func (p *Popup) revealSearchMatch() {
+	if !p.search.reveal || len(p.search.matches) == 0 {
+		return
+	}
+	p.search.reveal = false
+	line := p.search.matches[p.search.current].line
+	if line < p.scrollOffset {
+		p.scrollOffset = line
+	} else if line >= p.scrollOffset+p.visibleContentLines {
+		p.scrollOffset = line - p.visibleContentLines + 1
+	}
+	p.scrollOffset = clampInt(p.scrollOffset, 0, p.maxScrollOffset())
+}
+
+// applySearchHighlight returns a copy of the visible lines with every match
+// reverse-videoed; the current match is also bold.
+// visibleLines[k] maps to full-content line scrollOffset+k.
+func (p *Popup) applySearchHighlight(visibleLines []string) []string {
+	out := append([]string(nil), visibleLines...)
+	last := p.scrollOffset + len(visibleLines)
+	for i, m := range p.search.matches {
+		if m.line < p.scrollOffset || m.line >= last {
+			continue
+		}
+		k := m.line - p.scrollOffset
+		if i == p.search.current {
+			out[k] = highlightColumnsAttrs(out[k], m.left, m.right, uv.AttrReverse|uv.AttrBold)
+		} else {
+			out[k] = highlightColumns(out[k], m.left, m.right)
+		}
+	}
+	return out
+}
+
+// renderSearchBar renders the search bar row: the query on the left and the
+// match counter and mode toggles on the right.
+func (p *Popup) renderSearchBar(styles style.PopupStyleSet, width int) string {
+	set := style.CurrentStyleSet()
+	s := &p.search
+
+	var status string
+	switch {
+	case s.err != nil:
+		status = set.Error.Render(T(MsgPopupSearchInvalid))
+	case s.input.Value() == "":
+		status = ""
+	case len(s.matches) == 0:
+		status = set.Muted.Render(T(MsgPopupSearchNoMatches))
+	default:
+		status = set.Normal.Render(fmt.Sprintf("%d/%d", s.current+1, len(s.matches)))
+	}
+	toggle := func(label string, on bool) string {
+		if on {
+			return set.Prompt.Render(label)
+		}
+		return set.Muted.Render(label)
+	}
+	right := strings.TrimSpace(status + " " + toggle("Aa", s.caseSensitive) + " " + toggle(".*", s.regex))
+
+	inputW := max(1, width-lipgloss.Width(right)-1)
+	s.input.SetStyles(dialogInputStyles(set))
+	s.input.SetWidth(max(1, inputW-lipgloss.Width(s.input.Prompt)-1))
+	left := ansi.Truncate(s.input.View(), inputW, "")
+	gap := max(1, width-lipgloss.Width(left)-lipgloss.Width(right))
+	bar := ansi.Truncate(left+strings.Repeat(" ", gap)+right, width, "")
+	return fillMissingBackground(lipgloss.NewStyle().Width(width).Render(bar), styles.Surface)
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func typeSearch(p *Popup, text string) {
+	for _, r := range text {
+		p.update(tea.KeyPressMsg(tea.Key{Code: r, Text: string(r)}))
+	}
+}
+
+func TestPopupSearchHighlightsAndNavigates(t *testing.T) {
+	lines := make([]string, 30)
+	for i := range lines {
+		lines[i] = "line"
+	}
+	lines[3] = "Foo here"
+	lines[20] = "and \x1b[1mfoo\x1b[0m again, FOO"
+	popup, err := NewPopup(PopupSpec{Content: strings.Join(lines, "\n"), MaxHeight: 10})
+	if err != nil {
+		t.Fatalf("NewPopup() error = %v", err)
+	}
+	styles := style.NewStyleSet(style.DefaultDarkTheme()).Popup
+	popup.render(styles)
+
+	popup.update(tea.KeyPressMsg(tea.Key{Code: '/', Text: "/"}))
+	typeSearch(popup, "foo")
+	if got := len(popup.search.matches); got != 3 {
+		t.Fatalf("case-insensitive matches = %d, want 3", got)
+	}
+	if m := popup.search.matches[1]; m.line != 20 || m.left != 4 || m.right != 7 {
+		t.Fatalf("styled match = %+v, want line 20 columns [4, 7)", m)
+	}
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	popup.update(tea.KeyPressMsg(tea.Key{Code: 'n', Text: "n"}))
+	out := ansi.Strip(popup.render(styles).content)
+	if popup.scrollOffset == 0 || !strings.Contains(out, "and foo again") || !strings.Contains(out, "2/3") {
+		t.Fatalf("n should scroll to the second match (offset %d):\n%s", popup.scrollOffset, out)
+	}
+	popup.update(tea.KeyPressMsg(tea.Key{Code: 'N', Text: "N"}))
+	popup.update(tea.KeyPressMsg(tea.Key{Code: 'N', Text: "N"}))
+	if popup.search.current != 2 {
+		t.Fatalf("N should wrap around to the last match, current = %d", popup.search.current)
+	}
+
+	// Case-sensitive regex mode.
+	popup.update(tea.KeyPressMsg(tea.Key{Code: '/', Text: "/"}))
+	popup.update(tea.KeyPressMsg(tea.Key{Code: 'c', Mod: tea.ModAlt}))
+	popup.update(tea.KeyPressMsg(tea.Key{Code: 'r', Mod: tea.ModAlt}))
+	for range 3 {
+		popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyBackspace}))
+	}
+	typeSearch(popup, "F[oO]+")
+	if len(popup.search.matches) != 2 || popup.search.err != nil {
+		t.Fatalf("regex matches = %+v, err = %v", popup.search.matches, popup.search.err)
+	}
+	typeSearch(popup, "(")
+	if popup.search.err == nil || !strings.Contains(ansi.Strip(popup.render(styles).content), T(MsgPopupSearchInvalid)) {
+		t.Fatal("an invalid regex should be reported in the search bar")
+	}
+
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))
+	if popup.search.open || popup.dismissed() {
+		t.Fatal("esc should close the search before the popup")
+	}
+}
@@ -0,0 +1,206 @@
+package model
+
+import (
+	"maps"
+	"time"
+)
+
+// Popup window management from the keyboard: the window mode key (ctrl+w by
+// default, see Options.PopupWindowModeKey) enters window mode, where the arrow
+// keys move the popup, shift+arrows resize it, m toggles maximize and enter,
+// esc or the window mode key leave; the maximize key (alt+m by default)
+// toggles maximize directly. Popups with a Body leave the default ctrl+w to
+// the body, since it deletes a word in text fields; a key configured
+// explicitly is handled before the body. Double-clicking the title toggles
+// maximize with the mouse.
+
+const (
+	popupWindowModeKey  = "ctrl+w"
+	popupMaximizeKey    = "alt+m"
+	popupMaximizeMargin = 1 // cells kept free around a maximized popup
+
+	// Smallest size reachable by resizing, with the mouse or the keyboard.
+	popupMinWidth  = popupFrameHorizontalOverhead + 10 // enough for buttons
+	popupMinHeight = popupFrameVerticalOverhead + 3    // title + 1 line + buttons
+
+	popupDoubleClickInterval = 400 * time.Millisecond
+)
+
+// PopupGeometry is the remembered placement of a popup with a GeometryKey.
+// Width and Height are the whole-popup size limits (0 = unlimited); when
+// Maximized is set they hold the size to restore to.
+type PopupGeometry struct {
+	OffsetX   int
+	OffsetY   int
+	Width     int
+	Height    int
+	Maximized bool
+}
+
+// popupRestore holds what maximizing replaced.
+type popupRestore struct {
+	offsetX, offsetY    int
+	maxWidth, maxHeight int
+}
+
+// toggleMaximize maximizes the popup to the terminal minus a margin, or
+// restores its previous geometry.
+func (p *Popup) toggleMaximize() {
+	p.clearSelection()
+	if p.maximized {
+		p.maximized = false
+		p.offsetX, p.offsetY = p.restore.offsetX, p.restore.offsetY
+		p.maxWidth, p.maxHeight = p.restore.maxWidth, p.restore.maxHeight
+		return
+	}
+	p.restore = popupRestore{offsetX: p.offsetX, offsetY: p.offsetY, maxWidth: p.maxWidth, maxHeight: p.maxHeight}
+	p.maximized = true
+	p.fitMaximized()
+}
+
+// fitMaximized sizes a maximized popup to the current terminal, which may
+// have changed since it was maximized.
+func (p *Popup) fitMaximized() {
+	if !p.maximized || p.termWidth <= 0 || p.termHeight <= 0 {
+		return
+	}
+	p.maxWidth = max(popupMinWidth, p.termWidth-2*popupMaximizeMargin)
+	p.maxHeight = max(popupMinHeight, p.termHeight-2*popupMaximizeMargin)
+}
+
+// updateWindowMode handles the window management keys. It returns false for
+// keys it doesn't consume.
+func (p *Popup) updateWindowMode(key string) bool {
+	if !p.windowMode {
+		switch {
+		case key == "":
+			return false
+		case key == p.windowModeKey && (p.body == nil || key != popupWindowModeKey):
+			p.windowMode = true
+			return true
+		case key == p.maximizeKey:
+			p.toggleMaximize()
+			return true
+		}
+		return false
+	}
+
+	switch key {
+	case "enter", "esc", p.windowModeKey:
+		p.windowMode = false
+	case "m", p.maximizeKey:
+		p.toggleMaximize()
+	case "left":
+		p.moveBy(-1, 0)
+	case "right":
+		p.moveBy(1, 0)
+	case "up":
+		p.moveBy(0, -1)
+	case "down":
+		p.moveBy(0, 1)
+	case "shift+left":
+		p.resizeBy(-1, 0)
+	case "shift+right":
+		p.resizeBy(1, 0)
+	case "shift+up":
+		p.resizeBy(0, -1)
+	case "shift+down":
+		p.resizeBy(0, 1)
+	}
+	// Window mode swallows every other key.
+	return true
+}
+
+// moveBy moves the popup one step if it stays within the terminal.
+func (p *Popup) moveBy(dx, dy int) {
+	if p.maximized || !p.boundsSet {
+		return
+	}
+	x, y := p.bounds.x+dx, p.bounds.y+dy
+	if x < 0 || y < 0 || x+p.bounds.w > p.termWidth || y+p.bounds.h > p.termHeight {
+		return
+	}
+	p.offsetX += dx
+	p.offsetY += dy
+}
+
+// resizeBy grows or shrinks the popup's right/bottom edge, between the
+// minimum size and the terminal edge.
+func (p *Popup) resizeBy(dw, dh int) {
+	if p.maximized || !p.boundsSet {
+		return
+	}
+	if dw != 0 {
+		p.maxWidth = clampInt(p.bounds.w+dw, popupMinWidth, max(popupMinWidth, p.termWidth-p.bounds.x))
+	}
+	if dh != 0 {
+		p.maxHeight = clampInt(p.bounds.h+dh, popupMinHeight, max(popupMinHeight, p.termHeight-p.bounds.y))
+	}
+}
+
+// titleDoubleClicked records a click on the title bar and reports whether it
+// completes a double click.
+func (p *Popup) titleDoubleClicked(now time.Time) bool {
+	double := !p.lastTitleClick.IsZero() && now.Sub(p.lastTitleClick) <= popupDoubleClickInterval
+	p.lastTitleClick = now
+	if double {
+		p.lastTitleClick = time.Time{}
+	}
+	return double
+}
+
+// geometry returns the popup's current geometry for remembering.
+func (p *Popup) geometry() PopupGeometry {
+	if p.maximized {
+		return PopupGeometry{OffsetX: p.restore.offsetX, OffsetY: p.restore.offsetY, Width: p.restore.maxWidth, Height: p.restore.maxHeight, Maximized: true}
+	}
+	return PopupGeometry{OffsetX: p.offsetX, OffsetY: p.offsetY, Width: p.maxWidth, Height: p.maxHeight}
+}
+
+// applyGeometry restores a remembered geometry.
+func (p *Popup) applyGeometry(g PopupGeometry) {
+	p.offsetX, p.offsetY = g.OffsetX, g.OffsetY
+	p.maxWidth, p.maxHeight = g.Width, g.Height
+	if g.Maximized {
+		p.toggleMaximize()
+	}
+}
+
+// applyPopupWindowKeys gives a popup being shown the configured window
+// management keys.
+func (a *App) applyPopupWindowKeys(m Modal) {
+	if p, ok := m.(*Popup); ok && a.options != nil {
+		p.windowModeKey, p.maximizeKey = a.options.PopupWindowModeKey, a.options.PopupMaximizeKey
+	}
+}
+
+// restorePopupGeometry applies the remembered geometry of a popup being shown.
+func (a *App) restorePopupGeometry(m Modal) {
+	if p, ok := m.(*Popup); ok && p.geometryKey != "" {
+		if g, ok := a.popupGeometries[p.geometryKey]; ok {
+			p.applyGeometry(g)
+		}
+	}
+}
+
+// rememberPopupGeometry stores the geometry of a popup leaving the stack.
+func (a *App) rememberPopupGeometry(m Modal) {
+	if p, ok := m.(*Popup); ok && p.geometryKey != "" {
+		if a.popupGeometries == nil {
+			a.popupGeometries = make(map[string]PopupGeometry)
+		}
+		a.popupGeometries[p.geometryKey] = p.geometry()
+	}
+}
+
+// PopupGeometries returns the remembered geometry of every popup shown with a
+// PopupSpec.GeometryKey, e.g. to save it with the application's settings.
+func (a *App) PopupGeometries() map[string]PopupGeometry {
+	return maps.Clone(a.popupGeometries)
+}
+
+// SetPopupGeometries replaces the remembered popup geometries, e.g. with ones
+// loaded from the application's settings. Applies to popups shown afterwards.
+func (a *App) SetPopupGeometries(geometries map[string]PopupGeometry) {
+	a.popupGeometries = maps.Clone(geometries)
+}
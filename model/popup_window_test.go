@@ -0,0 +1,137 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func showWindowTestPopup(t *testing.T, app *App, spec PopupSpec) (*Popup, func()) {
+	t.Helper()
+	popup, err := NewPopup(spec)
+	if err != nil {
+		t.Fatalf("NewPopup() error = %v", err)
+	}
+	app.ShowPopup(popup)
+	base := strings.TrimSuffix(strings.Repeat(strings.Repeat(" ", 60)+"\n", 20), "\n")
+	render := func() { app.compositeModals(base) }
+	render()
+	return popup, render
+}
+
+func TestPopupKeyboardWindowMode(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	app.windowWidth, app.windowHeight = 60, 20
+	popup, render := showWindowTestPopup(t, app, PopupSpec{Content: "text", Anchor: AnchorTopLeft, MaxWidth: 20, MaxHeight: 6})
+
+	popup.update(tea.KeyPressMsg(tea.Key{Code: 'w', Mod: tea.ModCtrl}))
+	if !popup.windowMode {
+		t.Fatal("ctrl+w should enter window mode")
+	}
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyLeft})) // blocked by the left edge
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyRight}))
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyRight, Mod: tea.ModShift}))
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyUp, Mod: tea.ModShift}))
+	render()
+	if x, y, w, h := popup.Bounds(); x != 1 || y != 1 || w != 21 || h != 5 {
+		t.Fatalf("bounds = %d,%d %dx%d; want 1,1 21x5", x, y, w, h)
+	}
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))
+	if popup.windowMode || popup.dismissed() {
+		t.Fatal("esc should only leave window mode")
+	}
+
+	popup.update(tea.KeyPressMsg(tea.Key{Code: 'm', Mod: tea.ModAlt}))
+	render()
+	if x, y, w, h := popup.Bounds(); x != 1 || y != 1 || w != 58 || h != 18 {
+		t.Fatalf("maximized bounds = %d,%d %dx%d; want 1,1 58x18", x, y, w, h)
+	}
+	popup.update(tea.KeyPressMsg(tea.Key{Code: 'm', Mod: tea.ModAlt}))
+	render()
+	if _, _, w, h := popup.Bounds(); w != 21 || h != 5 {
+		t.Fatalf("restored size = %dx%d, want 21x5", w, h)
+	}
+	// A body keeps ctrl+w, which deletes a word in its text input.
+	input, err := NewInputPopup(InputPopupSpec{Title: "Name", Value: "foo bar"})
+	if err != nil {
+		t.Fatalf("NewInputPopup() error = %v", err)
+	}
+	app.ShowPopup(input)
+	input.update(tea.KeyPressMsg(tea.Key{Code: 'w', Mod: tea.ModCtrl}))
+	if body := input.body.(*inputDialogBody); input.windowMode || body.input.Value() != "foo " {
+		t.Fatalf("ctrl+w should reach the body: windowMode=%v value=%q", input.windowMode, body.input.Value())
+	}
+}
+
+func TestPopupWindowKeysFromOptions(t *testing.T) {
+	ops := DefaultOptions()
+	ops.PopupWindowModeKey = "f7"
+	ops.PopupMaximizeKey = ""
+	app := NewApp(ops)
+	app.windowWidth, app.windowHeight = 60, 20
+	popup, _ := showWindowTestPopup(t, app, PopupSpec{Content: "text", MaxWidth: 20, MaxHeight: 6})
+
+	popup.update(tea.KeyPressMsg(tea.Key{Code: 'm', Mod: tea.ModAlt}))
+	popup.update(tea.KeyPressMsg(tea.Key{Code: 'w', Mod: tea.ModCtrl}))
+	if popup.maximized || popup.windowMode {
+		t.Fatal("the default keys should be replaced by the configured ones")
+	}
+	popup.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyF7}))
+	if !popup.windowMode {
+		t.Fatal("the configured key should enter window mode")
+	}
+
+	// Unlike the default ctrl+w, a configured key is not left to a body.
+	input, err := NewInputPopup(InputPopupSpec{Title: "Name", Value: "foo"})
+	if err != nil {
+		t.Fatalf("NewInputPopup() error = %v", err)
+	}
+	app.ShowPopup(input)
+	input.update(tea.KeyPressMsg(tea.Key{Code: tea.KeyF7}))
+	if !input.windowMode {
+		t.Fatal("the configured key should enter window mode in a body popup")
+	}
+	input.update(tea.KeyPressMsg(tea.Key{Code: 'w', Mod: tea.ModCtrl}))
+	if body := input.body.(*inputDialogBody); body.input.Value() != "foo" {
+		t.Fatalf("window mode should swallow keys, value = %q", body.input.Value())
+	}
+}
+
+func TestPopupTitleDoubleClickAndGeometryPersistence(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	app.page = &notificationMouseSpyPage{}
+	app.windowWidth, app.windowHeight = 60, 20
+	spec := PopupSpec{Title: "Lyrics", Content: "la la", GeometryKey: "lyrics"}
+	popup, render := showWindowTestPopup(t, app, spec)
+
+	x, y, _, _ := popup.Bounds()
+	click := tea.MouseClickMsg(tea.Mouse{X: x + 2, Y: y, Button: tea.MouseLeft})
+	release := tea.MouseReleaseMsg(tea.Mouse{X: x + 2, Y: y})
+	app.Update(click)
+	app.Update(release)
+	app.Update(click)
+	app.Update(release)
+	if !popup.maximized {
+		t.Fatal("double-clicking the title should maximize")
+	}
+	render()
+	app.DismissPopup()
+
+	saved := app.PopupGeometries()["lyrics"]
+	if !saved.Maximized {
+		t.Fatalf("remembered geometry = %+v", saved)
+	}
+	reopened, _ := showWindowTestPopup(t, app, spec)
+	if _, _, w, _ := reopened.Bounds(); !reopened.maximized || w != 58 {
+		t.Fatal("a popup with the same key should reopen maximized")
+	}
+
+	app.DismissPopup()
+	app.SetPopupGeometries(map[string]PopupGeometry{"lyrics": {OffsetX: 3, Width: 30, Height: 8}})
+	loaded, _ := showWindowTestPopup(t, app, spec)
+	if _, _, w, h := loaded.Bounds(); loaded.maximized || loaded.offsetX != 3 || w != 30 || h != 8 {
+		t.Fatalf("loaded geometry: offset %d, %dx%d", loaded.offsetX, w, h)
+	}
+}
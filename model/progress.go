@@ -4,6 +4,7 @@ import (
 	"image/color"
 	"strings"
 
+	"charm.land/lipgloss/v2"
 	"github.com/anhoder/foxful-cli/style"
 )
 
@@ -31,13 +32,14 @@ func Progress(options *ProgressOptions, width, fullSize int, progressRamp []colo
 	// Resolve the app background once. Both filled and empty cells are painted
 	// over it (component→app→transparent chain) so progress-bar cells never stay
 	// transparent and reveal content drawn beneath the TUI.
-	appBg := style.CurrentStyleSet().AppBackground.GetBackground()
-	fullCell := func(char string, c color.Color) string {
-		if appBg != nil {
-			return style.FGBG(char, c, appBg)
-		}
-		return style.FG(char, c)
-	}
+	return renderProgress(options, width, fullSize, progressRamp, style.CurrentStyleSet().AppBackground.GetBackground())
+}
+
+// renderProgress is Progress painted over bg instead of the app background,
+// for bars drawn on a surface such as a notification. A nil bg leaves the
+// cells transparent.
+func renderProgress(options *ProgressOptions, width, fullSize int, progressRamp []color.Color, bg color.Color) string {
+	fullCell := progressFullCell(bg)
 
 	var fullCells strings.Builder
 	for i := 0; i < fullSize && i < len(progressRamp); i++ {
@@ -72,11 +74,53 @@ func Progress(options *ProgressOptions, width, fullSize int, progressRamp []colo
 			emptyCells.WriteRune(options.EmptyCharWhenLast)
 		}
 	}
-	// ProgressEmpty carries the empty-cell foreground; inherit the app background
+	return fullCells.String() + progressEmptyStyle(bg).Render(emptyCells.String())
+}
+
+// indeterminateProgress renders a bar of the given width whose filled segment
+// (a quarter of the width) bounces back and forth as phase advances.
+func indeterminateProgress(options *ProgressOptions, width, phase int, progressRamp []color.Color, bg color.Color) string {
+	if width <= 0 {
+		return ""
+	}
+	segment := max(1, width/4)
+	span := width - segment
+	pos := 0
+	if span > 0 {
+		pos = phase % (2 * span)
+		if pos > span {
+			pos = 2*span - pos
+		}
+	}
+
+	fullCell := progressFullCell(bg)
+	emptyStyle := progressEmptyStyle(bg)
+	var b strings.Builder
+	b.WriteString(emptyStyle.Render(strings.Repeat(string(options.EmptyChar), pos)))
+	for i := pos; i < pos+segment && i < len(progressRamp); i++ {
+		b.WriteString(fullCell(string(options.FullChar), progressRamp[i]))
+	}
+	b.WriteString(emptyStyle.Render(strings.Repeat(string(options.EmptyChar), width-pos-segment)))
+	return b.String()
+}
+
+// progressFullCell returns a renderer for filled cells painted over bg.
+func progressFullCell(bg color.Color) func(char string, c color.Color) string {
+	return func(char string, c color.Color) string {
+		if bg != nil {
+			return style.FGBG(char, c, bg)
+		}
+		return style.FG(char, c)
+	}
+}
+
+// progressEmptyStyle returns the empty-cell style painted over bg.
+func progressEmptyStyle(bg color.Color) lipgloss.Style {
+	// ProgressEmpty carries the empty-cell foreground; inherit the background
 	// so the empty region is opaque when a theme sets an app background.
 	emptyStyle := style.CurrentStyleSet().ProgressEmpty
-	if appBg != nil {
-		emptyStyle = emptyStyle.Background(appBg)
+	if bg != nil {
+		emptyStyle = emptyStyle.Background(bg)
 	}
-	return fullCells.String() + emptyStyle.Render(emptyCells.String())
+	return emptyStyle
 }
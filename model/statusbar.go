@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,7 +28,7 @@ type StatusBar interface {
 }
 
 // StatusBarComponent is a renderable module injected into DefaultStatusBar's
-// centered content area.
+// content area (centered unless it implements ZonedStatusBarComponent).
 type StatusBarComponent interface {
 	View(a *App, m *Main) string
 }
@@ -40,8 +41,22 @@ type InteractiveStatusBarComponent interface {
 	IsMouseOver(x, y int) bool
 }
 
+// ContextMenuStatusBarComponent is an InteractiveStatusBarComponent with a
+// context menu, opened by right-clicking it.
+type ContextMenuStatusBarComponent interface {
+	InteractiveStatusBarComponent
+	// StatusBarContextMenuItems returns the items to show. Return nil to show
+	// no context menu.
+	StatusBarContextMenuItems(app *App) []ContextMenuItem
+	// StatusBarContextMenuAction is called when an item is selected.
+	StatusBarContextMenuAction(app *App, item ContextMenuItem) (Page, tea.Cmd)
+}
+
 // DefaultStatusBar shows a "PATH" nugget, the breadcrumb path on bar background,
-// injected centered components, and the current time on the right.
+// injected components, and the current time on the right. Components render
+// centered unless they implement ZonedStatusBarComponent to pick the left,
+// center or right zone; on narrow terminals low-priority segments switch to
+// their ShortStatusBarComponent form or disappear instead of being clipped.
 type DefaultStatusBar struct {
 	Components []StatusBarComponent
 
@@ -59,6 +74,10 @@ type DefaultStatusBar struct {
 	cachedBreadcrumb      string
 	cachedBreadcrumbHover int
 	cachedBounds          []statusBarComponentBounds
+
+	// segmentCache 按组件下标缓存各段渲染结果：整栏缓存失效时（例如某个
+	// 组件文本变化），其余未变化的段无需重新走 lipgloss。
+	segmentCache []statusBarSegmentCache
 }
 
 type statusBarComponentBounds struct {
@@ -78,16 +97,9 @@ func (d *DefaultStatusBar) View(a *App, m *Main) string {
 
 	// Render the injected components first — their text may change per frame.
 	// If nothing else changed, reuse the fully rendered bar.
-	componentViews := make([]string, 0, len(d.Components))
-	for _, component := range d.Components {
-		if component == nil {
-			continue
-		}
-		componentViews = append(componentViews, component.View(a, m))
-	}
+	componentInputs, componentsKey := d.collectStatusBarComponents(a, m)
 	gen := style.StyleGeneration()
 	minute := time.Now().Format("15:04")
-	componentsKey := strings.Join(componentViews, "\x00")
 	// Breadcrumb content is rebuilt from m.menuStack + m.menuTitle on every
 	// navigation, while nothing else in the key changes — so it must
 	// participate in the cache key, or entering/returning submenus keeps the
@@ -95,8 +107,12 @@ func (d *DefaultStatusBar) View(a *App, m *Main) string {
 	// switch. Hover paints a highlight, so it participates too.
 	breadcrumbKey := ""
 	breadcrumbHover := -1
+	var badge TabBadge
 	if m != nil {
-		breadcrumbHover = m.hoveredBreadcrumbIdx
+		if m.options != nil && m.options.EnableTabs && m.tabs != nil {
+			badge = m.TabBadge(m.activeTab)
+		}
+		breadcrumbHover = m.breadcrumbHighlight()
 		breadcrumbKey = m.menuTitle.Title
 		if m.menuStack != nil {
 			for _, item := range m.menuStack.ToSlice() {
@@ -105,6 +121,7 @@ func (d *DefaultStatusBar) View(a *App, m *Main) string {
 				}
 			}
 		}
+		breadcrumbKey += "\x00" + badge.label() + "\x00" + strconv.Itoa(int(badge.Level))
 	}
 	if d.cachedView != "" && d.cachedWidth == w && d.cachedMinute == minute &&
 		d.cachedStyleGen == gen && d.cachedComponents == componentsKey &&
@@ -137,7 +154,6 @@ func (d *DefaultStatusBar) View(a *App, m *Main) string {
 		timeSepLeft = " "
 	}
 	timeNugget := sepStyle.Render(timeSepLeft) + timeStyle.Render(" "+now+" ")
-	timeW := lipgloss.Width(timeNugget)
 
 	// Breadcrumb: constrain to available width so bar stays single-line.
 	// Calculate max width optimistically (without time) in case time gets hidden.
@@ -150,10 +166,19 @@ func (d *DefaultStatusBar) View(a *App, m *Main) string {
 		centerHalfWidth := w / 2
 		maxBreadcrumbW = centerHalfWidth - labelW - breadcrumbPadding
 	}
+	// The active tab's badge trails the path, so it comes out of its budget.
+	badgeView := ""
+	if !badge.IsZero() {
+		badgeView = " " + badge.style(ss).Background(breadcrumbBg).Render(badge.label())
+		maxBreadcrumbW -= lipgloss.Width(badgeView)
+	}
 	if maxBreadcrumbW < 10 {
 		maxBreadcrumbW = 10
 	}
 	path := buildBreadcrumbPath(m, ss, maxBreadcrumbW)
+	if path != "" {
+		path += badgeView
+	}
 
 	var breadcrumbBlock string
 	if path != "" {
@@ -168,88 +193,15 @@ func (d *DefaultStatusBar) View(a *App, m *Main) string {
 	}
 	bcrumbW := lipgloss.Width(breadcrumbBlock)
 
-	// Center: injected components.
-	type renderedComponent struct {
-		component StatusBarComponent
-		width     int
-	}
-	renderedComponents := make([]renderedComponent, 0, len(d.Components))
-	centerBlocks := make([]string, 0, len(d.Components))
-	compIdx := 0
-	for _, component := range d.Components {
-		if component == nil {
-			continue
-		}
-		content := componentViews[compIdx]
-		compIdx++
-		if content == "" {
-			continue
-		}
-		renderedComponents = append(renderedComponents, renderedComponent{
-			component: component,
-			width:     lipgloss.Width(content),
-		})
-		centerBlocks = append(centerBlocks, lipgloss.NewStyle().
-			Inherit(ss.StatusBarText).
-			Padding(0, 1).
-			Render(content))
-	}
-	centerBlock := lipgloss.JoinHorizontal(lipgloss.Top, centerBlocks...)
-	centerW := lipgloss.Width(centerBlock)
-
-	// If total content exceeds window width, progressively hide time then center.
-	if labelW+bcrumbW+centerW+timeW > w {
-		if timeW > 0 {
-			timeNugget = ""
-			timeW = 0
-		}
-	}
-	if labelW+bcrumbW+centerW+timeW > w {
-		if centerW > 0 {
-			centerBlock = ""
-			centerW = 0
-		}
-	}
-
-	// Layout: label + breadcrumb + leftFiller + center + rightFiller + time
+	// Zones: components (and the clock, as a low-priority right segment)
+	// collapse by priority until they fit beside the breadcrumb.
+	segments := d.renderStatusBarSegments(ss, componentInputs, gen)
+	segments = append(segments, newClockSegment(timeNugget))
 	leftUsed := labelW + bcrumbW
-	rightUsed := timeW
-
-	leftFillerW := (w-centerW)/2 - leftUsed
-	if leftFillerW < 0 {
-		leftFillerW = 0
-	}
-	rightFillerW := w - leftUsed - leftFillerW - centerW - rightUsed
-	if rightFillerW < 0 {
-		rightFillerW = 0
-		leftFillerW = w - leftUsed - centerW - rightUsed
-		if leftFillerW < 0 {
-			leftFillerW = 0
-		}
-	}
-	if centerW > 0 {
-		componentStart := leftUsed + leftFillerW
-		for _, component := range renderedComponents {
-			componentStart++ // left padding
-			d.componentBounds = append(d.componentBounds, statusBarComponentBounds{
-				component: component.component,
-				start:     componentStart,
-				end:       componentStart + component.width,
-			})
-			componentStart += component.width + 1 // content + right padding
-		}
-	}
+	zones, bounds := layoutStatusBarZones(ss, segments, leftUsed, w)
+	d.componentBounds = append(d.componentBounds, bounds...)
 
-	leftFiller := lipgloss.NewStyle().
-		Inherit(ss.StatusBarText).
-		Width(leftFillerW).
-		Render("")
-	rightFiller := lipgloss.NewStyle().
-		Inherit(ss.StatusBarText).
-		Width(rightFillerW).
-		Render("")
-
-	bar := lipgloss.JoinHorizontal(lipgloss.Top, pathLabel, breadcrumbBlock, leftFiller, centerBlock, rightFiller, timeNugget)
+	bar := pathLabel + breadcrumbBlock + zones
 	view := ss.StatusBar.Width(w).Render(bar)
 
 	// Store the rendered bar (go-musicfox 定制), including the component
@@ -266,20 +218,28 @@ func (d *DefaultStatusBar) View(a *App, m *Main) string {
 }
 
 func (d *DefaultStatusBar) handleComponentClick(mouse tea.Mouse, a *App, m *Main) (tea.Cmd, bool) {
-	if mouse.Y != m.statusBarRowY(a) {
+	bounds, ok := d.componentAt(mouse.X, mouse.Y, a, m)
+	if !ok {
 		return nil, false
 	}
+	if component, ok := bounds.component.(InteractiveStatusBarComponent); ok {
+		handled, cmd := component.HandleMouse(mouse, mouse.X-bounds.start, 0)
+		return cmd, handled
+	}
+	return nil, false
+}
+
+// componentAt returns the rendered component at the given screen position.
+func (d *DefaultStatusBar) componentAt(x, y int, a *App, m *Main) (statusBarComponentBounds, bool) {
+	if y != m.statusBarRowY(a) {
+		return statusBarComponentBounds{}, false
+	}
 	for _, bounds := range d.componentBounds {
-		if mouse.X < bounds.start || mouse.X >= bounds.end {
-			continue
-		}
-		if component, ok := bounds.component.(InteractiveStatusBarComponent); ok {
-			handled, cmd := component.HandleMouse(mouse, mouse.X-bounds.start, 0)
-			return cmd, handled
+		if x >= bounds.start && x < bounds.end {
+			return bounds, true
 		}
-		return nil, false
 	}
-	return nil, false
+	return statusBarComponentBounds{}, false
 }
 
 func (d *DefaultStatusBar) isOverComponent(x, y int, a *App, m *Main) bool {
@@ -382,7 +342,7 @@ func computeBreadcrumbSegments(m *Main) []breadcrumbSegmentInfo {
 }
 
 // buildBreadcrumbPath builds the styled breadcrumb path string for the status bar.
-// Applies hover/click effects based on m.hoveredBreadcrumbIdx.
+// Applies hover/click effects based on m.hoveredBreadcrumbIdx (or the keyboard-focused segment).
 // maxWidth is the maximum visual width allowed; 0 means no constraint.
 // When constrained, leftmost segments are dropped and replaced with "..." to keep
 // the rightmost (current) segment visible.
@@ -432,7 +392,7 @@ func buildBreadcrumbPath(m *Main, ss style.StyleSet, maxWidth int) string {
 
 	parts := make([]string, 0, len(segments))
 	for i, seg := range segments {
-		isHovered := !seg.IsLast && !seg.IsEllipsis && i == m.hoveredBreadcrumbIdx
+		isHovered := !seg.IsLast && !seg.IsEllipsis && i == m.breadcrumbHighlight()
 
 		var styled string
 		switch {
@@ -165,3 +165,117 @@ func TestDefaultStatusBarDispatchesToInjectedComponents(t *testing.T) {
 		t.Fatal("passive component is unexpectedly clickable")
 	}
 }
+
+// zonedStatusBarTestComponent is a configurable zoned component with an
+// optional short form.
+type zonedStatusBarTestComponent struct {
+	text, short string
+	layout      StatusBarSegmentLayout
+	views       int
+}
+
+func (c *zonedStatusBarTestComponent) View(*App, *Main) string {
+	c.views++
+	return c.text
+}
+func (c *zonedStatusBarTestComponent) ShortView(*App, *Main) string   { return c.short }
+func (c *zonedStatusBarTestComponent) Layout() StatusBarSegmentLayout { return c.layout }
+
+// zonedClickableStatusBarTestComponent is a clickable right-zone component.
+type zonedClickableStatusBarTestComponent struct {
+	clickableStatusBarTestComponent
+}
+
+func (c *zonedClickableStatusBarTestComponent) Layout() StatusBarSegmentLayout {
+	return StatusBarSegmentLayout{Zone: StatusBarZoneRight, Priority: 10}
+}
+
+func TestDefaultStatusBarZonesAndPriorityCollapse(t *testing.T) {
+	m := &Main{menuTitle: &MenuItem{Title: "Home"}, menuStack: &util.Stack{}, hoveredBreadcrumbIdx: -1}
+	left := &zonedStatusBarTestComponent{text: "LEFT", layout: StatusBarSegmentLayout{Zone: StatusBarZoneLeft, Priority: 5}}
+	volume := &zonedStatusBarTestComponent{text: "Volume 80%", short: "V80", layout: StatusBarSegmentLayout{Zone: StatusBarZoneRight, Order: 2, Priority: 1}}
+	song := &zonedStatusBarTestComponent{text: "A very long song title indeed", layout: StatusBarSegmentLayout{MaxWidth: 12, Priority: 3}}
+	bar := &DefaultStatusBar{Components: []StatusBarComponent{volume, song, left}}
+
+	wide := ansi.Strip(bar.View(&App{windowWidth: 120}, m))
+	if l, c, r := strings.Index(wide, "LEFT"), strings.Index(wide, "A very long…"), strings.Index(wide, "Volume"); !(l >= 0 && l < c && c < r) {
+		t.Fatalf("zones out of order (left=%d center=%d right=%d): %q", l, c, r, wide)
+	}
+	if !strings.Contains(wide, ":") {
+		t.Fatalf("clock should be visible on a wide bar: %q", wide)
+	}
+
+	// The clock (priority -1) goes first, then the volume segment collapses
+	// to its short form, then disappears; higher priorities survive.
+	for _, tt := range []struct {
+		width        int
+		want, absent []string
+	}{
+		{45, []string{"LEFT", "A very long…", "Volume 80%"}, []string{":"}},
+		{38, []string{"LEFT", "A very long…", "V80"}, []string{"Volume"}},
+		{33, []string{"LEFT", "A very long…"}, []string{"V80"}},
+		{24, []string{"LEFT"}, []string{"A very"}},
+	} {
+		got := ansi.Strip(bar.View(&App{windowWidth: tt.width}, m))
+		if w := ansi.StringWidth(got); w != tt.width {
+			t.Errorf("width %d: bar is %d columns wide: %q", tt.width, w, got)
+		}
+		for _, s := range tt.want {
+			if !strings.Contains(got, s) {
+				t.Errorf("width %d: missing %q in %q", tt.width, s, got)
+			}
+		}
+		for _, s := range tt.absent {
+			if strings.Contains(got, s) {
+				t.Errorf("width %d: %q should have collapsed in %q", tt.width, s, got)
+			}
+		}
+	}
+}
+
+func TestDefaultStatusBarZonedHitTestingAndSegmentCache(t *testing.T) {
+	clickable := &zonedClickableStatusBarTestComponent{}
+	ticker := &zonedStatusBarTestComponent{text: "tick 1"}
+	bar := &DefaultStatusBar{Components: []StatusBarComponent{ticker, clickable}}
+	options := DefaultOptions()
+	options.StatusBar = bar
+	options.StatusBarPosition = StatusBarTop
+	options.MainMenu = &testMenu{items: []MenuItem{{Title: "Item"}}}
+	options.MainMenuTitle = &MenuItem{Title: "Menu"}
+
+	app := NewApp(options)
+	app.windowWidth = 80
+	app.windowHeight = 24
+	main := NewMain(app, options)
+	app.main = main
+	_, _ = main.Update(tea.WindowSizeMsg{Width: app.windowWidth, Height: app.windowHeight}, app)
+	view := ansi.Strip(main.View(app))
+	row := strings.Split(view, "\n")[main.statusBarRowY(app)]
+
+	bounds := bar.componentBounds[len(bar.componentBounds)-1]
+	if bounds.component != clickable {
+		t.Fatalf("last bounds belong to %T, want the right-zone component", bounds.component)
+	}
+	if got := ansi.Cut(row, bounds.start, bounds.end); got != clickable.View(app, main) {
+		t.Fatalf("bounds [%d,%d) cover %q, want the component text", bounds.start, bounds.end, got)
+	}
+	_, cmd := main.mouseClickHandle(tea.Mouse{X: bounds.start, Y: main.statusBarRowY(app), Button: tea.MouseLeft}, app)
+	if cmd == nil {
+		t.Fatal("right-zone component did not receive the click")
+	}
+	_ = cmd()
+	if clickable.clicks != 1 {
+		t.Fatalf("clicks = %d, want 1", clickable.clicks)
+	}
+
+	// Changing one segment re-renders only that segment.
+	cached := bar.segmentCache[1].full
+	ticker.text = "tick 2"
+	_ = main.View(app)
+	if bar.segmentCache[1].full != cached {
+		t.Fatal("unchanged segment was re-rendered")
+	}
+	if !strings.Contains(ansi.Strip(bar.segmentCache[0].full), "tick 2") {
+		t.Fatal("changed segment was not re-rendered")
+	}
+}
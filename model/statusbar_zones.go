@@ -0,0 +1,317 @@
+package model
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// StatusBarZone selects the part of DefaultStatusBar a component renders in.
+type StatusBarZone int
+
+const (
+	// StatusBarZoneCenter centers the component in the bar (default; this is
+	// where components without a layout have always rendered).
+	StatusBarZoneCenter StatusBarZone = iota
+	// StatusBarZoneLeft places the component right after the breadcrumb.
+	StatusBarZoneLeft
+	// StatusBarZoneRight places the component right-aligned before the clock.
+	StatusBarZoneRight
+)
+
+// StatusBarSegmentLayout describes how a component takes part in the zoned
+// layout of DefaultStatusBar.
+type StatusBarSegmentLayout struct {
+	Zone     StatusBarZone
+	Order    int // Ascending within the zone; ties keep the Components order.
+	MinWidth int // Content is padded to at least this width. 0 = no minimum.
+	MaxWidth int // Content is truncated with "…" beyond this width. 0 = unlimited.
+	// Priority decides what survives on narrow terminals: when the segments
+	// don't fit, the lowest priority segment switches to its ShortView (if
+	// any) and is hidden next, before any higher priority segment shrinks.
+	// The clock is a right-zone segment with priority -1.
+	Priority int
+}
+
+// ZonedStatusBarComponent is an optional extension declaring the zone, order,
+// width limits and priority of a component. Components without it render
+// centered with priority 0.
+type ZonedStatusBarComponent interface {
+	StatusBarComponent
+	Layout() StatusBarSegmentLayout
+}
+
+// ShortStatusBarComponent is an optional extension supplying a compact form
+// used before a low-priority segment is hidden. Interactive components receive
+// coordinates relative to whichever form is rendered.
+type ShortStatusBarComponent interface {
+	StatusBarComponent
+	ShortView(a *App, m *Main) string
+}
+
+// clockSegmentPriority keeps the legacy behaviour of hiding the clock first.
+const clockSegmentPriority = -1
+
+// statusBarSegment is one component (or the clock) as laid out in the bar.
+type statusBarSegment struct {
+	component StatusBarComponent // nil for the clock
+	layout    StatusBarSegmentLayout
+	index     int // position in Components, for stable ordering
+
+	full, short           string // rendered blocks; short is "" without a short form
+	fullWidth, shortWidth int    // rendered block widths
+	fullText, shortText   int    // content widths, for hit-testing
+	padLeft               int    // columns between block start and content
+
+	useShort bool
+	hidden   bool
+}
+
+func (s *statusBarSegment) block() string {
+	if s.useShort {
+		return s.short
+	}
+	return s.full
+}
+
+func (s *statusBarSegment) width() int {
+	if s.hidden {
+		return 0
+	}
+	if s.useShort {
+		return s.shortWidth
+	}
+	return s.fullWidth
+}
+
+func (s *statusBarSegment) textWidth() int {
+	if s.useShort {
+		return s.shortText
+	}
+	return s.fullText
+}
+
+// statusBarSegmentCache holds the rendered blocks of one component so an
+// unchanged segment is not re-rendered when another one changes.
+type statusBarSegmentCache struct {
+	content, shortContent string
+	layout                StatusBarSegmentLayout
+	styleGen              uint64
+	full, short           string
+	fullText, shortText   int
+}
+
+// statusBarComponentInput is the per-frame text of one component.
+type statusBarComponentInput struct {
+	component StatusBarComponent
+	index     int
+	content   string
+	short     string
+	layout    StatusBarSegmentLayout
+}
+
+// collectStatusBarComponents asks every component for its text, short form
+// and layout, and returns them together with a cache key for the whole bar.
+func (d *DefaultStatusBar) collectStatusBarComponents(a *App, m *Main) ([]statusBarComponentInput, string) {
+	inputs := make([]statusBarComponentInput, 0, len(d.Components))
+	var key strings.Builder
+	for i, component := range d.Components {
+		if component == nil {
+			continue
+		}
+		in := statusBarComponentInput{component: component, index: i, content: component.View(a, m)}
+		if zoned, ok := component.(ZonedStatusBarComponent); ok {
+			in.layout = zoned.Layout()
+		}
+		if short, ok := component.(ShortStatusBarComponent); ok {
+			in.short = short.ShortView(a, m)
+		}
+		inputs = append(inputs, in)
+
+		key.WriteString(in.content)
+		key.WriteByte(0)
+		key.WriteString(in.short)
+		key.WriteByte(0)
+		for _, n := range []int{int(in.layout.Zone), in.layout.Order, in.layout.MinWidth, in.layout.MaxWidth, in.layout.Priority} {
+			key.WriteString(strconv.Itoa(n))
+			key.WriteByte(',')
+		}
+		key.WriteByte(0)
+	}
+	return inputs, key.String()
+}
+
+// renderStatusBarSegments turns component inputs into segments, reusing the
+// per-component cache for anything whose text, layout and style are unchanged.
+func (d *DefaultStatusBar) renderStatusBarSegments(ss style.StyleSet, inputs []statusBarComponentInput, gen uint64) []*statusBarSegment {
+	if len(d.segmentCache) != len(d.Components) {
+		d.segmentCache = make([]statusBarSegmentCache, len(d.Components))
+	}
+	block := lipgloss.NewStyle().Inherit(ss.StatusBarText).Padding(0, 1)
+
+	segments := make([]*statusBarSegment, 0, len(inputs))
+	for _, in := range inputs {
+		if in.content == "" {
+			continue
+		}
+		cache := &d.segmentCache[in.index]
+		if cache.styleGen != gen || cache.content != in.content ||
+			cache.shortContent != in.short || cache.layout != in.layout || cache.full == "" {
+			text := fitStatusBarText(in.content, in.layout)
+			*cache = statusBarSegmentCache{
+				content:      in.content,
+				shortContent: in.short,
+				layout:       in.layout,
+				styleGen:     gen,
+				full:         block.Render(text),
+				fullText:     lipgloss.Width(text),
+			}
+			if in.short != "" {
+				short := fitStatusBarText(in.short, StatusBarSegmentLayout{MaxWidth: in.layout.MaxWidth})
+				cache.short = block.Render(short)
+				cache.shortText = lipgloss.Width(short)
+			}
+		}
+		seg := &statusBarSegment{
+			component: in.component,
+			layout:    in.layout,
+			index:     in.index,
+			full:      cache.full,
+			short:     cache.short,
+			fullWidth: lipgloss.Width(cache.full),
+			fullText:  cache.fullText,
+			shortText: cache.shortText,
+			padLeft:   1,
+		}
+		if seg.short != "" {
+			seg.shortWidth = lipgloss.Width(seg.short)
+		}
+		segments = append(segments, seg)
+	}
+	return segments
+}
+
+// fitStatusBarText applies the MaxWidth and MinWidth of a layout to content.
+func fitStatusBarText(content string, layout StatusBarSegmentLayout) string {
+	if layout.MaxWidth > 0 && lipgloss.Width(content) > layout.MaxWidth {
+		content = ansi.Truncate(content, layout.MaxWidth, "…")
+	}
+	if pad := layout.MinWidth - lipgloss.Width(content); pad > 0 {
+		content += strings.Repeat(" ", pad)
+	}
+	return content
+}
+
+// newClockSegment wraps the pre-rendered clock nugget as a right-zone segment.
+func newClockSegment(clock string) *statusBarSegment {
+	return &statusBarSegment{
+		layout:    StatusBarSegmentLayout{Zone: StatusBarZoneRight, Order: math.MaxInt, Priority: clockSegmentPriority},
+		index:     math.MaxInt,
+		full:      clock,
+		fullWidth: lipgloss.Width(clock),
+	}
+}
+
+// fitStatusBarSegments shrinks segments until their total width fits avail:
+// the lowest priority visible segment (the later one on ties) first switches
+// to its short form, if narrower, and is hidden on its next turn.
+func fitStatusBarSegments(segments []*statusBarSegment, avail int) {
+	total := 0
+	for _, seg := range segments {
+		total += seg.width()
+	}
+	for total > avail {
+		var victim *statusBarSegment
+		for _, seg := range segments {
+			if seg.hidden {
+				continue
+			}
+			if victim == nil || seg.layout.Priority < victim.layout.Priority ||
+				(seg.layout.Priority == victim.layout.Priority && seg.index > victim.index) {
+				victim = seg
+			}
+		}
+		if victim == nil {
+			return
+		}
+		total -= victim.width()
+		if !victim.useShort && victim.short != "" && victim.shortWidth < victim.fullWidth {
+			victim.useShort = true
+		} else {
+			victim.hidden = true
+		}
+		total += victim.width()
+	}
+}
+
+// statusBarZoneSegments returns the visible segments of zone in display order.
+func statusBarZoneSegments(segments []*statusBarSegment, zone StatusBarZone) ([]*statusBarSegment, int) {
+	var zoned []*statusBarSegment
+	width := 0
+	for _, seg := range segments {
+		if seg.hidden || seg.layout.Zone != zone {
+			continue
+		}
+		zoned = append(zoned, seg)
+		width += seg.width()
+	}
+	sort.SliceStable(zoned, func(i, j int) bool {
+		return zoned[i].layout.Order < zoned[j].layout.Order
+	})
+	return zoned, width
+}
+
+// layoutStatusBarZones places the left, center and right zones after the
+// first leftUsed columns of a w-wide bar. It returns the zone blocks joined
+// with filler and the bounds of the rendered components, in screen columns.
+func layoutStatusBarZones(ss style.StyleSet, segments []*statusBarSegment, leftUsed, w int) (string, []statusBarComponentBounds) {
+	fitStatusBarSegments(segments, w-leftUsed)
+	left, leftW := statusBarZoneSegments(segments, StatusBarZoneLeft)
+	center, centerW := statusBarZoneSegments(segments, StatusBarZoneCenter)
+	right, rightW := statusBarZoneSegments(segments, StatusBarZoneRight)
+
+	leftEnd := leftUsed + leftW
+	rightStart := max(leftEnd, w-rightW)
+	// Center in the whole bar (not just the free space) like before zones
+	// existed, but never overlap the left or right zone.
+	centerStart := (w - centerW) / 2
+	centerStart = min(centerStart, rightStart-centerW)
+	centerStart = max(centerStart, leftEnd)
+
+	filler := lipgloss.NewStyle().Inherit(ss.StatusBarText)
+	var b strings.Builder
+	var bounds []statusBarComponentBounds
+	x := leftUsed
+	emit := func(segs []*statusBarSegment) {
+		for _, seg := range segs {
+			if seg.component != nil {
+				start := x + seg.padLeft
+				bounds = append(bounds, statusBarComponentBounds{
+					component: seg.component,
+					start:     start,
+					end:       start + seg.textWidth(),
+				})
+			}
+			b.WriteString(seg.block())
+			x += seg.width()
+		}
+	}
+	fill := func(to int) {
+		if to > x {
+			b.WriteString(filler.Width(to - x).Render(""))
+			x = to
+		}
+	}
+
+	emit(left)
+	fill(centerStart)
+	emit(center)
+	fill(rightStart)
+	emit(right)
+	return b.String(), bounds
+}
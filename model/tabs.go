@@ -9,11 +9,68 @@ import (
 	"github.com/anhoder/foxful-cli/style"
 )
 
+// TabBadge is an unread/activity indicator rendered after a tab title.
+// Text shows a count or short label (e.g. "3"); Dot shows "●" when Text is
+// empty. Level selects the themed color (Info, Success, Warning or Error).
+// The zero value renders nothing.
+type TabBadge struct {
+	Text  string
+	Dot   bool
+	Level NotificationLevel
+}
+
+// tabBadgeDot is rendered for a TabBadge with Dot set and no Text.
+const tabBadgeDot = "●"
+
+// IsZero reports whether the badge renders nothing.
+func (b TabBadge) IsZero() bool {
+	return b.Text == "" && !b.Dot
+}
+
+// label returns the badge text, or "" for the zero value.
+func (b TabBadge) label() string {
+	if b.Text != "" {
+		return b.Text
+	}
+	if b.Dot {
+		return tabBadgeDot
+	}
+	return ""
+}
+
+// style returns the bold themed foreground style for the badge level.
+func (b TabBadge) style(ss style.StyleSet) lipgloss.Style {
+	return notificationLevelStyle(ss, b.Level)
+}
+
+// render renders the badge in its level color, or "" for the zero value.
+func (b TabBadge) render(ss style.StyleSet) string {
+	if b.IsZero() {
+		return ""
+	}
+	return b.style(ss).Render(b.label())
+}
+
+// TabOrientation selects how Tabs lays out its headers.
+type TabOrientation int
+
+const (
+	// TabsHorizontal renders a single row of tab headers (default).
+	TabsHorizontal TabOrientation = iota
+	// TabsVertical renders one tab per row in a sidebar column that scrolls
+	// when the tabs exceed its height and can collapse to icons only.
+	TabsVertical
+)
+
 // Tabs renders a horizontal tab bar with keyboard navigation and optional content area.
 // Supports bordered tab bar and content display for a complete TUI panel system.
 // Active tab is highlighted, inactive tabs use the default menu item style.
+// With SetOrientation(TabsVertical) the headers render as a sidebar instead.
 type Tabs struct {
 	titles      []string
+	closable    []bool     // parallel to titles; true renders a clickable close glyph
+	badges      []TabBadge // parallel to titles; zero value renders nothing
+	icons       []string   // parallel to titles; "" renders no icon
 	active      int
 	hoveredIdx  int
 	focused     bool
@@ -22,6 +79,10 @@ type Tabs struct {
 	content     string
 	showBorder  bool
 	borderStyle lipgloss.Border
+
+	orientation TabOrientation
+	collapsed   bool // vertical only: render icons without titles
+	offset      int  // vertical only: index of the first visible tab
 }
 
 // NewTabs creates a new tab bar with the given titles.
@@ -29,7 +90,10 @@ type Tabs struct {
 // By default, borders are enabled with rounded border style.
 func NewTabs(titles []string) *Tabs {
 	return &Tabs{
-		titles:      titles,
+		titles:      append([]string(nil), titles...),
+		closable:    make([]bool, len(titles)),
+		badges:      make([]TabBadge, len(titles)),
+		icons:       make([]string, len(titles)),
 		active:      0,
 		hoveredIdx:  -1,
 		width:       80,
@@ -57,9 +121,34 @@ func (t *Tabs) Focused() bool {
 // SetSize updates the available width and height for rendering.
 // Tabs will truncate titles if width is constrained.
 // When content is set, height determines the content area size.
+// In vertical orientation, width is the expanded sidebar width (including
+// the separator column) and height is the number of visible tab rows.
 func (t *Tabs) SetSize(width, height int) {
 	t.width = width
 	t.height = height
+	t.clampOffset()
+}
+
+// SetOrientation switches between the horizontal tab bar and the vertical sidebar.
+func (t *Tabs) SetOrientation(orientation TabOrientation) {
+	t.orientation = orientation
+	t.ensureActiveVisible()
+}
+
+// Orientation returns the current layout orientation.
+func (t *Tabs) Orientation() TabOrientation {
+	return t.orientation
+}
+
+// SetCollapsed collapses the vertical sidebar to icons only. Tabs without an
+// icon show the first character of their title. Ignored when horizontal.
+func (t *Tabs) SetCollapsed(collapsed bool) {
+	t.collapsed = collapsed
+}
+
+// Collapsed returns whether the vertical sidebar renders icons only.
+func (t *Tabs) Collapsed() bool {
+	return t.collapsed
 }
 
 // SetContent sets the content to be displayed below the tab bar.
@@ -93,6 +182,7 @@ func (t *Tabs) SetActive(index int) {
 		return
 	}
 	t.active = clampInt(index, 0, len(t.titles)-1)
+	t.ensureActiveVisible()
 }
 
 // SetHovered sets the hovered tab index (-1 for none).
@@ -100,12 +190,151 @@ func (t *Tabs) SetHovered(index int) {
 	t.hoveredIdx = index
 }
 
+// Len returns the number of tabs.
+func (t *Tabs) Len() int {
+	return len(t.titles)
+}
+
+// Title returns the title of the tab at index, or "" when out of range.
+func (t *Tabs) Title(index int) string {
+	if index < 0 || index >= len(t.titles) {
+		return ""
+	}
+	return t.titles[index]
+}
+
+// SetTitle replaces the title of the tab at index. Out-of-range indices are ignored.
+func (t *Tabs) SetTitle(index int, title string) {
+	if index < 0 || index >= len(t.titles) {
+		return
+	}
+	t.titles[index] = title
+}
+
+// SetClosable shows or hides the close glyph of the tab at index.
+// Out-of-range indices are ignored.
+func (t *Tabs) SetClosable(index int, closable bool) {
+	if index < 0 || index >= len(t.closable) {
+		return
+	}
+	t.closable[index] = closable
+}
+
+// Closable returns whether the tab at index renders a close glyph.
+func (t *Tabs) Closable(index int) bool {
+	return index >= 0 && index < len(t.closable) && t.closable[index]
+}
+
+// SetBadge sets the badge rendered after the title of the tab at index.
+// Pass the zero TabBadge to clear it. Out-of-range indices are ignored.
+func (t *Tabs) SetBadge(index int, badge TabBadge) {
+	if index < 0 || index >= len(t.badges) {
+		return
+	}
+	t.badges[index] = badge
+}
+
+// Badge returns the badge of the tab at index, or the zero TabBadge.
+func (t *Tabs) Badge(index int) TabBadge {
+	if index < 0 || index >= len(t.badges) {
+		return TabBadge{}
+	}
+	return t.badges[index]
+}
+
+// SetIcon sets the icon rendered before the title of the tab at index, e.g.
+// "♫". Icons are what remains visible when the vertical sidebar is collapsed.
+// Out-of-range indices are ignored.
+func (t *Tabs) SetIcon(index int, icon string) {
+	if index < 0 || index >= len(t.icons) {
+		return
+	}
+	t.icons[index] = icon
+}
+
+// Icon returns the icon of the tab at index, or "".
+func (t *Tabs) Icon(index int) string {
+	if index < 0 || index >= len(t.icons) {
+		return ""
+	}
+	return t.icons[index]
+}
+
+// AddTab appends a tab and returns its index. The active tab is unchanged.
+func (t *Tabs) AddTab(title string, closable bool) int {
+	t.titles = append(t.titles, title)
+	t.closable = append(t.closable, closable)
+	t.badges = append(t.badges, TabBadge{})
+	t.icons = append(t.icons, "")
+	return len(t.titles) - 1
+}
+
+// RemoveTab removes the tab at index. The active index keeps pointing at the
+// same tab when possible; removing the active tab activates its right
+// neighbour, or the new last tab when it was the rightmost one.
+func (t *Tabs) RemoveTab(index int) {
+	if index < 0 || index >= len(t.titles) {
+		return
+	}
+	t.titles = append(t.titles[:index], t.titles[index+1:]...)
+	t.closable = append(t.closable[:index], t.closable[index+1:]...)
+	t.badges = append(t.badges[:index], t.badges[index+1:]...)
+	t.icons = append(t.icons[:index], t.icons[index+1:]...)
+	if index < t.active {
+		t.active--
+	}
+	t.active = clampInt(t.active, 0, max(len(t.titles)-1, 0))
+	t.hoveredIdx = -1
+	t.clampOffset()
+}
+
+// MoveTab moves the tab at from to position to, shifting the tabs in between.
+// The active tab follows its content, so moving the active tab keeps it active.
+func (t *Tabs) MoveTab(from, to int) {
+	if from < 0 || from >= len(t.titles) || to < 0 || to >= len(t.titles) || from == to {
+		return
+	}
+	t.titles = moveSliceItem(t.titles, from, to)
+	t.closable = moveSliceItem(t.closable, from, to)
+	t.badges = moveSliceItem(t.badges, from, to)
+	t.icons = moveSliceItem(t.icons, from, to)
+	t.active = movedIndex(t.active, from, to)
+	t.hoveredIdx = -1
+	t.ensureActiveVisible()
+}
+
+// moveSliceItem moves s[from] to position to in place and returns s.
+func moveSliceItem[T any](s []T, from, to int) []T {
+	item := s[from]
+	if from < to {
+		copy(s[from:to], s[from+1:to+1])
+	} else {
+		copy(s[to+1:from+1], s[to:from])
+	}
+	s[to] = item
+	return s
+}
+
+// movedIndex returns where index ends up after the item at from moves to to.
+func movedIndex(index, from, to int) int {
+	switch {
+	case index == from:
+		return to
+	case from < to && index > from && index <= to:
+		return index - 1
+	case from > to && index >= to && index < from:
+		return index + 1
+	}
+	return index
+}
+
 // Next moves to the next tab, wrapping to the first tab after the last.
 func (t *Tabs) Next() {
 	if len(t.titles) == 0 {
 		return
 	}
 	t.active = (t.active + 1) % len(t.titles)
+	t.ensureActiveVisible()
 }
 
 // Prev moves to the previous tab, wrapping to the last tab before the first.
@@ -117,10 +346,12 @@ func (t *Tabs) Prev() {
 	if t.active < 0 {
 		t.active = len(t.titles) - 1
 	}
+	t.ensureActiveVisible()
 }
 
 // Update handles keyboard input for tab navigation.
 // Supports: left/h (prev), right/l (next), home/g (first), end/G (last), 1-9 (jump to tab).
+// In vertical orientation up/k and down/j move between tabs as well.
 func (t *Tabs) Update(msg tea.Msg) tea.Cmd {
 	if !t.focused {
 		return nil
@@ -133,6 +364,14 @@ func (t *Tabs) Update(msg tea.Msg) tea.Cmd {
 			t.Prev()
 		case "right", "l":
 			t.Next()
+		case "up", "k":
+			if t.orientation == TabsVertical {
+				t.Prev()
+			}
+		case "down", "j":
+			if t.orientation == TabsVertical {
+				t.Next()
+			}
 		case "home", "g":
 			t.SetActive(0)
 		case "end", "G":
@@ -158,6 +397,14 @@ func (t *Tabs) View() string {
 
 	ss := style.CurrentStyleSet()
 
+	if t.orientation == TabsVertical {
+		sidebar := t.renderSidebar(ss, t.hoveredIdx)
+		if t.content == "" {
+			return sidebar
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, sidebar, t.content)
+	}
+
 	// Render tab bar with per-tab borders
 	tabBar := t.renderTabBar(ss, t.hoveredIdx)
 
@@ -170,25 +417,14 @@ func (t *Tabs) View() string {
 	return t.renderWithContent(ss, tabBar)
 }
 
-// renderTabBar renders tabs as individual bordered boxes.
-// The active tab has a notch cut in its bottom border to connect to content below.
-func (t *Tabs) renderTabBar(ss style.StyleSet, hoveredIdx int) string {
-	if !t.showBorder {
-		// Simple rendering without borders
-		var parts []string
-		for i, title := range t.titles {
-			if i == t.active {
-				parts = append(parts, ss.SelectedItem.Render(title))
-			} else {
-				parts = append(parts, ss.MenuItem.Render(title))
-			}
-		}
-		return strings.Join(parts, ss.AppBackground.Render(" "))
-	}
+// tabCloseGlyph is appended to closable tab titles and hit-tested by CloseButtonAt.
+const tabCloseGlyph = "✕"
 
-	// Border definitions matching lipgloss layout example
-	// Active tab has space at bottom to create notch/opening
-	activeTabBorder := lipgloss.Border{
+// tabBorders returns the active and inactive tab borders. The active tab has a
+// space at the bottom to create a notch/opening into the content below;
+// inactive tabs have T-junctions at the bottom to connect to the bar.
+func tabBorders() (active, inactive lipgloss.Border) {
+	active = lipgloss.Border{
 		Top:         "─",
 		Bottom:      " ", // Space creates opening beneath active tab
 		Left:        "│",
@@ -198,9 +434,7 @@ func (t *Tabs) renderTabBar(ss style.StyleSet, hoveredIdx int) string {
 		BottomLeft:  "┘", // Notch corners
 		BottomRight: "└",
 	}
-
-	// Inactive tabs have T-junction at bottom to connect to bar
-	tabBorder := lipgloss.Border{
+	inactive = lipgloss.Border{
 		Top:         "─",
 		Bottom:      "─",
 		Left:        "│",
@@ -210,38 +444,165 @@ func (t *Tabs) renderTabBar(ss style.StyleSet, hoveredIdx int) string {
 		BottomLeft:  "┴", // T-junction into bar
 		BottomRight: "┴",
 	}
+	return active, inactive
+}
+
+// renderTab renders the tab at index, bordered or plain depending on
+// showBorder. Rendering and hit-testing (tabSpans) both go through here so
+// their geometry can never drift apart.
+func (t *Tabs) renderTab(ss style.StyleSet, index, hoveredIdx int) string {
+	title := t.titles[index]
+	if icon := t.Icon(index); icon != "" {
+		title = icon + " " + title
+	}
+	closeGlyph := ""
+	if t.Closable(index) {
+		closeGlyph = " " + tabCloseGlyph
+	}
+	badge := ""
+	if b := t.Badge(index).render(ss); b != "" {
+		badge = " " + b
+	}
+
+	if !t.showBorder {
+		titleStyle := ss.MenuItem
+		if index == t.active {
+			titleStyle = ss.SelectedItem
+		}
+		if badge == "" {
+			return titleStyle.Render(title + closeGlyph)
+		}
+		return titleStyle.Render(title) + badge + titleStyle.Render(closeGlyph)
+	}
 
 	// 只有获得键盘焦点的活动 Tab 使用主色边框；其他边框不指定颜色。
+	activeBorder, inactiveBorder := tabBorders()
+	borderColor := color.Color(lipgloss.NoColor{})
+	if t.focused && index == t.active {
+		borderColor = ss.SelectedItem.GetForeground()
+	}
+
+	titleStyle := ss.MenuItem
+	if (index == t.active && t.focused) || index == hoveredIdx {
+		// 聚焦和 hover 仅使用主色前景，避免 SelectedItem 的背景色。
+		titleStyle = lipgloss.NewStyle().Foreground(ss.SelectedItem.GetForeground())
+	}
+	tabContent := titleStyle.Render(title) + badge
+	if closeGlyph != "" {
+		tabContent += ss.Muted.Render(closeGlyph)
+	}
+
+	border := inactiveBorder
+	if index == t.active {
+		border = activeBorder
+	}
+	return lipgloss.NewStyle().
+		Border(border, true).
+		BorderForeground(borderColor).
+		Padding(0, 1).
+		Render(tabContent)
+}
+
+// tabSpan is the horizontal extent of a rendered tab relative to the left
+// edge of the tab bar. closeStart == closeEnd when the tab has no close glyph.
+type tabSpan struct {
+	start, end           int
+	closeStart, closeEnd int
+}
+
+// tabSpans measures every tab as rendered by renderTab.
+func (t *Tabs) tabSpans(ss style.StyleSet) []tabSpan {
+	spans := make([]tabSpan, len(t.titles))
+	x := 0
+	// Bordered tabs end with one padding column plus the right border.
+	trailing := 2
+	gap := 0
+	if !t.showBorder {
+		trailing = 0
+		gap = 1 // single-space separator between plain tabs
+	}
+	glyphW := lipgloss.Width(tabCloseGlyph)
+	for i := range t.titles {
+		w := lipgloss.Width(t.renderTab(ss, i, -1))
+		spans[i] = tabSpan{start: x, end: x + w}
+		if t.Closable(i) {
+			spans[i].closeEnd = x + w - trailing
+			spans[i].closeStart = spans[i].closeEnd - glyphW
+		}
+		x += w + gap
+	}
+	return spans
+}
+
+// TabAt returns the index of the tab covering column x of the tab bar, or -1.
+func (t *Tabs) TabAt(x int) int {
+	for i, span := range t.tabSpans(style.CurrentStyleSet()) {
+		if x >= span.start && x < span.end {
+			return i
+		}
+	}
+	return -1
+}
+
+// CloseButtonAt returns the index of the tab whose close glyph covers column
+// x of the tab bar, or -1.
+func (t *Tabs) CloseButtonAt(x int) int {
+	for i, span := range t.tabSpans(style.CurrentStyleSet()) {
+		if span.closeEnd > span.closeStart && x >= span.closeStart && x < span.closeEnd {
+			return i
+		}
+	}
+	return -1
+}
+
+// dragTarget returns the index the tab at from should move to while being
+// dragged with the pointer at column x, or -1 when it should stay. A tab only
+// moves once the pointer would still be over it after the move, so dragging
+// a narrow tab across a wide one cannot oscillate between two positions.
+func (t *Tabs) dragTarget(from, x int) int {
+	spans := t.tabSpans(style.CurrentStyleSet())
+	if from < 0 || from >= len(spans) {
+		return -1
+	}
+	width := spans[from].end - spans[from].start
+	for i, span := range spans {
+		if i == from || x < span.start || x >= span.end {
+			continue
+		}
+		if i > from && x >= span.end-width {
+			return i
+		}
+		if i < from && x < span.start+width {
+			return i
+		}
+		return -1
+	}
+	return -1
+}
+
+// renderTabBar renders tabs as individual bordered boxes.
+// The active tab has a notch cut in its bottom border to connect to content below.
+func (t *Tabs) renderTabBar(ss style.StyleSet, hoveredIdx int) string {
+	if !t.showBorder {
+		// Simple rendering without borders
+		var parts []string
+		for i := range t.titles {
+			parts = append(parts, t.renderTab(ss, i, hoveredIdx))
+		}
+		return strings.Join(parts, ss.AppBackground.Render(" "))
+	}
+
+	_, tabBorder := tabBorders()
 	inactiveBorderColor := color.Color(lipgloss.NoColor{})
 	activeBorderColor := inactiveBorderColor
 	if t.focused {
 		activeBorderColor = ss.SelectedItem.GetForeground()
 	}
 
-	tab := lipgloss.NewStyle().
-		Border(tabBorder, true).
-		BorderForeground(inactiveBorderColor).
-		Padding(0, 1)
-
-	activeTab := lipgloss.NewStyle().
-		Border(activeTabBorder, true).
-		BorderForeground(activeBorderColor).
-		Padding(0, 1)
-
 	// Render each tab
 	var renderedTabs []string
-	for i, title := range t.titles {
-		titleStyle := ss.MenuItem
-		if (i == t.active && t.focused) || i == hoveredIdx {
-			// 聚焦和 hover 仅使用主色前景，避免 SelectedItem 的背景色。
-			titleStyle = lipgloss.NewStyle().Foreground(ss.SelectedItem.GetForeground())
-		}
-		tabContent := titleStyle.Render(title)
-		if i == t.active {
-			renderedTabs = append(renderedTabs, activeTab.Render(tabContent))
-		} else {
-			renderedTabs = append(renderedTabs, tab.Render(tabContent))
-		}
+	for i := range t.titles {
+		renderedTabs = append(renderedTabs, t.renderTab(ss, i, hoveredIdx))
 	}
 
 	// Join tabs horizontally (aligned at top)
@@ -0,0 +1,221 @@
+package model
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/style"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Vertical (sidebar) orientation of Tabs: one tab per row, a separator column
+// on the right edge that doubles as the scrollbar, and an icons-only
+// collapsed form. Rendering and hit-testing share sidebarInnerWidth and
+// sidebarCloseSpan so their geometry cannot drift apart.
+
+const (
+	// sidebarMinWidth keeps an expanded sidebar wide enough for an icon and
+	// one title character.
+	sidebarMinWidth = 6
+	// sidebarTrackGlyph and sidebarThumbGlyph draw the separator column; the
+	// thumb only appears when the tabs overflow the sidebar height.
+	sidebarTrackGlyph = "│"
+	sidebarThumbGlyph = "┃"
+)
+
+// SidebarWidth returns the rendered width of the vertical sidebar including
+// its separator column: the width passed to SetSize when expanded, or just
+// enough for the widest icon plus a badge dot when collapsed.
+func (t *Tabs) SidebarWidth() int {
+	if !t.collapsed {
+		return max(t.width, sidebarMinWidth)
+	}
+	iconW := 1
+	for i := range t.titles {
+		iconW = max(iconW, lipgloss.Width(t.sidebarIcon(i)))
+	}
+	// leading space + icon + badge slot + separator
+	return 1 + iconW + 1 + 1
+}
+
+// sidebarInnerWidth is the sidebar width without the separator column.
+func (t *Tabs) sidebarInnerWidth() int {
+	return t.SidebarWidth() - 1
+}
+
+// sidebarIcon returns the icon of the tab at index, falling back to the
+// first character of its title so collapsed tabs stay distinguishable.
+func (t *Tabs) sidebarIcon(index int) string {
+	if icon := t.icons[index]; icon != "" {
+		return icon
+	}
+	for _, r := range t.titles[index] {
+		return string(r)
+	}
+	return " "
+}
+
+// visibleRows returns how many tab rows fit in the sidebar. A non-positive
+// height shows every tab.
+func (t *Tabs) visibleRows() int {
+	if t.height <= 0 {
+		return len(t.titles)
+	}
+	return t.height
+}
+
+// clampOffset keeps the scroll offset within the scrollable range.
+func (t *Tabs) clampOffset() {
+	maxOffset := max(0, len(t.titles)-t.visibleRows())
+	t.offset = clampInt(t.offset, 0, maxOffset)
+}
+
+// ensureActiveVisible scrolls the sidebar just enough to show the active tab.
+func (t *Tabs) ensureActiveVisible() {
+	rows := t.visibleRows()
+	if t.active < t.offset {
+		t.offset = t.active
+	} else if rows > 0 && t.active >= t.offset+rows {
+		t.offset = t.active - rows + 1
+	}
+	t.clampOffset()
+}
+
+// ScrollBy scrolls the vertical sidebar by delta rows without changing the
+// active tab, e.g. in response to the mouse wheel.
+func (t *Tabs) ScrollBy(delta int) {
+	t.offset += delta
+	t.clampOffset()
+}
+
+// TabAtRow returns the index of the tab rendered on row y of the vertical
+// sidebar, or -1.
+func (t *Tabs) TabAtRow(y int) int {
+	if y < 0 || y >= t.visibleRows() {
+		return -1
+	}
+	index := t.offset + y
+	if index >= len(t.titles) {
+		return -1
+	}
+	return index
+}
+
+// CloseButtonAtRow returns the index of the tab whose close glyph covers
+// column x on row y of the vertical sidebar, or -1. Collapsed sidebars have
+// no close glyphs.
+func (t *Tabs) CloseButtonAtRow(x, y int) int {
+	index := t.TabAtRow(y)
+	if index < 0 {
+		return -1
+	}
+	start, end, ok := t.sidebarCloseSpan(index)
+	if !ok || x < start || x >= end {
+		return -1
+	}
+	return index
+}
+
+// sidebarCloseSpan returns the columns of the close glyph of an expanded
+// sidebar row. The glyph is followed by one column of padding.
+func (t *Tabs) sidebarCloseSpan(index int) (start, end int, ok bool) {
+	if t.collapsed || !t.Closable(index) {
+		return 0, 0, false
+	}
+	end = t.sidebarInnerWidth() - 1
+	return end - lipgloss.Width(tabCloseGlyph), end, true
+}
+
+// sidebarRowStyle returns the style of a sidebar row: SelectedItem for the
+// active tab, the primary foreground for the hovered one, MenuItem otherwise.
+func (t *Tabs) sidebarRowStyle(ss style.StyleSet, index, hoveredIdx int) lipgloss.Style {
+	switch {
+	case index == t.active:
+		return ss.SelectedItem
+	case index == hoveredIdx:
+		return lipgloss.NewStyle().Foreground(ss.SelectedItem.GetForeground())
+	default:
+		return ss.MenuItem
+	}
+}
+
+// renderSidebarRow renders the tab at index as one sidebar row of the inner
+// width, without the separator column.
+func (t *Tabs) renderSidebarRow(ss style.StyleSet, index, hoveredIdx int) string {
+	inner := t.sidebarInnerWidth()
+	rowStyle := t.sidebarRowStyle(ss, index, hoveredIdx)
+	bg := rowStyle.GetBackground()
+	badge := t.Badge(index)
+
+	if t.collapsed {
+		icon := t.sidebarIcon(index)
+		icon += strings.Repeat(" ", max(0, inner-2-lipgloss.Width(icon)))
+		slot := rowStyle.Render(" ")
+		if !badge.IsZero() {
+			slot = badge.style(ss).Background(bg).Render(tabBadgeDot)
+		}
+		return rowStyle.Render(" "+icon) + slot
+	}
+
+	left := " "
+	if icon := t.Icon(index); icon != "" {
+		left += icon + " "
+	}
+	rightW := 1 // trailing padding
+	if !badge.IsZero() {
+		rightW += 1 + lipgloss.Width(badge.label())
+	}
+	if t.Closable(index) {
+		rightW += 1 + lipgloss.Width(tabCloseGlyph)
+	}
+	titleW := max(0, inner-lipgloss.Width(left)-rightW)
+	title := ansi.Truncate(t.titles[index], titleW, "…")
+	title += strings.Repeat(" ", max(0, titleW-lipgloss.Width(title)))
+
+	row := rowStyle.Render(left + title)
+	if !badge.IsZero() {
+		row += rowStyle.Render(" ") + badge.style(ss).Background(bg).Render(badge.label())
+	}
+	if t.Closable(index) {
+		row += rowStyle.Render(" ") + ss.Muted.Background(bg).Render(tabCloseGlyph)
+	}
+	row += rowStyle.Render(" ")
+	// Narrow sidebars can't fit the fixed parts; never spill past the edge.
+	return ansi.Truncate(row, inner, "")
+}
+
+// renderSidebar renders the visible tab rows followed by the separator
+// column. When the tabs overflow, the separator shows a scrollbar thumb.
+func (t *Tabs) renderSidebar(ss style.StyleSet, hoveredIdx int) string {
+	rows := t.visibleRows()
+	inner := t.sidebarInnerWidth()
+	track := lipgloss.NewStyle().Foreground(ss.Border.GetForeground())
+	thumb := lipgloss.NewStyle().Foreground(ss.SelectedItem.GetForeground())
+
+	thumbStart, thumbEnd := 0, 0
+	if n := len(t.titles); n > rows && rows > 0 {
+		thumbH := max(1, rows*rows/n)
+		thumbStart = t.offset * rows / n
+		if t.offset >= n-rows {
+			thumbStart = rows - thumbH
+		}
+		thumbEnd = thumbStart + thumbH
+	}
+
+	lines := make([]string, rows)
+	for y := range lines {
+		var row string
+		if index := t.TabAtRow(y); index >= 0 {
+			row = t.renderSidebarRow(ss, index, hoveredIdx)
+		} else {
+			row = ss.AppBackground.Render(strings.Repeat(" ", inner))
+		}
+		if y >= thumbStart && y < thumbEnd {
+			row += thumb.Render(sidebarThumbGlyph)
+		} else {
+			row += track.Render(sidebarTrackGlyph)
+		}
+		lines[y] = row
+	}
+	return strings.Join(lines, "\n")
+}
@@ -3,6 +3,8 @@ package model
 import (
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestTabsNew(t *testing.T) {
@@ -246,3 +248,128 @@ func TestTabsNextPrev(t *testing.T) {
 		t.Errorf("Prev: got %d, want 1", tabs.Active())
 	}
 }
+
+func TestTabsMutationKeepsActiveTab(t *testing.T) {
+	tabs := NewTabs([]string{"A", "B", "C"})
+	tabs.SetActive(1)
+
+	tabs.MoveTab(1, 2)
+	if tabs.Active() != 2 || tabs.Title(2) != "B" {
+		t.Fatalf("after MoveTab(1, 2) active=%d titles=%v", tabs.Active(), tabs.titles)
+	}
+	tabs.MoveTab(0, 2)
+	if tabs.Active() != 1 || tabs.Title(2) != "A" {
+		t.Fatalf("after MoveTab(0, 2) active=%d titles=%v", tabs.Active(), tabs.titles)
+	}
+
+	tabs.RemoveTab(0)
+	if tabs.Active() != 0 || tabs.Title(0) != "B" {
+		t.Fatalf("after RemoveTab(0) active=%d titles=%v", tabs.Active(), tabs.titles)
+	}
+	tabs.RemoveTab(0)
+	if tabs.Active() != 0 || tabs.Len() != 1 || tabs.Title(0) != "A" {
+		t.Fatalf("removing the active tab should activate its neighbour, active=%d titles=%v", tabs.Active(), tabs.titles)
+	}
+}
+
+func TestTabsCloseButtonAt(t *testing.T) {
+	for _, border := range []bool{true, false} {
+		tabs := NewTabs([]string{"One", "Two"})
+		tabs.SetBorder(border)
+		idx := tabs.AddTab("Three", true)
+
+		lines := strings.Split(ansi.Strip(tabs.View()), "\n")
+		row := lines[0]
+		if border {
+			row = lines[1]
+		}
+		col := strings.Index(row, tabCloseGlyph)
+		if col < 0 {
+			t.Fatalf("border=%v: close glyph missing from %q", border, row)
+		}
+		x := ansi.StringWidth(row[:col])
+		if got := tabs.CloseButtonAt(x); got != idx {
+			t.Errorf("border=%v: CloseButtonAt(%d) = %d, want %d", border, x, got, idx)
+		}
+		if got := tabs.TabAt(x); got != idx {
+			t.Errorf("border=%v: TabAt(%d) = %d, want %d", border, x, got, idx)
+		}
+		if got := tabs.CloseButtonAt(0); got != -1 {
+			t.Errorf("border=%v: CloseButtonAt(0) = %d, want -1", border, got)
+		}
+	}
+}
+
+func TestTabsBadgeFollowsTab(t *testing.T) {
+	tabs := NewTabs([]string{"A", "B", "C"})
+	tabs.SetBadge(2, TabBadge{Text: "7"})
+	tabs.MoveTab(2, 0)
+	if tabs.Badge(0).Text != "7" {
+		t.Fatalf("badge did not move with its tab: %+v", tabs.badges)
+	}
+	if !strings.Contains(ansi.Strip(tabs.View()), "C 7") {
+		t.Fatalf("badge not rendered: %q", ansi.Strip(tabs.View()))
+	}
+	tabs.RemoveTab(0)
+	for i := 0; i < tabs.Len(); i++ {
+		if !tabs.Badge(i).IsZero() {
+			t.Fatalf("tab %d kept a removed tab's badge", i)
+		}
+	}
+}
+
+func TestTabsVerticalScrollAndHitTest(t *testing.T) {
+	tabs := NewTabs([]string{"Home", "Library", "Radio", "Podcasts", "Settings"})
+	tabs.SetOrientation(TabsVertical)
+	tabs.SetSize(16, 3)
+	tabs.SetIcon(0, "⌂")
+	tabs.SetClosable(2, true)
+	tabs.Focus()
+
+	lines := strings.Split(ansi.Strip(tabs.View()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], "⌂ Home") || strings.Contains(tabs.View(), "Podcasts") {
+		t.Fatalf("unexpected sidebar rows: %q", lines)
+	}
+	for i, line := range lines {
+		if got := ansi.StringWidth(line); got != 16 {
+			t.Errorf("row %d width = %d, want 16", i, got)
+		}
+	}
+
+	// Keyboard navigation scrolls the active tab into view.
+	tabs.Update(newKeyMsg("down"))
+	tabs.Update(newKeyMsg("j"))
+	tabs.Update(newKeyMsg("j"))
+	if tabs.Active() != 3 || tabs.TabAtRow(2) != 3 {
+		t.Fatalf("active=%d, TabAtRow(2)=%d, want 3, 3", tabs.Active(), tabs.TabAtRow(2))
+	}
+	if got := tabs.CloseButtonAtRow(13, 1); got != 2 {
+		t.Fatalf("CloseButtonAtRow(13, 1) = %d, want 2", got)
+	}
+
+	tabs.ScrollBy(10)
+	if tabs.TabAtRow(0) != 2 || tabs.TabAtRow(3) != -1 {
+		t.Fatalf("ScrollBy should clamp to the last page, TabAtRow(0) = %d", tabs.TabAtRow(0))
+	}
+}
+
+func TestTabsVerticalCollapsed(t *testing.T) {
+	tabs := NewTabs([]string{"Home", "Library"})
+	tabs.SetOrientation(TabsVertical)
+	tabs.SetSize(20, 0)
+	tabs.SetIcon(0, "⌂")
+	tabs.SetClosable(1, true)
+	tabs.SetBadge(1, TabBadge{Text: "4"})
+	tabs.SetCollapsed(true)
+
+	if got := tabs.SidebarWidth(); got != 4 {
+		t.Fatalf("collapsed SidebarWidth() = %d, want 4", got)
+	}
+	view := ansi.Strip(tabs.View())
+	if strings.Contains(view, "Home") || !strings.Contains(view, "⌂") || !strings.Contains(view, "L"+tabBadgeDot) {
+		t.Fatalf("collapsed sidebar should show icons and badge dots only: %q", view)
+	}
+	if got := tabs.CloseButtonAtRow(1, 1); got != -1 {
+		t.Fatalf("collapsed sidebar reported a close button: %d", got)
+	}
+}
@@ -0,0 +1,476 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/anhoder/foxful-cli/util"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TaskID identifies a background task started with App.RunTask.
+type TaskID uint64
+
+// TaskProgress receives progress reports from a background task. All methods
+// are safe to call from the task goroutine; the UI picks the values up on
+// its next frame.
+type TaskProgress interface {
+	// SetPercent reports determinate progress; values are clamped to [0, 1].
+	SetPercent(percent float64)
+	// SetIndeterminate switches back to a spinner without a percentage.
+	SetIndeterminate()
+	// SetStatus sets the short status text shown next to the task.
+	SetStatus(status string)
+}
+
+// TaskInfo is a snapshot of a running background task.
+type TaskInfo struct {
+	ID      TaskID
+	Name    string
+	Percent float64 // in [0, 1]; negative while indeterminate
+	Status  string
+	Started time.Time
+}
+
+// Task is the handle of a background task started with App.RunTask. It
+// implements TaskProgress, which is how the task function reports progress.
+type Task struct {
+	id      TaskID
+	name    string
+	started time.Time
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	mu      sync.Mutex
+	percent float64
+	status  string
+	err     error
+}
+
+var _ TaskProgress = (*Task)(nil)
+
+// ID returns the task ID.
+func (t *Task) ID() TaskID { return t.id }
+
+// Name returns the name passed to App.RunTask.
+func (t *Task) Name() string { return t.name }
+
+// Cancel cancels the context passed to the task function. The task is
+// reported as cancelled once the function returns.
+func (t *Task) Cancel() { t.cancel() }
+
+// Done is closed once the task function has returned.
+func (t *Task) Done() <-chan struct{} { return t.done }
+
+// Err returns the error of a finished task: nil on success,
+// context.Canceled when cancelled. It is nil while the task is running.
+func (t *Task) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+// Info returns a snapshot of the task's progress.
+func (t *Task) Info() TaskInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return TaskInfo{ID: t.id, Name: t.name, Percent: t.percent, Status: t.status, Started: t.started}
+}
+
+// SetPercent implements TaskProgress.
+func (t *Task) SetPercent(percent float64) {
+	if math.IsNaN(percent) {
+		percent = 0
+	}
+	t.mu.Lock()
+	t.percent = math.Max(0, math.Min(1, percent))
+	t.mu.Unlock()
+}
+
+// SetIndeterminate implements TaskProgress.
+func (t *Task) SetIndeterminate() {
+	t.mu.Lock()
+	t.percent = -1
+	t.mu.Unlock()
+}
+
+// SetStatus implements TaskProgress.
+func (t *Task) SetStatus(status string) {
+	t.mu.Lock()
+	t.status = status
+	t.mu.Unlock()
+}
+
+// run calls fn, turning a panic into an error and a cancelled context into
+// context.Canceled, and records the outcome.
+func (t *Task) run(ctx context.Context, fn func(ctx context.Context, progress TaskProgress) error) {
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("task panicked: %v", r)
+			}
+		}()
+		err = fn(ctx, t)
+	}()
+	if err != nil && ctx.Err() != nil {
+		err = context.Canceled
+	}
+	t.cancel()
+
+	t.mu.Lock()
+	t.err = err
+	t.mu.Unlock()
+	close(t.done)
+}
+
+// ---- messages ----
+
+// taskStartedMsg starts the spinner tick loop when the first task starts.
+type taskStartedMsg struct{}
+
+// taskTickMsg advances the task spinner and refreshes the task list.
+type taskTickMsg struct{}
+
+// taskFinishedMsg wakes the loop to post the notifications of finished
+// tasks (see App.finishedTasks).
+type taskFinishedMsg struct{}
+
+// taskTickInterval paces the spinner while tasks are running.
+const taskTickInterval = 120 * time.Millisecond
+
+// taskActionPrefix prefixes the per-task cancel actions of the task list popup.
+const taskActionPrefix = "cancel-task:"
+
+// RunTask runs fn on its own goroutine and tracks it as a background task:
+// running tasks appear in TaskStatusBarComponent and in the ShowTaskList
+// popup, where they can be cancelled. When fn returns, a notification
+// reports success, cancellation or the returned error.
+//
+// Safe to call from goroutines. fn should honour ctx cancellation; any error
+// it returns after cancellation is reported as a cancellation.
+func (a *App) RunTask(name string, fn func(ctx context.Context, progress TaskProgress) error) *Task {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.tasksMu.Lock()
+	a.nextTaskID++
+	task := &Task{
+		id:      a.nextTaskID,
+		name:    name,
+		started: time.Now(),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		percent: -1,
+	}
+	a.tasks = append(a.tasks, task)
+	a.tasksMu.Unlock()
+
+	if a.program != nil {
+		go a.program.Send(taskStartedMsg{})
+	}
+	go func() {
+		task.run(ctx, fn)
+		a.finishTask(task)
+		// Without a program yet, the notification waits for the tick loop
+		// Init starts.
+		if a.program != nil {
+			a.program.Send(taskFinishedMsg{})
+		}
+	}()
+	return task
+}
+
+// Tasks returns snapshots of the running tasks in start order.
+func (a *App) Tasks() []TaskInfo {
+	a.tasksMu.Lock()
+	defer a.tasksMu.Unlock()
+	infos := make([]TaskInfo, len(a.tasks))
+	for i, task := range a.tasks {
+		infos[i] = task.Info()
+	}
+	return infos
+}
+
+// CancelTask cancels the running task with id. Returns false when no such
+// task is running.
+func (a *App) CancelTask(id TaskID) bool {
+	a.tasksMu.Lock()
+	defer a.tasksMu.Unlock()
+	for _, task := range a.tasks {
+		if task.id == id {
+			task.Cancel()
+			return true
+		}
+	}
+	return false
+}
+
+// removeTask drops a finished task from the running list.
+func (a *App) removeTask(id TaskID) {
+	a.tasksMu.Lock()
+	defer a.tasksMu.Unlock()
+	for i, task := range a.tasks {
+		if task.id == id {
+			a.tasks = append(a.tasks[:i], a.tasks[i+1:]...)
+			return
+		}
+	}
+}
+
+// finishTask moves task from the running tasks to the finished ones.
+func (a *App) finishTask(task *Task) {
+	a.removeTask(task.id)
+	a.tasksMu.Lock()
+	a.finishedTasks = append(a.finishedTasks, task)
+	a.tasksMu.Unlock()
+}
+
+// hasFinishedTasks reports whether finished tasks await their notification.
+func (a *App) hasFinishedTasks() bool {
+	a.tasksMu.Lock()
+	defer a.tasksMu.Unlock()
+	return len(a.finishedTasks) > 0
+}
+
+// taskCount returns the number of running tasks.
+func (a *App) taskCount() int {
+	a.tasksMu.Lock()
+	defer a.tasksMu.Unlock()
+	return len(a.tasks)
+}
+
+// handleTaskStarted starts the spinner tick loop unless it is already running.
+func (a *App) handleTaskStarted() tea.Cmd {
+	if a.taskTicking {
+		return nil
+	}
+	a.taskTicking = true
+	return tea.Batch(a.RerenderCmd(false), taskTick())
+}
+
+// handleTaskTick refreshes the task views, posts pending finish
+// notifications and keeps ticking while tasks run.
+func (a *App) handleTaskTick() tea.Cmd {
+	finished := a.handleTaskFinished()
+	if a.taskCount() == 0 {
+		a.taskTicking = false
+		return tea.Batch(finished, a.RerenderCmd(false))
+	}
+	return tea.Batch(finished, a.RerenderCmd(false), taskTick())
+}
+
+func taskTick() tea.Cmd {
+	return tea.Tick(taskTickInterval, func(time.Time) tea.Msg { return taskTickMsg{} })
+}
+
+// handleTaskFinished posts the completion notifications of the finished
+// tasks and refreshes the task list.
+func (a *App) handleTaskFinished() tea.Cmd {
+	a.tasksMu.Lock()
+	finished := a.finishedTasks
+	a.finishedTasks = nil
+	a.tasksMu.Unlock()
+
+	a.refreshTaskList()
+	cmds := make([]tea.Cmd, 0, len(finished))
+	for _, task := range finished {
+		cmds = append(cmds, a.handleShowNotification(taskNotificationSpec(task)))
+	}
+	return tea.Batch(cmds...)
+}
+
+// taskNotificationSpec describes how a finished task ended.
+func taskNotificationSpec(task *Task) NotificationSpec {
+	err := task.Err()
+	switch {
+	case err == nil:
+		return NotificationSpec{Level: NotificationSuccess, Title: task.name, Message: T(MsgTaskCompleted)}
+	case errors.Is(err, context.Canceled):
+		return NotificationSpec{Level: NotificationInfo, Title: task.name, Message: T(MsgTaskCancelled)}
+	default:
+		return NotificationSpec{Level: NotificationError, Title: task.name, Message: err.Error()}
+	}
+}
+
+// taskSpinnerFrame returns the spinner glyph for the current time.
+func taskSpinnerFrame() string {
+	frames := []rune(startupSpinnerFrames)
+	return string(frames[time.Now().UnixMilli()/taskTickInterval.Milliseconds()%int64(len(frames))])
+}
+
+// formatTaskPercent formats a TaskInfo.Percent, or "" when indeterminate.
+func formatTaskPercent(percent float64) string {
+	if percent < 0 {
+		return ""
+	}
+	return strconv.Itoa(int(math.Round(percent*100))) + "%"
+}
+
+// ShowTaskList opens a popup listing the running tasks with a cancel action
+// for each. The list and its actions follow the tasks while open. Must be
+// called from the UI goroutine.
+func (a *App) ShowTaskList() {
+	tasks := a.Tasks()
+	popup, err := NewPopup(PopupSpec{
+		Title:    T(MsgTasks),
+		Content:  renderTaskList(tasks, a.options),
+		Actions:  taskListActions(tasks),
+		MaxWidth: 64,
+		OnResult: func(result PopupResult) {
+			a.taskPopup = nil
+			if id, ok := strings.CutPrefix(result.ActionID, taskActionPrefix); ok {
+				if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+					a.CancelTask(TaskID(n))
+				}
+			}
+		},
+	})
+	if err != nil {
+		return
+	}
+	a.taskPopup, a.taskListShown = popup, tasks
+	a.ShowPopup(popup)
+}
+
+// taskListActions returns a cancel action per task and "Close".
+func taskListActions(tasks []TaskInfo) []PopupAction {
+	actions := make([]PopupAction, 0, len(tasks)+1)
+	for _, task := range tasks {
+		actions = append(actions, PopupAction{
+			ID:    taskActionPrefix + strconv.FormatUint(uint64(task.ID), 10),
+			Label: T(MsgCancel) + " " + taskActionLabel(task.Name),
+		})
+	}
+	return append(actions, PopupAction{ID: "close", Label: T(MsgClose), IsCancel: true})
+}
+
+// taskActionLabel keeps task names usable as single-line action labels.
+func taskActionLabel(name string) string {
+	name = strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == '\n' || r == '\r' || r == '\x1b' || r < ' '
+	}), " ")
+	return ansi.Truncate(name, 24, "…")
+}
+
+// refreshTaskList re-renders the open task list popup when a task started,
+// finished or reported progress. Unchanged lists are left alone so a text
+// selection in the popup survives the ticks.
+func (a *App) refreshTaskList() {
+	if a.taskPopup == nil {
+		return
+	}
+	if !slices.Contains(a.modalStack, Modal(a.taskPopup)) {
+		a.taskPopup, a.taskListShown = nil, nil
+		return
+	}
+	tasks := a.Tasks()
+	if slices.Equal(tasks, a.taskListShown) {
+		return
+	}
+	a.taskListShown = tasks
+	a.taskPopup.setContent(renderTaskList(tasks, a.options))
+	a.taskPopup.setActions(taskListActions(tasks))
+}
+
+// taskListBarWidth is the width of the progress bars in the task list.
+const taskListBarWidth = 20
+
+// renderTaskList renders one block per task: name, status and a progress bar
+// (or a spinner while indeterminate).
+func renderTaskList(tasks []TaskInfo, options *Options) string {
+	if len(tasks) == 0 {
+		return T(MsgNoTasks)
+	}
+	start, end := GetProgressColor()
+	ramp := util.MakeRamp(start, end, taskListBarWidth)
+	blocks := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		header := task.Name
+		if task.Status != "" {
+			header += " · " + task.Status
+		}
+		var bar string
+		if task.Percent < 0 {
+			bar = "…" // no spinner: the list only re-renders on changes
+		} else {
+			full := int(math.Round(task.Percent * taskListBarWidth))
+			bar = Progress(&options.ProgressOptions, taskListBarWidth, full, ramp) + " " + formatTaskPercent(task.Percent)
+		}
+		blocks = append(blocks, header+"\n"+bar)
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// TaskStatusBarComponent is a DefaultStatusBar segment showing a spinner and
+// the number of running background tasks (or the single task's name and
+// percentage). It renders nothing while no task runs, sits in the right zone,
+// shortens to the spinner and count on narrow terminals, and opens the task
+// list popup when clicked. The zero value is ready to use.
+type TaskStatusBarComponent struct {
+	app   *App
+	width int
+}
+
+var (
+	_ InteractiveStatusBarComponent = (*TaskStatusBarComponent)(nil)
+	_ ZonedStatusBarComponent       = (*TaskStatusBarComponent)(nil)
+	_ ShortStatusBarComponent       = (*TaskStatusBarComponent)(nil)
+)
+
+// View implements StatusBarComponent.
+func (c *TaskStatusBarComponent) View(a *App, _ *Main) string {
+	c.app = a
+	tasks := a.Tasks()
+	if len(tasks) == 0 {
+		c.width = 0
+		return ""
+	}
+	view := taskSpinnerFrame() + " "
+	if len(tasks) == 1 {
+		view += tasks[0].Name
+		if percent := formatTaskPercent(tasks[0].Percent); percent != "" {
+			view += " " + percent
+		}
+	} else {
+		view += Tf(MsgTasksRunning, len(tasks))
+	}
+	c.width = lipgloss.Width(view)
+	return view
+}
+
+// ShortView implements ShortStatusBarComponent.
+func (c *TaskStatusBarComponent) ShortView(a *App, _ *Main) string {
+	n := a.taskCount()
+	if n == 0 {
+		return ""
+	}
+	return taskSpinnerFrame() + " " + strconv.Itoa(n)
+}
+
+// Layout implements ZonedStatusBarComponent.
+func (c *TaskStatusBarComponent) Layout() StatusBarSegmentLayout {
+	return StatusBarSegmentLayout{Zone: StatusBarZoneRight, MaxWidth: 32, Priority: 5}
+}
+
+// HandleMouse implements InteractiveStatusBarComponent: a left click opens
+// the task list.
+func (c *TaskStatusBarComponent) HandleMouse(mouse tea.Mouse, x, y int) (bool, tea.Cmd) {
+	if mouse.Button != tea.MouseLeft || c.app == nil || !c.IsMouseOver(x, y) {
+		return false, nil
+	}
+	c.app.ShowTaskList()
+	return true, c.app.RerenderCmd(true)
+}
+
+// IsMouseOver implements InteractiveStatusBarComponent.
+func (c *TaskStatusBarComponent) IsMouseOver(x, _ int) bool {
+	return x >= 0 && x < c.width
+}
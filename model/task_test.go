@@ -0,0 +1,151 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func waitTask(t *testing.T, task *Task) {
+	t.Helper()
+	select {
+	case <-task.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatalf("task %q did not finish", task.Name())
+	}
+}
+
+// waitTaskNotifications delivers finish wake-ups until n notifications show.
+func waitTaskNotifications(t *testing.T, app *App, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(app.notifications) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("notifications = %d, want %d", len(app.notifications), n)
+		}
+		app.Update(taskFinishedMsg{})
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRunTaskProgressCancelAndNotifications(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	started := make(chan struct{})
+	task := app.RunTask("Sync", func(ctx context.Context, progress TaskProgress) error {
+		progress.SetPercent(1.7)
+		progress.SetStatus("uploading")
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	<-started
+
+	infos := app.Tasks()
+	if len(infos) != 1 || infos[0].Percent != 1 || infos[0].Status != "uploading" {
+		t.Fatalf("Tasks() = %+v, want one clamped task with status", infos)
+	}
+	if !app.CancelTask(task.ID()) {
+		t.Fatal("CancelTask returned false for a running task")
+	}
+	waitTask(t, task)
+	if !errors.Is(task.Err(), context.Canceled) {
+		t.Fatalf("Err() = %v, want context.Canceled", task.Err())
+	}
+	// Without a program the notification is not dropped: it waits for the
+	// next wake-up of the loop.
+	waitTaskNotifications(t, app, 1)
+	if app.CancelTask(task.ID()) {
+		t.Fatal("CancelTask returned true for a finished task")
+	}
+
+	failed := app.RunTask("Export", func(context.Context, TaskProgress) error {
+		return errors.New("disk full")
+	})
+	waitTask(t, failed)
+	waitTaskNotifications(t, app, 2)
+	if spec := app.notifications[0].spec; spec.Level != NotificationInfo || spec.Message != T(MsgTaskCancelled) {
+		t.Fatalf("cancelled notification = %+v", spec)
+	}
+	if spec := app.notifications[1].spec; spec.Level != NotificationError || spec.Title != "Export" || spec.Message != "disk full" {
+		t.Fatalf("failed notification = %+v", spec)
+	}
+}
+
+func TestTaskStatusBarComponentAndTaskList(t *testing.T) {
+	app := NewApp(DefaultOptions())
+	component := &TaskStatusBarComponent{}
+	if got := component.View(app, nil); got != "" {
+		t.Fatalf("View without tasks = %q, want empty", got)
+	}
+
+	release := make(chan struct{})
+	block := func(ctx context.Context, progress TaskProgress) error {
+		progress.SetPercent(0.5)
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	first := app.RunTask("Download", block)
+	for app.Tasks()[0].Percent < 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if got := component.View(app, nil); !strings.Contains(got, "Download 50%") {
+		t.Fatalf("View with one task = %q, want name and percent", got)
+	}
+	second := app.RunTask("Index", block)
+	if got := component.View(app, nil); !strings.Contains(got, Tf(MsgTasksRunning, 2)) {
+		t.Fatalf("View with two tasks = %q, want task count", got)
+	}
+	if got := component.ShortView(app, nil); !strings.HasSuffix(got, " 2") {
+		t.Fatalf("ShortView = %q, want spinner and count", got)
+	}
+
+	handled, _ := component.HandleMouse(tea.Mouse{Button: tea.MouseLeft}, 0, 0)
+	if !handled || app.taskPopup == nil || len(app.modalStack) == 0 {
+		t.Fatal("clicking the segment did not open the task list")
+	}
+	popup := app.taskPopup
+	if len(popup.actions) != 3 || !popup.actions[2].IsCancel {
+		t.Fatalf("task list actions = %+v, want one cancel per task and Close", popup.actions)
+	}
+
+	// Unchanged ticks keep a selection; the actions follow the tasks.
+	popup.hasSelection = true
+	app.Update(taskTickMsg{})
+	if !popup.hasSelection {
+		t.Fatal("a tick without task changes should not re-render the list")
+	}
+	app.CancelTask(second.ID())
+	waitTask(t, second)
+	if !errors.Is(second.Err(), context.Canceled) {
+		t.Fatalf("second task Err() = %v, want context.Canceled", second.Err())
+	}
+	waitTaskNotifications(t, app, 1)
+	if len(popup.actions) != 2 || popup.actions[0].ID != taskActionPrefix+"1" {
+		t.Fatalf("finished task should lose its cancel action, got %+v", popup.actions)
+	}
+	third := app.RunTask("Scan", block)
+	app.Update(taskTickMsg{})
+	if len(popup.actions) != 3 || popup.actions[1].ID != taskActionPrefix+"3" {
+		t.Fatalf("a new task should get a cancel action, got %+v", popup.actions)
+	}
+
+	popup.dismissAction(1, PopupDismissAction)
+	popup.complete(app)
+	waitTask(t, third)
+	if !errors.Is(third.Err(), context.Canceled) {
+		t.Fatalf("third task Err() = %v, want context.Canceled", third.Err())
+	}
+	close(release)
+	waitTask(t, first)
+	if first.Err() != nil {
+		t.Fatalf("first task Err() = %v, want nil", first.Err())
+	}
+}
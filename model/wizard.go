@@ -0,0 +1,497 @@
+package model
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/anhoder/foxful-cli/style"
+)
+
+// Wizard is a multi-step input widget: a sequence of Form (or custom) steps
+// under a step indicator, ending with a review step that lists every value
+// before submit. Like Form it is embedded in a host model or used as a
+// PopupBody.
+//
+// Keys: enter or ctrl+n validates the step and moves on (once its async
+// validators have passed), esc goes back (and cancels on the first step),
+// ctrl+s skips a skippable step. Steps keep their state when going back.
+
+const (
+	wizardNextKey = "ctrl+n"
+	wizardBackKey = "esc"
+	wizardSkipKey = "ctrl+s"
+)
+
+// WizardBody is the widget of a step without a Form, e.g. a FilePicker.
+type WizardBody interface {
+	Update(msg tea.Msg) tea.Cmd
+	View() string
+	SetSize(width, height int)
+	Focus()
+	Blur()
+}
+
+// WizardStep is one step of a Wizard.
+type WizardStep struct {
+	Title string
+	// Form collects the step's values; it must pass validation to move on.
+	Form *Form
+	// Body replaces Form for custom steps. Values supplies their values and
+	// Validate, if set, must pass to move on.
+	Body     WizardBody
+	Values   func() map[string]string
+	Validate func() error
+	// Skippable steps can be skipped with ctrl+s; their values are left out.
+	Skippable bool
+	// When shows the step only while it returns true for the values of the
+	// steps before it.
+	When func(values map[string]string) bool
+}
+
+// wizardStepState is the progress of a step.
+type wizardStepState uint8
+
+const (
+	wizardStepTodo wizardStepState = iota
+	wizardStepDone
+	wizardStepSkipped
+)
+
+// Wizard is a multi-step form widget.
+type Wizard struct {
+	steps  []WizardStep
+	states []wizardStepState
+
+	current int   // step shown; len(steps) = the review step
+	err     error // Validate error of the current Body step
+
+	focused   bool
+	submitted bool
+	cancelled bool
+
+	width  int
+	height int
+}
+
+// NewWizard creates a Wizard with the given steps.
+func NewWizard(steps []WizardStep) *Wizard {
+	w := &Wizard{
+		steps:  steps,
+		states: make([]wizardStepState, len(steps)),
+	}
+	for _, step := range steps {
+		if form := step.Form; form != nil {
+			form.onAsyncSubmit = func() { w.stepSubmitted(form) }
+		}
+	}
+	w.current = w.visibleFrom(0, 1)
+	return w
+}
+
+// Focus marks the wizard as focused and focuses the current step.
+func (w *Wizard) Focus() {
+	w.focused = true
+	w.focusStep()
+}
+
+// Blur marks the wizard as blurred.
+func (w *Wizard) Blur() {
+	w.focused = false
+	w.blurStep()
+}
+
+// Focused returns whether the wizard is currently focused.
+func (w *Wizard) Focused() bool {
+	return w.focused
+}
+
+// SetSize sets the wizard's display dimensions.
+func (w *Wizard) SetSize(width, height int) {
+	w.width, w.height = width, height
+	// Indicator, title and blank lines above, error and hints below.
+	bodyHeight := max(height-6, 1)
+	for _, step := range w.steps {
+		if step.Form != nil {
+			step.Form.SetSize(width, bodyHeight)
+		} else if step.Body != nil {
+			step.Body.SetSize(width, bodyHeight)
+		}
+	}
+}
+
+// Current returns the index of the step shown; len(steps) is the review
+// step.
+func (w *Wizard) Current() int {
+	return w.current
+}
+
+// Submitted returns true once the review step has been confirmed.
+func (w *Wizard) Submitted() bool {
+	return w.submitted
+}
+
+// Cancelled returns true if the wizard was left backwards from its first
+// step.
+func (w *Wizard) Cancelled() bool {
+	return w.cancelled
+}
+
+// Values returns the values of all shown, completed steps merged into one
+// map.
+func (w *Wizard) Values() map[string]string {
+	return w.valuesBefore(len(w.steps))
+}
+
+// Reset returns to the first step, clearing the submission and progress but
+// keeping the steps' values.
+func (w *Wizard) Reset() {
+	w.blurStep()
+	clear(w.states)
+	w.submitted, w.cancelled, w.err = false, false, nil
+	w.current = w.visibleFrom(0, 1)
+	if w.focused {
+		w.focusStep()
+	}
+}
+
+// Update handles input events for the wizard.
+func (w *Wizard) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(formValidationMsg); ok {
+		msg.form.applyValidation(msg)
+		return nil
+	}
+	if !w.focused || w.submitted || w.cancelled {
+		return nil
+	}
+
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if !isKey {
+		return w.updateStep(msg)
+	}
+	key := keyMsg.String()
+	if w.current < len(w.steps) {
+		if form := w.steps[w.current].Form; form != nil && form.capturesKey(key) {
+			return form.Update(msg)
+		}
+	}
+
+	switch key {
+	case wizardBackKey:
+		w.back()
+		return nil
+	case wizardNextKey:
+		return w.next()
+	case wizardSkipKey:
+		if w.current < len(w.steps) && w.steps[w.current].Skippable {
+			w.advance(wizardStepSkipped)
+			return nil
+		}
+	case "enter":
+		if w.current == len(w.steps) {
+			w.submitted = true
+			return nil
+		}
+	}
+
+	cmd := w.updateStep(msg)
+	if w.current < len(w.steps) {
+		// Enter on a Form step submits it, which moves on.
+		if form := w.steps[w.current].Form; form != nil && form.Submitted() {
+			form.submitted = false
+			w.advance(wizardStepDone)
+		}
+	}
+	return cmd
+}
+
+// capturesKey keeps esc from closing a hosting popup unless the wizard is on
+// its first step.
+func (w *Wizard) capturesKey(key string) bool {
+	if w.current < len(w.steps) {
+		if form := w.steps[w.current].Form; form != nil && form.capturesKey(key) {
+			return true
+		}
+	}
+	return key == wizardBackKey && w.focused && w.visibleFrom(w.current-1, -1) >= 0
+}
+
+// updateStep forwards msg to the current step's widget.
+func (w *Wizard) updateStep(msg tea.Msg) tea.Cmd {
+	if w.current >= len(w.steps) {
+		return nil
+	}
+	step := w.steps[w.current]
+	switch {
+	case step.Form != nil:
+		return step.Form.Update(msg)
+	case step.Body != nil:
+		return step.Body.Update(msg)
+	}
+	return nil
+}
+
+// next validates the current step and moves on; the review step submits.
+func (w *Wizard) next() tea.Cmd {
+	if w.current >= len(w.steps) {
+		w.submitted = true
+		return nil
+	}
+	step := w.steps[w.current]
+	switch {
+	case step.Form != nil:
+		cmd := step.Form.trySubmit()
+		if !step.Form.Submitted() {
+			return cmd
+		}
+		step.Form.submitted = false
+	case step.Validate != nil:
+		if w.err = step.Validate(); w.err != nil {
+			return nil
+		}
+	}
+	w.advance(wizardStepDone)
+	return nil
+}
+
+// stepSubmitted moves on from a Form step whose submission waited for its
+// async validators, unless the wizard has left the step meanwhile.
+func (w *Wizard) stepSubmitted(form *Form) {
+	form.submitted = false
+	if w.current < len(w.steps) && w.steps[w.current].Form == form && !w.cancelled {
+		w.advance(wizardStepDone)
+	}
+}
+
+// advance marks the current step and shows the next visible one.
+func (w *Wizard) advance(state wizardStepState) {
+	w.blurStep()
+	w.states[w.current] = state
+	w.current = w.visibleFrom(w.current+1, 1)
+	w.focusStep()
+}
+
+// back shows the previous visible step, or cancels on the first one.
+func (w *Wizard) back() {
+	prev := w.visibleFrom(w.current-1, -1)
+	if prev < 0 {
+		w.cancelled = true
+		return
+	}
+	w.blurStep()
+	w.current = prev
+	w.focusStep()
+}
+
+// visibleFrom returns the first step from idx on in direction dir whose
+// condition holds: len(steps) (the review step) or -1 when none.
+func (w *Wizard) visibleFrom(idx, dir int) int {
+	for ; idx >= 0 && idx < len(w.steps); idx += dir {
+		if w.visible(idx) {
+			return idx
+		}
+	}
+	if dir > 0 {
+		return len(w.steps)
+	}
+	return -1
+}
+
+// visible reports whether the When condition of step idx holds.
+func (w *Wizard) visible(idx int) bool {
+	when := w.steps[idx].When
+	return when == nil || when(w.valuesBefore(idx))
+}
+
+// valuesBefore merges the values of the shown, completed steps before idx.
+func (w *Wizard) valuesBefore(idx int) map[string]string {
+	values := make(map[string]string)
+	for i := range idx {
+		if w.states[i] != wizardStepDone || !w.visible(i) {
+			continue
+		}
+		maps.Copy(values, w.stepValues(i))
+	}
+	return values
+}
+
+// stepValues returns the values of step idx.
+func (w *Wizard) stepValues(idx int) map[string]string {
+	step := w.steps[idx]
+	switch {
+	case step.Form != nil:
+		return step.Form.Values()
+	case step.Values != nil:
+		return step.Values()
+	}
+	return nil
+}
+
+func (w *Wizard) focusStep() {
+	w.err = nil
+	if w.current >= len(w.steps) || !w.focused {
+		return
+	}
+	if step := w.steps[w.current]; step.Form != nil {
+		step.Form.Focus()
+	} else if step.Body != nil {
+		step.Body.Focus()
+	}
+}
+
+func (w *Wizard) blurStep() {
+	if w.current >= len(w.steps) {
+		return
+	}
+	if step := w.steps[w.current]; step.Form != nil {
+		step.Form.Blur()
+	} else if step.Body != nil {
+		step.Body.Blur()
+	}
+}
+
+// View renders the wizard.
+func (w *Wizard) View() string {
+	styles := style.CurrentStyleSet()
+	var b strings.Builder
+
+	b.WriteString(w.indicatorView(styles))
+	b.WriteString("\n\n")
+
+	if w.current < len(w.steps) {
+		step := w.steps[w.current]
+		b.WriteString(styles.Title.Render(step.Title))
+		b.WriteString("\n")
+		switch {
+		case step.Form != nil:
+			b.WriteString(step.Form.View())
+		case step.Body != nil:
+			b.WriteString(step.Body.View())
+		}
+	} else {
+		b.WriteString(styles.Title.Render(T(MsgWizardReview)))
+		b.WriteString("\n")
+		b.WriteString(w.reviewView(styles))
+	}
+
+	if w.err != nil {
+		b.WriteString("\n")
+		b.WriteString(styles.Error.Render(w.err.Error()))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(w.hintsView(styles))
+	return b.String()
+}
+
+// indicatorView renders the step indicator: the shown steps numbered, done
+// ones ticked, skipped ones struck out, the current one highlighted.
+func (w *Wizard) indicatorView(styles style.StyleSet) string {
+	var parts []string
+	n := 0
+	for i := range len(w.steps) + 1 {
+		if i < len(w.steps) && !w.visible(i) {
+			continue
+		}
+		n++
+		title := T(MsgWizardReview)
+		if i < len(w.steps) {
+			title = w.steps[i].Title
+		}
+		st := styles.Muted
+		mark := ""
+		switch {
+		case i == w.current:
+			st = styles.SelectedItem
+		case i < len(w.steps) && w.states[i] == wizardStepDone:
+			st, mark = styles.Success, "✓ "
+		case i < len(w.steps) && w.states[i] == wizardStepSkipped:
+			st = styles.Muted.Strikethrough(true)
+		}
+		parts = append(parts, st.Render(" "+mark+strconv.Itoa(n)+". "+title+" "))
+	}
+	return strings.Join(parts, styles.Muted.Render(" › "))
+}
+
+// reviewView lists the values of every shown step for review.
+func (w *Wizard) reviewView(styles style.StyleSet) string {
+	var b strings.Builder
+	for i, step := range w.steps {
+		if !w.visible(i) {
+			continue
+		}
+		b.WriteString("\n")
+		b.WriteString(styles.Subtitle.Render(step.Title))
+		b.WriteString("\n")
+		if w.states[i] == wizardStepSkipped {
+			b.WriteString("  " + styles.Muted.Render(T(MsgWizardSkipped)) + "\n")
+			continue
+		}
+		for _, row := range w.reviewRows(i) {
+			b.WriteString("  " + styles.MenuItem.Render(row[0]+":") + " " + styles.Normal.Render(row[1]) + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// reviewRows returns the label/value pairs of step idx for review. Passwords
+// are masked, checkboxes read yes/no.
+func (w *Wizard) reviewRows(idx int) [][2]string {
+	step := w.steps[idx]
+	var rows [][2]string
+	if f := step.Form; f != nil {
+		for i, field := range f.fields {
+			if f.hidden(i) {
+				continue
+			}
+			value := f.fieldValue(i)
+			switch field.Type {
+			case FieldPassword:
+				value = strings.Repeat("•", min(len([]rune(value)), 8))
+			case FieldCheckbox:
+				value = T(MsgNo)
+				if f.states[i].checked {
+					value = T(MsgYes)
+				}
+			case FieldTextarea:
+				value = strings.ReplaceAll(value, "\n", " ↵ ")
+			}
+			if value == "" {
+				value = "—"
+			}
+			rows = append(rows, [2]string{field.Label, value})
+		}
+		return rows
+	}
+	values := w.stepValues(idx)
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		rows = append(rows, [2]string{key, values[key]})
+	}
+	return rows
+}
+
+// hintsView renders the keys of the current step.
+func (w *Wizard) hintsView(styles style.StyleSet) string {
+	type hint struct{ key, desc string }
+	var hints []hint
+	if w.current < len(w.steps) {
+		hints = append(hints, hint{wizardNextKey, T(MsgWizardNext)})
+		if w.steps[w.current].Skippable {
+			hints = append(hints, hint{wizardSkipKey, T(MsgWizardSkip)})
+		}
+	} else {
+		hints = append(hints, hint{"enter", T(MsgWizardSubmit)})
+	}
+	back := T(MsgWizardBack)
+	if w.visibleFrom(w.current-1, -1) < 0 {
+		back = T(MsgCancel)
+	}
+	hints = append(hints, hint{wizardBackKey, back})
+
+	parts := make([]string, len(hints))
+	for i, h := range hints {
+		parts[i] = styles.HintKey.Render(h.key) + styles.Muted.Render(" "+h.desc)
+	}
+	return strings.Join(parts, styles.Muted.Render("  "))
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestWizardStepsNavigationAndReview(t *testing.T) {
+	login := NewForm([]FormField{
+		{Key: "user", Label: "User", Required: true},
+		{Key: "pass", Label: "Password", Type: FieldPassword},
+	})
+	quality := NewForm([]FormField{
+		{Key: "quality", Label: "Quality", Type: FieldRadio, Options: []string{"standard", "lossless"}, Default: "standard"},
+	})
+	codec := NewForm([]FormField{{Key: "codec", Label: "Codec", Default: "flac"}})
+	dir := ""
+	w := NewWizard([]WizardStep{
+		{Title: "Login", Form: login},
+		{Title: "Quality", Form: quality},
+		{Title: "Codec", Form: codec, When: func(values map[string]string) bool {
+			return values["quality"] == "lossless"
+		}},
+		{
+			Title:     "Folder",
+			Body:      NewFilePicker(t.TempDir()),
+			Values:    func() map[string]string { return map[string]string{"dir": dir} },
+			Validate:  func() error { return errors.New("pick a folder") },
+			Skippable: true,
+		},
+	})
+	w.SetSize(80, 30)
+	w.Focus()
+	esc := tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape})
+	next := tea.KeyPressMsg(tea.Key{Code: 'n', Mod: tea.ModCtrl})
+	skip := tea.KeyPressMsg(tea.Key{Code: 's', Mod: tea.ModCtrl})
+
+	view := ansi.Strip(w.View())
+	if !strings.Contains(view, "1. Login") || !strings.Contains(view, "3. Folder") || strings.Contains(view, "Codec") {
+		t.Errorf("indicator should list the shown steps only:\n%s", view)
+	}
+
+	// Per-step validation.
+	w.Update(keyMsg("enter"))
+	if w.Current() != 0 || login.errors[0] == nil {
+		t.Fatal("an invalid step should not be left")
+	}
+	w.Update(keyMsg("a"))
+	w.Update(keyMsg("enter"))
+	if w.Current() != 1 {
+		t.Fatalf("Current() = %d, want 1", w.Current())
+	}
+
+	// Going back keeps the answers; esc is the wizard's, not the popup's.
+	if !w.capturesKey("esc") {
+		t.Error("esc should be captured past the first step")
+	}
+	w.Update(esc)
+	if w.Current() != 0 || login.inputs[0].Value() != "a" {
+		t.Fatalf("back should show the login step with its value, got step %d", w.Current())
+	}
+	w.Update(next)
+
+	// A conditional step follows the earlier answer.
+	w.Update(keyMsg("right"))
+	w.Update(next)
+	if w.Current() != 2 {
+		t.Fatalf("lossless should show the codec step, got step %d", w.Current())
+	}
+	w.Update(next)
+
+	// A failing custom step stays; a skippable one can be skipped.
+	w.Update(next)
+	if w.Current() != 3 || !strings.Contains(w.View(), "pick a folder") {
+		t.Fatal("a failing Validate should keep the step and show its error")
+	}
+	w.Update(skip)
+	if w.Current() != 4 {
+		t.Fatalf("skip should reach the review step, got %d", w.Current())
+	}
+
+	review := ansi.Strip(w.View())
+	for _, want := range []string{"User: a", "Quality: lossless", "Codec: flac", T(MsgWizardSkipped)} {
+		if !strings.Contains(review, want) {
+			t.Errorf("review should show %q:\n%s", want, review)
+		}
+	}
+	w.Update(keyMsg("enter"))
+	if !w.Submitted() {
+		t.Fatal("enter on the review step should submit")
+	}
+	values := w.Values()
+	if values["user"] != "a" || values["codec"] != "flac" {
+		t.Errorf("Values() = %v", values)
+	}
+	if _, ok := values["dir"]; ok {
+		t.Error("a skipped step's values should be left out")
+	}
+}